@@ -75,6 +75,22 @@ func (c *Client) Version() (*VersionResponse, error) {
 	return versionResponse, nil
 }
 
+// WorkersStatus returns what the background workers and the scheduler are doing (admin only).
+func (c *Client) WorkersStatus() (*WorkerPoolStatus, error) {
+	body, err := c.request.Get("/v1/admin/workers")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var poolStatus *WorkerPoolStatus
+	if err := json.NewDecoder(body).Decode(&poolStatus); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return poolStatus, nil
+}
+
 // Me returns the logged user information.
 func (c *Client) Me() (*User, error) {
 	body, err := c.request.Get("/v1/me")
@@ -219,6 +235,43 @@ func (c *Client) DeleteAPIKey(apiKeyID int64) error {
 	return c.request.Delete(fmt.Sprintf("/v1/api-keys/%d", apiKeyID))
 }
 
+// PublicFeeds returns all public feeds of the authenticated user.
+func (c *Client) PublicFeeds() (PublicFeeds, error) {
+	body, err := c.request.Get("/v1/public-feeds")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var publicFeeds PublicFeeds
+	if err := json.NewDecoder(body).Decode(&publicFeeds); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return publicFeeds, nil
+}
+
+// CreatePublicFeed publishes entries of the authenticated user as a feed.
+func (c *Client) CreatePublicFeed(publicFeedCreationRequest *PublicFeedCreationRequest) (*PublicFeed, error) {
+	body, err := c.request.Post("/v1/public-feeds", publicFeedCreationRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var publicFeed *PublicFeed
+	if err := json.NewDecoder(body).Decode(&publicFeed); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return publicFeed, nil
+}
+
+// DeletePublicFeed removes a public feed, its token is revoked.
+func (c *Client) DeletePublicFeed(publicFeedID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/public-feeds/%d", publicFeedID))
+}
+
 // MarkAllAsRead marks all unread entries as read for a given user.
 func (c *Client) MarkAllAsRead(userID int64) error {
 	_, err := c.request.Put(fmt.Sprintf("/v1/users/%d/mark-all-as-read", userID), nil)
@@ -482,6 +535,49 @@ func (c *Client) UpdateFeed(feedID int64, feedChanges *FeedModificationRequest)
 	return f, nil
 }
 
+// TestFeedFilters evaluates filter rules against the stored entries of a feed without saving them.
+func (c *Client) TestFeedFilters(feedID int64, filterRules *FeedFilterTestRequest) (*FeedFilterTestResult, error) {
+	body, err := c.request.Post(fmt.Sprintf("/v1/feeds/%d/filters/test", feedID), filterRules)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result *FeedFilterTestResult
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return result, nil
+}
+
+// ApplyFeedFilters applies the filter rules to the unread entries of a feed.
+// Blocked entries get the given status ("read" or "removed"), the number of affected entries is returned.
+func (c *Client) ApplyFeedFilters(feedID int64, status string) (int, error) {
+	return c.applyFilters(fmt.Sprintf("/v1/feeds/%d/filters/apply", feedID), status)
+}
+
+// ApplyFilters applies the filter rules to the unread entries of all feeds.
+// Blocked entries get the given status ("read" or "removed"), the number of affected entries is returned.
+func (c *Client) ApplyFilters(status string) (int, error) {
+	return c.applyFilters("/v1/filters/apply", status)
+}
+
+func (c *Client) applyFilters(path, status string) (int, error) {
+	body, err := c.request.Put(path, &EntriesFilterRequest{Status: status})
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	var response EntriesFilterResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return response.EntriesAffected, nil
+}
+
 // MarkFeedAsRead marks all unread entries of the feed as read.
 func (c *Client) MarkFeedAsRead(feedID int64) error {
 	_, err := c.request.Put(fmt.Sprintf("/v1/feeds/%d/mark-all-as-read", feedID), nil)
@@ -521,6 +617,22 @@ func (c *Client) FeedIcon(feedID int64) (*FeedIcon, error) {
 	return feedIcon, nil
 }
 
+// FeedHistory gets the latest refresh attempts of a feed, the most recent first.
+func (c *Client) FeedHistory(feedID int64) (FeedFetches, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/history", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var history FeedFetches
+	if err := json.NewDecoder(body).Decode(&history); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return history, nil
+}
+
 // FeedEntry gets a single feed entry.
 func (c *Client) FeedEntry(feedID, entryID int64) (*Entry, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/entries/%d", feedID, entryID))
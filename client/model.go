@@ -45,6 +45,8 @@ type User struct {
 	MediaPlaybackRate         float64    `json:"media_playback_rate"`
 	BlockFilterEntryRules     string     `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules      string     `json:"keep_filter_entry_rules"`
+	EntryActionRules          string     `json:"entry_action_rules"`
+	DuplicateEntriesPolicy    string     `json:"duplicate_entries_policy"`
 	ExternalFontHosts         string     `json:"external_font_hosts"`
 	AlwaysOpenExternalLinks   bool       `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab bool       `json:"open_external_links_in_new_tab"`
@@ -91,6 +93,8 @@ type UserModificationRequest struct {
 	MediaPlaybackRate         *float64 `json:"media_playback_rate"`
 	BlockFilterEntryRules     *string  `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules      *string  `json:"keep_filter_entry_rules"`
+	EntryActionRules          *string  `json:"entry_action_rules"`
+	DuplicateEntriesPolicy    *string  `json:"duplicate_entries_policy"`
 	ExternalFontHosts         *string  `json:"external_font_hosts"`
 	AlwaysOpenExternalLinks   *bool    `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab *bool    `json:"open_external_links_in_new_tab"`
@@ -101,12 +105,18 @@ type Users []User
 
 // Category represents a feed category.
 type Category struct {
-	ID           int64  `json:"id"`
-	Title        string `json:"title"`
-	UserID       int64  `json:"user_id,omitempty"`
-	HideGlobally bool   `json:"hide_globally,omitempty"`
-	FeedCount    *int   `json:"feed_count,omitempty"`
-	TotalUnread  *int   `json:"total_unread,omitempty"`
+	ID                    int64  `json:"id"`
+	Title                 string `json:"title"`
+	UserID                int64  `json:"user_id,omitempty"`
+	HideGlobally          bool   `json:"hide_globally,omitempty"`
+	ScraperRules          string `json:"scraper_rules,omitempty"`
+	RewriteRules          string `json:"rewrite_rules,omitempty"`
+	UrlRewriteRules       string `json:"urlrewrite_rules,omitempty"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules,omitempty"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules,omitempty"`
+	Crawler               bool   `json:"crawler,omitempty"`
+	FeedCount             *int   `json:"feed_count,omitempty"`
+	TotalUnread           *int   `json:"total_unread,omitempty"`
 }
 
 func (c Category) String() string {
@@ -118,14 +128,26 @@ type Categories []*Category
 
 // CategoryCreationRequest represents the request to create a category.
 type CategoryCreationRequest struct {
-	Title        string `json:"title"`
-	HideGlobally bool   `json:"hide_globally"`
+	Title                 string `json:"title"`
+	HideGlobally          bool   `json:"hide_globally"`
+	ScraperRules          string `json:"scraper_rules"`
+	RewriteRules          string `json:"rewrite_rules"`
+	UrlRewriteRules       string `json:"urlrewrite_rules"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules"`
+	Crawler               bool   `json:"crawler"`
 }
 
 // CategoryModificationRequest represents the request to update a category.
 type CategoryModificationRequest struct {
-	Title        *string `json:"title"`
-	HideGlobally *bool   `json:"hide_globally"`
+	Title                 *string `json:"title"`
+	HideGlobally          *bool   `json:"hide_globally"`
+	ScraperRules          *string `json:"scraper_rules"`
+	RewriteRules          *string `json:"rewrite_rules"`
+	UrlRewriteRules       *string `json:"urlrewrite_rules"`
+	BlockFilterEntryRules *string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  *string `json:"keep_filter_entry_rules"`
+	Crawler               *bool   `json:"crawler"`
 }
 
 // Subscription represents a feed subscription.
@@ -144,88 +166,150 @@ type Subscriptions []*Subscription
 
 // Feed represents a Miniflux feed.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	CheckedAt                   time.Time `json:"checked_at,omitempty"`
-	EtagHeader                  string    `json:"etag_header,omitempty"`
-	LastModifiedHeader          string    `json:"last_modified_header,omitempty"`
-	ParsingErrorMsg             string    `json:"parsing_error_message,omitempty"`
-	ParsingErrorCount           int       `json:"parsing_error_count,omitempty"`
-	Disabled                    bool      `json:"disabled"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	UrlRewriteRules             string    `json:"urlrewrite_rules"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	BlockFilterEntryRules       string    `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        string    `json:"keep_filter_entry_rules"`
-	Crawler                     bool      `json:"crawler"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Category                    *Category `json:"category,omitempty"`
-	HideGlobally                bool      `json:"hide_globally"`
-	DisableHTTP2                bool      `json:"disable_http2"`
-	ProxyURL                    string    `json:"proxy_url"`
+	ID                          int64             `json:"id"`
+	UserID                      int64             `json:"user_id"`
+	FeedURL                     string            `json:"feed_url"`
+	SiteURL                     string            `json:"site_url"`
+	Title                       string            `json:"title"`
+	CheckedAt                   time.Time         `json:"checked_at,omitempty"`
+	EtagHeader                  string            `json:"etag_header,omitempty"`
+	LastModifiedHeader          string            `json:"last_modified_header,omitempty"`
+	ParsingErrorMsg             string            `json:"parsing_error_message,omitempty"`
+	ParsingErrorCount           int               `json:"parsing_error_count,omitempty"`
+	Disabled                    bool              `json:"disabled"`
+	IgnoreHTTPCache             bool              `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool              `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool              `json:"fetch_via_proxy"`
+	ScraperRules                string            `json:"scraper_rules"`
+	RewriteRules                string            `json:"rewrite_rules"`
+	UrlRewriteRules             string            `json:"urlrewrite_rules"`
+	BlocklistRules              string            `json:"blocklist_rules"`
+	KeeplistRules               string            `json:"keeplist_rules"`
+	BlockFilterEntryRules       string            `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        string            `json:"keep_filter_entry_rules"`
+	EntryActionRules            string            `json:"entry_action_rules"`
+	Crawler                     bool              `json:"crawler"`
+	UserAgent                   string            `json:"user_agent"`
+	Cookie                      string            `json:"cookie"`
+	Username                    string            `json:"username"`
+	Password                    string            `json:"password"`
+	Category                    *Category         `json:"category,omitempty"`
+	HideGlobally                bool              `json:"hide_globally"`
+	DisableHTTP2                bool              `json:"disable_http2"`
+	ProxyURL                    string            `json:"proxy_url"`
+	WebPageItemSelector         string            `json:"webpage_item_selector"`
+	WebPageTitleSelector        string            `json:"webpage_title_selector"`
+	WebPageLinkSelector         string            `json:"webpage_link_selector"`
+	WebPageDateSelector         string            `json:"webpage_date_selector"`
+	WebPageContentSelector      string            `json:"webpage_content_selector"`
+	SkipHours                   []int64           `json:"skip_hours"`
+	SkipDays                    []string          `json:"skip_days"`
+	IgnoreSkipHints             bool              `json:"ignore_skip_hints"`
+	ProposedFeedURL             string            `json:"proposed_feed_url"`
+	CustomHeaders               map[string]string `json:"custom_headers"`
+	ClientCertificate           string            `json:"client_certificate"`
+	ClientPrivateKey            string            `json:"client_private_key"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
-	FeedURL                     string `json:"feed_url"`
-	CategoryID                  int64  `json:"category_id"`
-	UserAgent                   string `json:"user_agent"`
-	Cookie                      string `json:"cookie"`
-	Username                    string `json:"username"`
-	Password                    string `json:"password"`
-	Crawler                     bool   `json:"crawler"`
-	Disabled                    bool   `json:"disabled"`
-	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool   `json:"fetch_via_proxy"`
-	ScraperRules                string `json:"scraper_rules"`
-	RewriteRules                string `json:"rewrite_rules"`
-	UrlRewriteRules             string `json:"urlrewrite_rules"`
-	BlocklistRules              string `json:"blocklist_rules"`
-	KeeplistRules               string `json:"keeplist_rules"`
-	BlockFilterEntryRules       string `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        string `json:"keep_filter_entry_rules"`
-	HideGlobally                bool   `json:"hide_globally"`
-	DisableHTTP2                bool   `json:"disable_http2"`
-	ProxyURL                    string `json:"proxy_url"`
+	FeedURL                     string            `json:"feed_url"`
+	CategoryID                  int64             `json:"category_id"`
+	UserAgent                   string            `json:"user_agent"`
+	Cookie                      string            `json:"cookie"`
+	Username                    string            `json:"username"`
+	Password                    string            `json:"password"`
+	Crawler                     bool              `json:"crawler"`
+	Disabled                    bool              `json:"disabled"`
+	IgnoreHTTPCache             bool              `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool              `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool              `json:"fetch_via_proxy"`
+	ScraperRules                string            `json:"scraper_rules"`
+	RewriteRules                string            `json:"rewrite_rules"`
+	UrlRewriteRules             string            `json:"urlrewrite_rules"`
+	BlocklistRules              string            `json:"blocklist_rules"`
+	KeeplistRules               string            `json:"keeplist_rules"`
+	BlockFilterEntryRules       string            `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        string            `json:"keep_filter_entry_rules"`
+	EntryActionRules            string            `json:"entry_action_rules"`
+	HideGlobally                bool              `json:"hide_globally"`
+	DisableHTTP2                bool              `json:"disable_http2"`
+	ProxyURL                    string            `json:"proxy_url"`
+	WebPageItemSelector         string            `json:"webpage_item_selector"`
+	WebPageTitleSelector        string            `json:"webpage_title_selector"`
+	WebPageLinkSelector         string            `json:"webpage_link_selector"`
+	WebPageDateSelector         string            `json:"webpage_date_selector"`
+	WebPageContentSelector      string            `json:"webpage_content_selector"`
+	IgnoreSkipHints             bool              `json:"ignore_skip_hints"`
+	CustomHeaders               map[string]string `json:"custom_headers"`
+	ClientCertificate           string            `json:"client_certificate"`
+	ClientPrivateKey            string            `json:"client_private_key"`
+	BackfillHistory             bool              `json:"backfill_history"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string `json:"feed_url"`
-	SiteURL                     *string `json:"site_url"`
-	Title                       *string `json:"title"`
-	ScraperRules                *string `json:"scraper_rules"`
-	RewriteRules                *string `json:"rewrite_rules"`
-	UrlRewriteRules             *string `json:"urlrewrite_rules"`
-	BlocklistRules              *string `json:"blocklist_rules"`
-	KeeplistRules               *string `json:"keeplist_rules"`
-	BlockFilterEntryRules       *string `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        *string `json:"keep_filter_entry_rules"`
-	Crawler                     *bool   `json:"crawler"`
-	UserAgent                   *string `json:"user_agent"`
-	Cookie                      *string `json:"cookie"`
-	Username                    *string `json:"username"`
-	Password                    *string `json:"password"`
-	CategoryID                  *int64  `json:"category_id"`
-	Disabled                    *bool   `json:"disabled"`
-	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
-	HideGlobally                *bool   `json:"hide_globally"`
-	DisableHTTP2                *bool   `json:"disable_http2"`
-	ProxyURL                    *string `json:"proxy_url"`
+	FeedURL                     *string            `json:"feed_url"`
+	SiteURL                     *string            `json:"site_url"`
+	Title                       *string            `json:"title"`
+	ScraperRules                *string            `json:"scraper_rules"`
+	RewriteRules                *string            `json:"rewrite_rules"`
+	UrlRewriteRules             *string            `json:"urlrewrite_rules"`
+	BlocklistRules              *string            `json:"blocklist_rules"`
+	KeeplistRules               *string            `json:"keeplist_rules"`
+	BlockFilterEntryRules       *string            `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        *string            `json:"keep_filter_entry_rules"`
+	EntryActionRules            *string            `json:"entry_action_rules"`
+	Crawler                     *bool              `json:"crawler"`
+	UserAgent                   *string            `json:"user_agent"`
+	Cookie                      *string            `json:"cookie"`
+	Username                    *string            `json:"username"`
+	Password                    *string            `json:"password"`
+	CategoryID                  *int64             `json:"category_id"`
+	Disabled                    *bool              `json:"disabled"`
+	IgnoreHTTPCache             *bool              `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool              `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool              `json:"fetch_via_proxy"`
+	HideGlobally                *bool              `json:"hide_globally"`
+	DisableHTTP2                *bool              `json:"disable_http2"`
+	ProxyURL                    *string            `json:"proxy_url"`
+	WebPageItemSelector         *string            `json:"webpage_item_selector"`
+	WebPageTitleSelector        *string            `json:"webpage_title_selector"`
+	WebPageLinkSelector         *string            `json:"webpage_link_selector"`
+	WebPageDateSelector         *string            `json:"webpage_date_selector"`
+	WebPageContentSelector      *string            `json:"webpage_content_selector"`
+	IgnoreSkipHints             *bool              `json:"ignore_skip_hints"`
+	CustomHeaders               *map[string]string `json:"custom_headers"`
+	ClientCertificate           *string            `json:"client_certificate"`
+	ClientPrivateKey            *string            `json:"client_private_key"`
+}
+
+// FeedFilterTestRequest represents the filter rules to test against the entries of a feed.
+// Nil rules default to the rules saved on the feed.
+type FeedFilterTestRequest struct {
+	BlocklistRules        *string `json:"blocklist_rules"`
+	KeeplistRules         *string `json:"keeplist_rules"`
+	BlockFilterEntryRules *string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  *string `json:"keep_filter_entry_rules"`
+	Limit                 int     `json:"limit"`
+}
+
+// FeedFilterTestResult represents the outcome of the filter rules on the entries of a feed.
+type FeedFilterTestResult struct {
+	Total   int                    `json:"total"`
+	Blocked int                    `json:"blocked"`
+	Kept    int                    `json:"kept"`
+	Entries []*FeedFilterTestEntry `json:"entries"`
+}
+
+// FeedFilterTestEntry represents the filter decision for one entry.
+type FeedFilterTestEntry struct {
+	EntryID int64  `json:"entry_id"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Blocked bool   `json:"blocked"`
+	Reason  string `json:"reason"`
+	Rule    string `json:"rule"`
 }
 
 // FeedIcon represents the feed icon.
@@ -235,6 +319,24 @@ type FeedIcon struct {
 	Data     string `json:"data"`
 }
 
+// FeedFetch represents a refresh attempt of a feed.
+type FeedFetch struct {
+	ID              int64     `json:"id"`
+	FeedID          int64     `json:"feed_id"`
+	FetchedAt       time.Time `json:"fetched_at"`
+	StatusCode      int       `json:"status_code"`
+	ResponseTimeMs  int64     `json:"response_time_ms"`
+	ResponseSize    int64     `json:"response_size"`
+	NotModified     bool      `json:"not_modified"`
+	NewEntries      int       `json:"new_entries"`
+	ErrorMsg        string    `json:"error_message"`
+	PreviousFeedURL string    `json:"previous_feed_url"`
+	NewFeedURL      string    `json:"new_feed_url"`
+}
+
+// FeedFetches represents a list of refresh attempts.
+type FeedFetches []*FeedFetch
+
 type FeedCounters struct {
 	ReadCounters   map[int64]int `json:"reads"`
 	UnreadCounters map[int64]int `json:"unreads"`
@@ -260,10 +362,66 @@ type Entry struct {
 	ShareCode   string     `json:"share_code"`
 	Enclosures  Enclosures `json:"enclosures,omitempty"`
 	Tags        []string   `json:"tags"`
+	Language    string     `json:"language"`
 	ReadingTime int        `json:"reading_time"`
 	UserID      int64      `json:"user_id"`
 	FeedID      int64      `json:"feed_id"`
 	Starred     bool       `json:"starred"`
+
+	DuplicateOfEntryID int64           `json:"duplicate_of_entry_id,omitempty"`
+	Podcast            *PodcastEpisode `json:"podcast,omitempty"`
+}
+
+// PodcastEpisode represents the Podcasting 2.0 metadata of an entry.
+type PodcastEpisode struct {
+	Season       int                  `json:"season,omitempty"`
+	SeasonName   string               `json:"season_name,omitempty"`
+	Episode      float64              `json:"episode,omitempty"`
+	EpisodeName  string               `json:"episode_name,omitempty"`
+	Transcripts  []*PodcastTranscript `json:"transcripts,omitempty"`
+	ChaptersURL  string               `json:"chapters_url,omitempty"`
+	ChaptersType string               `json:"chapters_type,omitempty"`
+	Chapters     []*PodcastChapter    `json:"chapters,omitempty"`
+	Persons      []*PodcastPerson     `json:"persons,omitempty"`
+	Funding      []*PodcastFunding    `json:"funding,omitempty"`
+	Soundbites   []*PodcastSoundbite  `json:"soundbites,omitempty"`
+}
+
+// PodcastTranscript represents a transcript of a podcast episode.
+type PodcastTranscript struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Language string `json:"language,omitempty"`
+	Rel      string `json:"rel,omitempty"`
+}
+
+// PodcastChapter represents a chapter of a podcast episode.
+type PodcastChapter struct {
+	StartTime float64 `json:"start_time"`
+	Title     string  `json:"title"`
+	URL       string  `json:"url,omitempty"`
+}
+
+// PodcastPerson represents a person taking part in a podcast episode.
+type PodcastPerson struct {
+	Name     string `json:"name"`
+	Role     string `json:"role,omitempty"`
+	Group    string `json:"group,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// PodcastFunding represents a link to support a podcast.
+type PodcastFunding struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// PodcastSoundbite represents a highlight of a podcast episode.
+type PodcastSoundbite struct {
+	StartTime float64 `json:"start_time"`
+	Duration  float64 `json:"duration"`
+	Title     string  `json:"title,omitempty"`
 }
 
 // EntryModificationRequest represents a request to modify an entry.
@@ -321,6 +479,16 @@ type Filter struct {
 	GloballyVisible bool
 }
 
+// EntriesFilterRequest represents the status given to the entries blocked by the filter rules.
+type EntriesFilterRequest struct {
+	Status string `json:"status"`
+}
+
+// EntriesFilterResponse represents the number of entries affected by the filter rules.
+type EntriesFilterResponse struct {
+	EntriesAffected int `json:"entries_affected"`
+}
+
 // EntryResultSet represents the response when fetching entries.
 type EntryResultSet struct {
 	Total   int     `json:"total"`
@@ -338,6 +506,52 @@ type VersionResponse struct {
 	OS        string `json:"os"`
 }
 
+// WorkerStatus represents what a background worker is doing.
+type WorkerStatus struct {
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	Busy          bool       `json:"busy"`
+	JobID         int64      `json:"job_id"`
+	UserID        int64      `json:"user_id"`
+	FeedID        int64      `json:"feed_id"`
+	FeedURL       string     `json:"feed_url"`
+	StartedAt     *time.Time `json:"started_at"`
+	ProcessedJobs int64      `json:"processed_jobs"`
+}
+
+// JobQueueStats represents the number of jobs in the queue by status and priority.
+type JobQueueStats struct {
+	Pending       int64 `json:"pending"`
+	PendingManual int64 `json:"pending_manual"`
+	Running       int64 `json:"running"`
+}
+
+// SchedulerStatus represents the state of the feed scheduler.
+type SchedulerStatus struct {
+	Frequency     int64      `json:"frequency"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	NextRunAt     *time.Time `json:"next_run_at"`
+	LastBatchSize int        `json:"last_batch_size"`
+}
+
+// HostStatus represents the state of a host receiving feed requests.
+type HostStatus struct {
+	Host                string     `json:"host"`
+	ActiveRequests      int        `json:"active_requests"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	PausedUntil         *time.Time `json:"paused_until"`
+	PauseReason         string     `json:"pause_reason"`
+}
+
+// WorkerPoolStatus represents the background workers, the scheduler and the hosts limited by the instance serving the request.
+type WorkerPoolStatus struct {
+	InstanceName string          `json:"instance_name"`
+	Workers      []*WorkerStatus `json:"workers"`
+	Queue        *JobQueueStats  `json:"queue"`
+	Scheduler    SchedulerStatus `json:"scheduler"`
+	Hosts        []*HostStatus   `json:"hosts"`
+}
+
 // APIKey represents an application API key.
 type APIKey struct {
 	ID          int64      `json:"id"`
@@ -356,6 +570,43 @@ type APIKeyCreationRequest struct {
 	Description string `json:"description"`
 }
 
+// Sources of the entries published by a public feed.
+const (
+	PublicFeedSourceCategory = "category"
+	PublicFeedSourceTag      = "tag"
+	PublicFeedSourceStarred  = "starred"
+	PublicFeedSourceSearch   = "search"
+)
+
+// PublicFeed represents entries of a user published as RSS, Atom and JSON feeds.
+// The URLs are indexed by format: "rss", "atom" and "json".
+type PublicFeed struct {
+	ID            int64             `json:"id"`
+	UserID        int64             `json:"user_id"`
+	Token         string            `json:"token"`
+	Title         string            `json:"title"`
+	Source        string            `json:"source"`
+	CategoryID    int64             `json:"category_id,omitempty"`
+	CategoryTitle string            `json:"category_title,omitempty"`
+	Tag           string            `json:"tag,omitempty"`
+	SearchQuery   string            `json:"search_query,omitempty"`
+	LastUsedAt    *time.Time        `json:"last_used_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	URLs          map[string]string `json:"urls"`
+}
+
+// PublicFeeds represents a collection of public feeds.
+type PublicFeeds []*PublicFeed
+
+// PublicFeedCreationRequest represents the request to publish a feed.
+type PublicFeedCreationRequest struct {
+	Title       string `json:"title"`
+	Source      string `json:"source"`
+	CategoryID  int64  `json:"category_id,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	SearchQuery string `json:"search_query,omitempty"`
+}
+
 func SetOptionalField[T any](value T) *T {
 	return &value
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand/v2"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -229,6 +230,50 @@ func TestGetUsersEndpointAsAdmin(t *testing.T) {
 	}
 }
 
+func TestGetWorkersStatusEndpointAsAdmin(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	client := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	poolStatus, err := client.WorkersStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if poolStatus.InstanceName == "" {
+		t.Fatal(`The instance name should not be empty`)
+	}
+
+	if len(poolStatus.Workers) == 0 {
+		t.Fatal(`The workers should be listed`)
+	}
+
+	if poolStatus.Queue == nil {
+		t.Fatal(`The job queue statistics should be returned`)
+	}
+}
+
+func TestGetWorkersStatusEndpointAsRegularUser(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+	if _, err := regularUserClient.WorkersStatus(); err == nil {
+		t.Fatal(`Regular users should not have access to the workers endpoint`)
+	}
+}
+
 func TestGetUsersEndpointAsRegularUser(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -839,6 +884,108 @@ func TestAPIKeysEndpoint(t *testing.T) {
 	}
 }
 
+func TestPublicFeedsEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+	publicFeeds, err := regularUserClient.PublicFeeds()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(publicFeeds) != 0 {
+		t.Fatalf(`Expected no public feeds, got %d`, len(publicFeeds))
+	}
+
+	publicFeed, err := regularUserClient.CreatePublicFeed(&miniflux.PublicFeedCreationRequest{
+		Title:  "Starred Entries",
+		Source: miniflux.PublicFeedSourceStarred,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publicFeed.ID == 0 {
+		t.Fatalf(`Invalid public feed ID, got "%v"`, publicFeed.ID)
+	}
+	if publicFeed.UserID != regularTestUser.ID {
+		t.Fatalf(`Invalid user ID for public feed, got "%v" instead of "%v"`, publicFeed.UserID, regularTestUser.ID)
+	}
+	if publicFeed.Token == "" {
+		t.Fatalf(`Invalid public feed token, got "%v"`, publicFeed.Token)
+	}
+	if publicFeed.Source != miniflux.PublicFeedSourceStarred {
+		t.Fatalf(`Invalid public feed source, got "%v"`, publicFeed.Source)
+	}
+	if len(publicFeed.URLs) != 3 {
+		t.Fatalf(`Expected 3 public feed URLs, got %v`, publicFeed.URLs)
+	}
+
+	// Create a duplicate public feed with the same title.
+	if _, err := regularUserClient.CreatePublicFeed(&miniflux.PublicFeedCreationRequest{Title: "Starred Entries", Source: miniflux.PublicFeedSourceStarred}); err == nil {
+		t.Fatal(`Creating a duplicate public feed with the same title should raise an error`)
+	}
+
+	// Create public feeds with invalid criteria.
+	if _, err := regularUserClient.CreatePublicFeed(&miniflux.PublicFeedCreationRequest{Title: "Tag", Source: miniflux.PublicFeedSourceTag}); err == nil {
+		t.Fatal(`Creating a tag public feed without tag should raise an error`)
+	}
+	if _, err := regularUserClient.CreatePublicFeed(&miniflux.PublicFeedCreationRequest{Title: "Invalid", Source: "invalid"}); err == nil {
+		t.Fatal(`Creating a public feed with an invalid source should raise an error`)
+	}
+
+	publicFeeds, err = regularUserClient.PublicFeeds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(publicFeeds) != 1 {
+		t.Fatalf(`Expected 1 public feed, got %d`, len(publicFeeds))
+	}
+	if publicFeeds[0].Token != publicFeed.Token {
+		t.Fatalf(`Invalid public feed token, got "%v" instead of "%v"`, publicFeeds[0].Token, publicFeed.Token)
+	}
+
+	// The public feed is readable without authentication.
+	publicFeedURL := testConfig.testBaseURL + "/public/" + publicFeed.Token + "/atom"
+	response, err := http.Get(publicFeedURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf(`Expected status code 200 for the public feed, got %d`, response.StatusCode)
+	}
+
+	// Delete the public feed.
+	if err := regularUserClient.DeletePublicFeed(publicFeed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The token is revoked.
+	response, err = http.Get(publicFeedURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf(`Expected status code 404 for a removed public feed, got %d`, response.StatusCode)
+	}
+
+	err = regularUserClient.DeletePublicFeed(publicFeed.ID)
+	if !errors.Is(err, miniflux.ErrNotFound) {
+		t.Fatalf(`Expected "not found" error, got %v`, err)
+	}
+}
+
 func TestMarkUserAsReadEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -1021,6 +1168,77 @@ func TestCreateCategoryWithOptions(t *testing.T) {
 	}
 }
 
+func TestCreateCategoryWithRules(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	newCategory, err := regularUserClient.CreateCategoryWithOptions(&miniflux.CategoryCreationRequest{
+		Title:                 "My category",
+		ScraperRules:          "article",
+		RewriteRules:          "add_youtube_video",
+		BlockFilterEntryRules: "EntryTitle=(?i)sponsored",
+		Crawler:               true,
+	})
+	if err != nil {
+		t.Fatalf(`Creating a category with rules should not raise an error: %v`, err)
+	}
+
+	if newCategory.ScraperRules != "article" {
+		t.Errorf(`Invalid scraper rules, got %q`, newCategory.ScraperRules)
+	}
+
+	if newCategory.RewriteRules != "add_youtube_video" {
+		t.Errorf(`Invalid rewrite rules, got %q`, newCategory.RewriteRules)
+	}
+
+	if newCategory.BlockFilterEntryRules != "EntryTitle=(?i)sponsored" {
+		t.Errorf(`Invalid block filter rules, got %q`, newCategory.BlockFilterEntryRules)
+	}
+
+	if !newCategory.Crawler {
+		t.Errorf(`The crawler should be enabled`)
+	}
+
+	updatedCategory, err := regularUserClient.UpdateCategoryWithOptions(newCategory.ID, &miniflux.CategoryModificationRequest{
+		KeepFilterEntryRules: miniflux.SetOptionalField("EntryTitle=(?i)golang"),
+		Crawler:              miniflux.SetOptionalField(false),
+	})
+	if err != nil {
+		t.Fatalf(`Updating a category with rules should not raise an error: %v`, err)
+	}
+
+	if updatedCategory.KeepFilterEntryRules != "EntryTitle=(?i)golang" {
+		t.Errorf(`Invalid keep filter rules, got %q`, updatedCategory.KeepFilterEntryRules)
+	}
+
+	if updatedCategory.ScraperRules != "article" {
+		t.Errorf(`The scraper rules should not have changed, got %q`, updatedCategory.ScraperRules)
+	}
+
+	if updatedCategory.Crawler {
+		t.Errorf(`The crawler should be disabled`)
+	}
+
+	if _, err := regularUserClient.CreateCategoryWithOptions(&miniflux.CategoryCreationRequest{
+		Title:                 "Invalid rules",
+		BlockFilterEntryRules: "EntryTitle=(",
+	}); err == nil {
+		t.Fatal(`Creating a category with invalid filter rules should raise an error`)
+	}
+}
+
 func TestUpdateCategoryEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -1692,6 +1910,272 @@ func TestUpdateFeedWithInvalidCategory(t *testing.T) {
 	}
 }
 
+func TestUpdateFeedWithWebPageSelectors(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		WebPageItemSelector: miniflux.SetOptionalField("article["),
+	}); err == nil {
+		t.Fatal(`Updating a feed with an invalid CSS selector should raise an error`)
+	}
+
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		WebPageItemSelector:  miniflux.SetOptionalField("article.post"),
+		WebPageTitleSelector: miniflux.SetOptionalField("h2"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.WebPageItemSelector != "article.post" {
+		t.Fatalf(`Invalid item selector, got %q`, updatedFeed.WebPageItemSelector)
+	}
+
+	if updatedFeed.WebPageTitleSelector != "h2" {
+		t.Fatalf(`Invalid title selector, got %q`, updatedFeed.WebPageTitleSelector)
+	}
+}
+
+func TestUpdateFeedWithCustomHeaders(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL:       testConfig.testFeedURL,
+		CustomHeaders: map[string]string{"X-Api-Key": "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		CustomHeaders: miniflux.SetOptionalField(map[string]string{"X Api Key": "secret"}),
+	}); err == nil {
+		t.Fatal(`Updating a feed with an invalid header name should raise an error`)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		ClientCertificate: miniflux.SetOptionalField("invalid"),
+	}); err == nil {
+		t.Fatal(`Updating a feed with an invalid client certificate should raise an error`)
+	}
+
+	feed, err := regularUserClient.Feed(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.CustomHeaders["X-Api-Key"] != "********" {
+		t.Fatalf(`The custom header value should be redacted, got %q`, feed.CustomHeaders["X-Api-Key"])
+	}
+
+	updatedFeed, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		CustomHeaders: miniflux.SetOptionalField(map[string]string{"X-Api-Key": "********", "Authorization": "Bearer token"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updatedFeed.CustomHeaders) != 2 || updatedFeed.CustomHeaders["Authorization"] != "********" {
+		t.Fatalf(`Unexpected custom headers, got %v`, updatedFeed.CustomHeaders)
+	}
+}
+
+func TestTestFeedFiltersEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.TestFeedFilters(feedID, &miniflux.FeedFilterTestRequest{
+		BlockFilterEntryRules: miniflux.SetOptionalField("EntryURL=.*"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total == 0 || result.Blocked != result.Total || result.Kept != 0 {
+		t.Fatalf(`Expected all entries to be blocked, got %d blocked and %d kept out of %d`, result.Blocked, result.Kept, result.Total)
+	}
+
+	if result.Entries[0].Reason != "block_rule" || result.Entries[0].Rule != "EntryURL=.*" {
+		t.Fatalf(`Invalid filter decision, got %+v`, result.Entries[0])
+	}
+
+	feed, err := regularUserClient.Feed(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.BlockFilterEntryRules != "" {
+		t.Fatalf(`The tested rules should not be saved, got %q`, feed.BlockFilterEntryRules)
+	}
+
+	if _, err := regularUserClient.TestFeedFilters(feedID, &miniflux.FeedFilterTestRequest{
+		BlockFilterEntryRules: miniflux.SetOptionalField("EntryTitle =~ \"[invalid\""),
+	}); err == nil {
+		t.Fatal(`Invalid filter rules should be rejected`)
+	}
+}
+
+func TestTestFeedFiltersEndpointWithEnclosureRule(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := regularUserClient.FeedEntries(feedID, &miniflux.Filter{Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entriesWithEnclosures := 0
+	for _, entry := range results.Entries {
+		if len(entry.Enclosures) > 0 {
+			entriesWithEnclosures++
+		}
+	}
+
+	if entriesWithEnclosures == 0 {
+		t.Skip(`Skipping test, missing enclosure in feed.`)
+	}
+
+	result, err := regularUserClient.TestFeedFilters(feedID, &miniflux.FeedFilterTestRequest{
+		BlockFilterEntryRules: miniflux.SetOptionalField("EnclosureURL=.+"),
+		Limit:                 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Blocked != entriesWithEnclosures {
+		t.Fatalf(`Expected the %d entries with an enclosure to be blocked, got %d`, entriesWithEnclosures, result.Blocked)
+	}
+}
+
+func TestApplyFeedFiltersEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := regularUserClient.FeedEntries(feedID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := regularUserClient.UpdateFeed(feedID, &miniflux.FeedModificationRequest{
+		BlockFilterEntryRules: miniflux.SetOptionalField("EntryURL=.*"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := regularUserClient.ApplyFeedFilters(feedID, "invalid"); err == nil {
+		t.Fatal(`An invalid status should be rejected`)
+	}
+
+	affected, err := regularUserClient.ApplyFeedFilters(feedID, "read")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected != results.Total {
+		t.Fatalf(`Expected %d entries to be affected, got %d`, results.Total, affected)
+	}
+
+	results, err = regularUserClient.FeedEntries(feedID, &miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`Expected no unread entries, got %d`, results.Total)
+	}
+}
+
 func TestMarkFeedAsReadEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -1961,6 +2445,57 @@ func TestGetFeedIconWithInexistingFeedID(t *testing.T) {
 	}
 }
 
+func TestGetFeedHistory(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := regularUserClient.FeedHistory(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if history == nil {
+		t.Fatalf(`The history should be an empty list, got nil`)
+	}
+
+	for _, feedFetch := range history {
+		if feedFetch.FeedID != feedID {
+			t.Fatalf(`Invalid feed ID, got %d instead of %d`, feedFetch.FeedID, feedID)
+		}
+	}
+}
+
+func TestGetFeedHistoryWithInexistingFeedID(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	client := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+	if _, err := client.FeedHistory(123456789); err == nil {
+		t.Fatalf(`Fetching the history of an inexisting feed should raise an error`)
+	}
+}
+
 func TestGetFeedsEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
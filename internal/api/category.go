@@ -158,7 +158,10 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) {
 		slog.Int("nb_jobs", len(jobs)),
 	)
 
-	go h.pool.Push(jobs)
+	if err := h.pool.Push(jobs, model.JobPriorityManual); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
 
 	json.NoContent(w, r)
 }
@@ -5,6 +5,8 @@ package api // import "miniflux.app/v2/internal/api"
 
 import (
 	json_parser "encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/validator"
 )
@@ -59,7 +62,7 @@ func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	localizedError := feedHandler.RefreshFeed(h.store, userID, feedID, false)
+	localizedError := feedHandler.RefreshFeed(r.Context(), h.store, userID, feedID, false)
 	if localizedError != nil {
 		json.ServerError(w, r, localizedError.Error())
 		return
@@ -90,7 +93,10 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 		slog.Int("nb_jobs", len(jobs)),
 	)
 
-	go h.pool.Push(jobs)
+	if err := h.pool.Push(jobs, model.JobPriorityManual); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
 
 	json.NoContent(w, r)
 }
@@ -116,12 +122,23 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The URLs of a newsletter feed are email addresses, they are not editable.
+	if originalFeed.IsNewsletter() {
+		feedModificationRequest.FeedURL = nil
+		feedModificationRequest.SiteURL = nil
+	}
+
 	if validationErr := validator.ValidateFeedModification(h.store, userID, originalFeed.ID, &feedModificationRequest); validationErr != nil {
 		json.BadRequest(w, r, validationErr.Error())
 		return
 	}
 
 	feedModificationRequest.Patch(originalFeed)
+	if validationErr := validator.ValidateFeedClientCertificate(originalFeed.ClientCertificate, originalFeed.ClientPrivateKey); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
 	originalFeed.ResetErrorCounter()
 	if err := h.store.UpdateFeed(originalFeed); err != nil {
 		json.ServerError(w, r, err)
@@ -134,9 +151,125 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	originalFeed.WithRedactedSecrets()
 	json.Created(w, r, originalFeed)
 }
 
+func (h *handler) testFeedFilters(w http.ResponseWriter, r *http.Request) {
+	var feedFilterTestRequest model.FeedFilterTestRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&feedFilterTestRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if feedFilterTestRequest.Limit == 0 {
+		feedFilterTestRequest.Limit = 100
+	}
+
+	if err := validator.ValidateRange(0, feedFilterTestRequest.Limit); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateFeedFilterTest(&feedFilterTestRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	feed, err := h.store.FeedByID(user.ID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	feedFilterTestRequest.Patch(feed)
+
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithFeedID(feed.ID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("published_at", "desc")
+	builder.WithLimit(feedFilterTestRequest.Limit)
+	builder.WithEnclosures()
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	blockRules := filter.ParseRules(user.BlockFilterEntryRules, feed.EffectiveBlockFilterEntryRules()).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
+	keepRules := filter.ParseRules(user.KeepFilterEntryRules, feed.EffectiveKeepFilterEntryRules()).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
+
+	response := &feedFilterTestResponse{Total: len(entries), Entries: make([]*feedFilterTestEntry, 0, len(entries))}
+	for _, entry := range entries {
+		result := filter.EvaluateEntry(blockRules, keepRules, feed, entry)
+		if result.Blocked {
+			response.Blocked++
+		} else {
+			response.Kept++
+		}
+
+		response.Entries = append(response.Entries, &feedFilterTestEntry{
+			EntryID: entry.ID,
+			Title:   entry.Title,
+			URL:     entry.URL,
+			Blocked: result.Blocked,
+			Reason:  result.Reason,
+			Rule:    result.Rule,
+		})
+	}
+
+	json.OK(w, r, response)
+}
+
+func (h *handler) applyFeedFilters(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	userID := request.UserID(r)
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	h.applyFilters(w, r, userID, feedID)
+}
+
+func (h *handler) applyUserFilters(w http.ResponseWriter, r *http.Request) {
+	h.applyFilters(w, r, request.UserID(r), 0)
+}
+
+func (h *handler) applyFilters(w http.ResponseWriter, r *http.Request, userID, feedID int64) {
+	entriesFilterRequest := model.EntriesFilterRequest{Status: model.EntryStatusRemoved}
+	if err := json_parser.NewDecoder(r.Body).Decode(&entriesFilterRequest); err != nil && !errors.Is(err, io.EOF) {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if err := validator.ValidateEntriesFilterRequest(&entriesFilterRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	affected, err := feedHandler.ApplyFilterRules(h.store, userID, feedID, entriesFilterRequest.Status)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, &entriesFilterResponse{EntriesAffected: affected})
+}
+
 func (h *handler) markFeedAsRead(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
@@ -175,6 +308,7 @@ func (h *handler) getCategoryFeeds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	feeds.WithRedactedSecrets()
 	json.OK(w, r, feeds)
 }
 
@@ -185,6 +319,7 @@ func (h *handler) getFeeds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	feeds.WithRedactedSecrets()
 	json.OK(w, r, feeds)
 }
 
@@ -211,9 +346,34 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	feed.WithRedactedSecrets()
 	json.OK(w, r, feed)
 }
 
+func (h *handler) getFeedHistory(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	userID := request.UserID(r)
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	limit := request.QueryIntParam(r, "limit", config.Opts.CleanupFetchHistorySize())
+	if limit <= 0 {
+		json.BadRequest(w, r, errors.New("limit value should be > 0"))
+		return
+	}
+
+	history, err := h.store.FeedFetchHistory(userID, feedID, limit)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, history)
+}
+
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
@@ -22,6 +22,26 @@ type feedCreationResponse struct {
 	FeedID int64 `json:"feed_id"`
 }
 
+type feedFilterTestResponse struct {
+	Total   int                    `json:"total"`
+	Blocked int                    `json:"blocked"`
+	Kept    int                    `json:"kept"`
+	Entries []*feedFilterTestEntry `json:"entries"`
+}
+
+type feedFilterTestEntry struct {
+	EntryID int64  `json:"entry_id"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Blocked bool   `json:"blocked"`
+	Reason  string `json:"reason"`
+	Rule    string `json:"rule"`
+}
+
+type entriesFilterResponse struct {
+	EntriesAffected int `json:"entries_affected"`
+}
+
 type versionResponse struct {
 	Version   string `json:"version"`
 	Commit    string `json:"commit"`
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) createPublicFeed(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var publicFeedCreationRequest model.PublicFeedCreationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&publicFeedCreationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	publicFeedCreationRequest.Title = strings.TrimSpace(publicFeedCreationRequest.Title)
+	publicFeedCreationRequest.Tag = strings.TrimSpace(publicFeedCreationRequest.Tag)
+	publicFeedCreationRequest.SearchQuery = strings.TrimSpace(publicFeedCreationRequest.SearchQuery)
+
+	if validationErr := validator.ValidatePublicFeedCreation(h.store, userID, &publicFeedCreationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	publicFeed, err := h.store.CreatePublicFeed(userID, &publicFeedCreationRequest)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, publicFeed)
+}
+
+func (h *handler) getPublicFeeds(w http.ResponseWriter, r *http.Request) {
+	publicFeeds, err := h.store.PublicFeeds(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	json.OK(w, r, publicFeeds)
+}
+
+func (h *handler) deletePublicFeed(w http.ResponseWriter, r *http.Request) {
+	publicFeedID := request.RouteInt64Param(r, "publicFeedID")
+
+	if err := h.store.RemovePublicFeed(request.UserID(r), publicFeedID); err != nil {
+		if errors.Is(err, storage.ErrPublicFeedNotFound) {
+			json.NotFound(w, r)
+			return
+		}
+		json.ServerError(w, r, err)
+		return
+	}
+	json.NoContent(w, r)
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+func applyFilters(store *storage.Storage, status string) {
+	if err := validator.ValidateEntriesFilterRequest(&model.EntriesFilterRequest{Status: status}); err != nil {
+		printErrorAndExit(err)
+	}
+
+	users, err := store.Users()
+	if err != nil {
+		printErrorAndExit(fmt.Errorf("unable to fetch users: %w", err))
+	}
+
+	total := 0
+	for _, user := range users {
+		affected, err := feedHandler.ApplyFilterRules(store, user.ID, 0, status)
+		if err != nil {
+			printErrorAndExit(fmt.Errorf("unable to apply filter rules for user %q: %w", user.Username, err))
+		}
+
+		if affected > 0 {
+			fmt.Printf("%s: %d entries marked as %s\n", user.Username, affected, status)
+		}
+		total += affected
+	}
+
+	fmt.Printf("%d entries marked as %s\n", total, status)
+}
@@ -54,10 +54,24 @@ func runCleanupTasks(store *storage.Storage) {
 			slog.Int64("removed_entries_enclosures_deleted", enclosuresAffected))
 	}
 
+	if attachmentsAffected, err := store.DeleteRemovedEntriesNewsletterAttachments(); err != nil {
+		slog.Error("Unable to delete newsletter attachments from removed entries", slog.Any("error", err))
+	} else {
+		slog.Info("Deleting newsletter attachments from removed entries completed",
+			slog.Int64("removed_entries_newsletter_attachments_deleted", attachmentsAffected))
+	}
+
 	if contentAffected, err := store.ClearRemovedEntriesContent(config.Opts.CleanupArchiveBatchSize()); err != nil {
 		slog.Error("Unable to clear content from removed entries", slog.Any("error", err))
 	} else {
 		slog.Info("Clearing content from removed entries completed",
 			slog.Int64("removed_entries_content_cleared", contentAffected))
 	}
+
+	if rowsAffected, err := store.CleanFeedFetchHistory(config.Opts.CleanupFetchHistorySize()); err != nil {
+		slog.Error("Unable to clean feed fetch history", slog.Any("error", err))
+	} else {
+		slog.Info("Feed fetch history cleanup completed",
+			slog.Int64("feed_fetches_removed", rowsAffected))
+	}
 }
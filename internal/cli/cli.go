@@ -14,6 +14,7 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/database"
 	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/static"
 	"miniflux.app/v2/internal/version"
@@ -35,6 +36,7 @@ const (
 	flagRunCleanupTasksHelp  = "Run cleanup tasks (delete old sessions and archives old entries)"
 	flagExportUserFeedsHelp  = "Export user feeds (provide the username as argument)"
 	flagResetNextCheckAtHelp = "Reset the next check time for all feeds"
+	flagApplyFiltersHelp     = `Apply filter rules to the existing unread entries of all users (provide "read" or "removed" as the status of blocked entries)`
 )
 
 // Parse parses command line arguments.
@@ -56,6 +58,7 @@ func Parse() {
 		flagRefreshFeeds         bool
 		flagRunCleanupTasks      bool
 		flagExportUserFeeds      string
+		flagApplyFilters         string
 	)
 
 	flag.BoolVar(&flagInfo, "info", false, flagInfoHelp)
@@ -76,6 +79,7 @@ func Parse() {
 	flag.BoolVar(&flagRefreshFeeds, "refresh-feeds", false, flagRefreshFeedsHelp)
 	flag.BoolVar(&flagRunCleanupTasks, "run-cleanup-tasks", false, flagRunCleanupTasksHelp)
 	flag.StringVar(&flagExportUserFeeds, "export-user-feeds", "", flagExportUserFeedsHelp)
+	flag.StringVar(&flagApplyFilters, "apply-filters", "", flagApplyFiltersHelp)
 	flag.Parse()
 
 	cfg := config.NewConfigParser()
@@ -133,7 +137,7 @@ func Parse() {
 		defer logFileHandler.(*os.File).Close()
 	}
 
-	if err := InitializeDefaultLogger(config.Opts.LogLevel(), logFileHandler, config.Opts.LogFormat(), config.Opts.LogDateTime()); err != nil {
+	if err := InitializeDefaultLogger(config.Opts.LogLevel(), logFileHandler, config.Opts.LogFormat(), config.Opts.LogDateTime(), config.Opts.InstanceName()); err != nil {
 		printErrorAndExit(err)
 	}
 
@@ -211,6 +215,11 @@ func Parse() {
 		return
 	}
 
+	if flagApplyFilters != "" {
+		applyFilters(store, flagApplyFilters)
+		return
+	}
+
 	if flagFlushSessions {
 		flushSessions(store)
 		return
@@ -249,6 +258,12 @@ func Parse() {
 		}
 	}
 
+	fetcher.HostLimiterInstance = fetcher.NewHostLimiter(
+		config.Opts.PollingLimitPerHost(),
+		config.Opts.PollingHostFailureLimit(),
+		config.Opts.PollingHostPauseDuration(),
+	)
+
 	if flagRefreshFeeds {
 		refreshFeeds(store)
 		return
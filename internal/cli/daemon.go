@@ -5,6 +5,7 @@ package cli // import "miniflux.app/v2/internal/cli"
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/server"
 	"miniflux.app/v2/internal/metric"
+	"miniflux.app/v2/internal/newsletter"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/systemd"
 	"miniflux.app/v2/internal/worker"
@@ -27,10 +29,14 @@ func startDaemon(store *storage.Storage) {
 	signal.Notify(stop, os.Interrupt)
 	signal.Notify(stop, syscall.SIGTERM)
 
-	pool := worker.NewPool(store, config.Opts.WorkerPoolSize())
+	// The background tasks stop taking new work when this context is canceled.
+	backgroundCtx, stopBackgroundTasks := context.WithCancel(context.Background())
+	defer stopBackgroundTasks()
+
+	pool := worker.NewPool(backgroundCtx, store, config.Opts.WorkerPoolSize())
 
 	if config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode() {
-		runScheduler(store, pool)
+		runScheduler(backgroundCtx, store, pool)
 	}
 
 	var httpServers []*http.Server
@@ -38,6 +44,21 @@ func startDaemon(store *storage.Storage) {
 		httpServers = server.StartWebServer(store, pool)
 	}
 
+	var newsletterServer *newsletter.Server
+	if config.Opts.HasNewsletterListener() && !config.Opts.HasMaintenanceMode() {
+		newsletterServer = newsletter.NewServer(store)
+		go func() {
+			slog.Info("Starting newsletter server",
+				slog.String("listen_address", config.Opts.NewsletterListenAddr()),
+				slog.String("protocol", config.Opts.NewsletterProtocol()),
+				slog.String("domain", config.Opts.NewsletterDomain()),
+			)
+			if err := newsletterServer.ListenAndServe(config.Opts.NewsletterListenAddr()); err != newsletter.ErrServerClosed {
+				printErrorAndExit(fmt.Errorf("newsletter server failed to start on %s: %v", config.Opts.NewsletterListenAddr(), err))
+			}
+		}()
+	}
+
 	if config.Opts.HasMetricsCollector() {
 		collector := metric.NewCollector(store, config.Opts.MetricsRefreshInterval())
 		go collector.GatherStorageMetrics()
@@ -75,6 +96,12 @@ func startDaemon(store *storage.Storage) {
 
 	<-stop
 	slog.Debug("Shutting down the process")
+	stopBackgroundTasks()
+
+	// The workers complete their current job while the HTTP servers are shut down.
+	workersCtx, cancelWorkers := context.WithTimeout(context.Background(), config.Opts.WorkerShutdownTimeout())
+	defer cancelWorkers()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -92,5 +119,34 @@ func startDaemon(store *storage.Storage) {
 		slog.Debug("No HTTP servers to shut down.")
 	}
 
+	if newsletterServer != nil {
+		slog.Debug("Shutting down newsletter server...")
+		if err := newsletterServer.Shutdown(ctx); err != nil {
+			slog.Error("Newsletter server shutdown error", slog.Any("error", err))
+		}
+	}
+
+	slog.Debug("Waiting for the background workers to complete their current job...")
+	interruptedWorkers := pool.Wait(workersCtx)
+	for _, interruptedWorker := range interruptedWorkers {
+		slog.Warn("Feed refresh interrupted by the shutdown, the job is put back in the queue",
+			slog.String("worker_name", interruptedWorker.Name),
+			slog.Int64("job_id", interruptedWorker.JobID),
+			slog.Int64("user_id", interruptedWorker.UserID),
+			slog.Int64("feed_id", interruptedWorker.FeedID),
+			slog.String("feed_url", interruptedWorker.FeedURL),
+			slog.Duration("running_time", time.Since(*interruptedWorker.StartedAt)),
+		)
+	}
+
+	if len(interruptedWorkers) > 0 {
+		slog.Warn("Background workers stopped before completing their job",
+			slog.Int("interrupted_jobs", len(interruptedWorkers)),
+			slog.Duration("shutdown_timeout", config.Opts.WorkerShutdownTimeout()),
+		)
+	} else {
+		slog.Debug("All background workers stopped.")
+	}
+
 	slog.Debug("Process gracefully stopped")
 }
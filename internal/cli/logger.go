@@ -8,7 +8,7 @@ import (
 	"log/slog"
 )
 
-func InitializeDefaultLogger(logLevel string, logFile io.Writer, logFormat string, logTime bool) error {
+func InitializeDefaultLogger(logLevel string, logFile io.Writer, logFormat string, logTime bool, instanceName string) error {
 	var programLogLevel = new(slog.LevelVar)
 	switch logLevel {
 	case "debug":
@@ -40,6 +40,11 @@ func InitializeDefaultLogger(logLevel string, logFile io.Writer, logFormat strin
 		logger = slog.New(slog.NewTextHandler(logFile, logHandlerOptions))
 	}
 
+	// Identify the instance when several instances share the same log collector.
+	if instanceName != "" {
+		logger = logger.With(slog.String("instance", instanceName))
+	}
+
 	slog.SetDefault(logger)
 
 	return nil
@@ -4,18 +4,22 @@
 package cli // import "miniflux.app/v2/internal/cli"
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/worker"
 )
 
-func runScheduler(store *storage.Storage, pool *worker.Pool) {
+// runScheduler starts the schedulers, they are stopped when the context is canceled.
+func runScheduler(ctx context.Context, store *storage.Storage, pool *worker.Pool) {
 	slog.Debug(`Starting background scheduler...`)
 
 	go feedScheduler(
+		ctx,
 		store,
 		pool,
 		config.Opts.PollingFrequency(),
@@ -25,13 +29,27 @@ func runScheduler(store *storage.Storage, pool *worker.Pool) {
 	)
 
 	go cleanupScheduler(
+		ctx,
 		store,
 		config.Opts.CleanupFrequency(),
 	)
 }
 
-func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency time.Duration, batchSize, errorLimit, limitPerHost int) {
-	for range time.Tick(frequency) {
+func feedScheduler(ctx context.Context, store *storage.Storage, pool *worker.Pool, frequency time.Duration, batchSize, errorLimit, limitPerHost int) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	pool.SetSchedulerFrequency(frequency, time.Now().Add(frequency))
+
+	for {
+		var runAt time.Time
+		select {
+		case <-ctx.Done():
+			slog.Debug("Feed scheduler stopped")
+			return
+		case runAt = <-ticker.C:
+		}
+
 		// Generate a batch of feeds for any user that has feeds to refresh.
 		batchBuilder := store.NewBatchBuilder()
 		batchBuilder.WithBatchSize(batchSize)
@@ -40,17 +58,65 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency time.Dur
 		batchBuilder.WithNextCheckExpired()
 		batchBuilder.WithLimitPerHost(limitPerHost)
 
+		nbPushedJobs := 0
 		if jobs, err := batchBuilder.FetchJobs(); err != nil {
 			slog.Error("Unable to fetch jobs from database", slog.Any("error", err))
 		} else if len(jobs) > 0 {
 			slog.Debug("Feed URLs in this batch", slog.Any("feed_urls", jobs.FeedURLs()))
-			pool.Push(jobs)
+			if err := pool.Push(jobs, model.JobPriorityBackground); err != nil {
+				slog.Error("Unable to push jobs to the queue", slog.Any("error", err))
+			} else {
+				nbPushedJobs = len(jobs)
+			}
 		}
+
+		pool.RecordSchedulerRun(runAt, nbPushedJobs, runAt.Add(frequency))
 	}
 }
 
-func cleanupScheduler(store *storage.Storage, frequency time.Duration) {
-	for range time.Tick(frequency) {
+// cleanupScheduler runs the cleanup tasks when this instance is the leader.
+// The leader is the instance holding the cleanup advisory lock: when it stops, its database connection is closed
+// and another instance sharing the same database takes over.
+func cleanupScheduler(ctx context.Context, store *storage.Storage, frequency time.Duration) {
+	var leaderLock *storage.AdvisoryLock
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Let another instance take over the cleanup tasks without waiting for the database connection to be closed.
+			if leaderLock != nil {
+				leaderLock.Release()
+			}
+			slog.Debug("Cleanup scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+
+		if leaderLock != nil && !leaderLock.IsHeld() {
+			slog.Warn("Lost the database connection holding the cleanup lock")
+			leaderLock.Release()
+			leaderLock = nil
+		}
+
+		if leaderLock == nil {
+			lock, err := store.TryAdvisoryLock(storage.CleanupAdvisoryLockID)
+			if err != nil {
+				slog.Error("Unable to acquire the cleanup lock", slog.Any("error", err))
+				continue
+			}
+
+			if lock == nil {
+				slog.Debug("Skipping cleanup tasks, another instance is in charge of them")
+				continue
+			}
+
+			slog.Info("This instance is now in charge of the cleanup tasks")
+			leaderLock = lock
+		}
+
 		runCleanupTasks(store)
 	}
 }
@@ -99,6 +99,22 @@ func NewConfigOptions() *configOptions {
 				RawValue:        "0",
 				ValueType:       boolType,
 			},
+			"BACKFILL_MAX_ENTRIES": {
+				ParsedIntValue: 500,
+				RawValue:       "500",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"BACKFILL_MAX_PAGES": {
+				ParsedIntValue: 10,
+				RawValue:       "10",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"BASE_URL": {
 				ParsedStringValue: "http://localhost",
 				RawValue:          "http://localhost",
@@ -140,6 +156,14 @@ func NewConfigOptions() *configOptions {
 				RawValue:       "180",
 				ValueType:      dayType,
 			},
+			"CLEANUP_FETCH_HISTORY_SIZE": {
+				ParsedIntValue: 100,
+				RawValue:       "100",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"CLEANUP_FREQUENCY_HOURS": {
 				ParsedDuration: time.Hour * 24,
 				RawValue:       "24",
@@ -296,11 +320,32 @@ func NewConfigOptions() *configOptions {
 				RawValue:        "0",
 				ValueType:       boolType,
 			},
+			"INSTANCE_NAME": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
 			"INVIDIOUS_INSTANCE": {
 				ParsedStringValue: "yewtu.be",
 				RawValue:          "yewtu.be",
 				ValueType:         stringType,
 			},
+			"JOB_QUEUE_LOCK_TIMEOUT": {
+				ParsedDuration: 600 * time.Second,
+				RawValue:       "600",
+				ValueType:      secondType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"JOB_QUEUE_MAX_ATTEMPTS": {
+				ParsedIntValue: 3,
+				RawValue:       "3",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"KEY_FILE": {
 				ParsedStringValue: "",
 				RawValue:          "",
@@ -420,6 +465,40 @@ func NewConfigOptions() *configOptions {
 				ValueType:         secretFileType,
 				TargetKey:         "METRICS_USERNAME",
 			},
+			"NEWSLETTER_DOMAIN": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
+			"NEWSLETTER_LISTEN_ADDR": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
+			"NEWSLETTER_MAX_MESSAGE_SIZE": {
+				ParsedInt64Value: 10,
+				RawValue:         "10",
+				ValueType:        int64Type,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"NEWSLETTER_MAX_SENDER_FEEDS": {
+				ParsedIntValue: 10,
+				RawValue:       "10",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"NEWSLETTER_PROTOCOL": {
+				ParsedStringValue: "smtp",
+				RawValue:          "smtp",
+				ValueType:         stringType,
+				Validator: func(rawValue string) error {
+					return validateChoices(rawValue, []string{"smtp", "lmtp"})
+				},
+			},
 			"OAUTH2_CLIENT_ID": {
 				ParsedStringValue: "",
 				RawValue:          "",
@@ -480,6 +559,22 @@ func NewConfigOptions() *configOptions {
 					return validateGreaterOrEqualThan(rawValue, 1)
 				},
 			},
+			"POLLING_HOST_FAILURE_LIMIT": {
+				ParsedIntValue: 3,
+				RawValue:       "3",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"POLLING_HOST_PAUSE_DURATION": {
+				ParsedDuration: 300 * time.Second,
+				RawValue:       "300",
+				ValueType:      secondType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"POLLING_LIMIT_PER_HOST": {
 				ParsedIntValue: 0,
 				RawValue:       "0",
@@ -496,12 +591,25 @@ func NewConfigOptions() *configOptions {
 					return validateGreaterOrEqualThan(rawValue, 0)
 				},
 			},
+			"POLLING_REDISCOVERY_AUTO_APPLY": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
+			"POLLING_REDISCOVERY_ERROR_LIMIT": {
+				ParsedIntValue: 2,
+				RawValue:       "2",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
 			"POLLING_SCHEDULER": {
 				ParsedStringValue: "round_robin",
 				RawValue:          "round_robin",
 				ValueType:         stringType,
 				Validator: func(rawValue string) error {
-					return validateChoices(rawValue, []string{"round_robin", "entry_frequency"})
+					return validateChoices(rawValue, []string{"round_robin", "entry_frequency", "publication_time"})
 				},
 			},
 			"PORT": {
@@ -564,6 +672,19 @@ func NewConfigOptions() *configOptions {
 				RawValue:        "0",
 				ValueType:       boolType,
 			},
+			"WEBSUB": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
+			"WEBSUB_LEASE_DURATION": {
+				ParsedDuration: 864000 * time.Second,
+				RawValue:       "864000",
+				ValueType:      secondType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 3600)
+				},
+			},
 			"WORKER_POOL_SIZE": {
 				ParsedIntValue: 16,
 				RawValue:       "16",
@@ -572,6 +693,14 @@ func NewConfigOptions() *configOptions {
 					return validateGreaterOrEqualThan(rawValue, 1)
 				},
 			},
+			"WORKER_SHUTDOWN_TIMEOUT": {
+				ParsedDuration: 10 * time.Second,
+				RawValue:       "10",
+				ValueType:      secondType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
 			"YOUTUBE_API_KEY": {
 				ParsedStringValue: "",
 				RawValue:          "",
@@ -603,6 +732,14 @@ func (c *configOptions) AuthProxyUserCreation() bool {
 	return c.options["AUTH_PROXY_USER_CREATION"].ParsedBoolValue
 }
 
+func (c *configOptions) BackfillMaxEntries() int {
+	return c.options["BACKFILL_MAX_ENTRIES"].ParsedIntValue
+}
+
+func (c *configOptions) BackfillMaxPages() int {
+	return c.options["BACKFILL_MAX_PAGES"].ParsedIntValue
+}
+
 func (c *configOptions) BasePath() string {
 	return c.basePath
 }
@@ -639,6 +776,10 @@ func (c *configOptions) CleanupArchiveUnreadInterval() time.Duration {
 	return c.options["CLEANUP_ARCHIVE_UNREAD_DAYS"].ParsedDuration
 }
 
+func (c *configOptions) CleanupFetchHistorySize() int {
+	return c.options["CLEANUP_FETCH_HISTORY_SIZE"].ParsedIntValue
+}
+
 func (c *configOptions) CleanupFrequency() time.Duration {
 	return c.options["CLEANUP_FREQUENCY_HOURS"].ParsedDuration
 }
@@ -731,6 +872,10 @@ func (c *configOptions) HasMetricsCollector() bool {
 	return c.options["METRICS_COLLECTOR"].ParsedBoolValue
 }
 
+func (c *configOptions) HasNewsletterListener() bool {
+	return c.options["NEWSLETTER_LISTEN_ADDR"].ParsedStringValue != ""
+}
+
 func (c *configOptions) HasSchedulerService() bool {
 	return !c.options["DISABLE_SCHEDULER_SERVICE"].ParsedBoolValue
 }
@@ -770,10 +915,22 @@ func (c *configOptions) HTTPS() bool {
 	return c.options["HTTPS"].ParsedBoolValue
 }
 
+func (c *configOptions) InstanceName() string {
+	return c.options["INSTANCE_NAME"].ParsedStringValue
+}
+
 func (c *configOptions) InvidiousInstance() string {
 	return c.options["INVIDIOUS_INSTANCE"].ParsedStringValue
 }
 
+func (c *configOptions) JobQueueLockTimeout() time.Duration {
+	return c.options["JOB_QUEUE_LOCK_TIMEOUT"].ParsedDuration
+}
+
+func (c *configOptions) JobQueueMaxAttempts() int {
+	return c.options["JOB_QUEUE_MAX_ATTEMPTS"].ParsedIntValue
+}
+
 func (c *configOptions) IsAuthProxyUserCreationAllowed() bool {
 	return c.options["AUTH_PROXY_USER_CREATION"].ParsedBoolValue
 }
@@ -858,6 +1015,35 @@ func (c *configOptions) MetricsUsername() string {
 	return c.options["METRICS_USERNAME"].ParsedStringValue
 }
 
+// NewsletterDomain returns the domain of the newsletter addresses, the hostname of the base URL by default.
+func (c *configOptions) NewsletterDomain() string {
+	if domain := c.options["NEWSLETTER_DOMAIN"].ParsedStringValue; domain != "" {
+		return strings.ToLower(domain)
+	}
+
+	if parsedURL, err := url.Parse(c.rootURL); err == nil && parsedURL.Hostname() != "" {
+		return strings.ToLower(parsedURL.Hostname())
+	}
+
+	return "localhost"
+}
+
+func (c *configOptions) NewsletterListenAddr() string {
+	return c.options["NEWSLETTER_LISTEN_ADDR"].ParsedStringValue
+}
+
+func (c *configOptions) NewsletterMaxMessageSize() int64 {
+	return c.options["NEWSLETTER_MAX_MESSAGE_SIZE"].ParsedInt64Value * 1024 * 1024
+}
+
+func (c *configOptions) NewsletterMaxSenderFeeds() int {
+	return c.options["NEWSLETTER_MAX_SENDER_FEEDS"].ParsedIntValue
+}
+
+func (c *configOptions) NewsletterProtocol() string {
+	return c.options["NEWSLETTER_PROTOCOL"].ParsedStringValue
+}
+
 func (c *configOptions) OAuth2ClientID() string {
 	return c.options["OAUTH2_CLIENT_ID"].ParsedStringValue
 }
@@ -890,6 +1076,14 @@ func (c *configOptions) PollingFrequency() time.Duration {
 	return c.options["POLLING_FREQUENCY"].ParsedDuration
 }
 
+func (c *configOptions) PollingHostFailureLimit() int {
+	return c.options["POLLING_HOST_FAILURE_LIMIT"].ParsedIntValue
+}
+
+func (c *configOptions) PollingHostPauseDuration() time.Duration {
+	return c.options["POLLING_HOST_PAUSE_DURATION"].ParsedDuration
+}
+
 func (c *configOptions) PollingLimitPerHost() int {
 	return c.options["POLLING_LIMIT_PER_HOST"].ParsedIntValue
 }
@@ -898,6 +1092,14 @@ func (c *configOptions) PollingParsingErrorLimit() int {
 	return c.options["POLLING_PARSING_ERROR_LIMIT"].ParsedIntValue
 }
 
+func (c *configOptions) PollingRediscoveryAutoApply() bool {
+	return c.options["POLLING_REDISCOVERY_AUTO_APPLY"].ParsedBoolValue
+}
+
+func (c *configOptions) PollingRediscoveryErrorLimit() int {
+	return c.options["POLLING_REDISCOVERY_ERROR_LIMIT"].ParsedIntValue
+}
+
 func (c *configOptions) PollingScheduler() string {
 	return c.options["POLLING_SCHEDULER"].ParsedStringValue
 }
@@ -952,10 +1154,22 @@ func (c *configOptions) WebAuthn() bool {
 	return c.options["WEBAUTHN"].ParsedBoolValue
 }
 
+func (c *configOptions) WebSub() bool {
+	return c.options["WEBSUB"].ParsedBoolValue
+}
+
+func (c *configOptions) WebSubLeaseDuration() time.Duration {
+	return c.options["WEBSUB_LEASE_DURATION"].ParsedDuration
+}
+
 func (c *configOptions) WorkerPoolSize() int {
 	return c.options["WORKER_POOL_SIZE"].ParsedIntValue
 }
 
+func (c *configOptions) WorkerShutdownTimeout() time.Duration {
+	return c.options["WORKER_SHUTDOWN_TIMEOUT"].ParsedDuration
+}
+
 func (c *configOptions) YouTubeAPIKey() string {
 	return c.options["YOUTUBE_API_KEY"].ParsedStringValue
 }
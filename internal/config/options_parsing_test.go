@@ -5,6 +5,46 @@ package config // import "miniflux.app/v2/internal/config"
 
 import "testing"
 
+func TestBackfillMaxEntriesOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.BackfillMaxEntries() != 500 {
+		t.Fatal("Expected BACKFILL_MAX_ENTRIES to be 500 by default")
+	}
+
+	if err := configParser.parseLines([]string{"BACKFILL_MAX_ENTRIES=50"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.BackfillMaxEntries() != 50 {
+		t.Fatal("Expected BACKFILL_MAX_ENTRIES to be 50")
+	}
+
+	if err := configParser.parseLines([]string{"BACKFILL_MAX_ENTRIES=0"}); err == nil {
+		t.Fatal("Expected error for BACKFILL_MAX_ENTRIES lower than 1")
+	}
+}
+
+func TestBackfillMaxPagesOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.BackfillMaxPages() != 10 {
+		t.Fatal("Expected BACKFILL_MAX_PAGES to be 10 by default")
+	}
+
+	if err := configParser.parseLines([]string{"BACKFILL_MAX_PAGES=3"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.BackfillMaxPages() != 3 {
+		t.Fatal("Expected BACKFILL_MAX_PAGES to be 3")
+	}
+
+	if err := configParser.parseLines([]string{"BACKFILL_MAX_PAGES=0"}); err == nil {
+		t.Fatal("Expected error for BACKFILL_MAX_PAGES lower than 1")
+	}
+}
+
 func TestBaseURLOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -109,6 +149,42 @@ func TestWebAuthnOptionParsing(t *testing.T) {
 	}
 }
 
+func TestWebSubOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.WebSub() {
+		t.Fatalf("Expected WEBSUB to be disabled by default")
+	}
+
+	if err := configParser.parseLines([]string{"WEBSUB=1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !configParser.options.WebSub() {
+		t.Fatalf("Expected WEBSUB to be enabled")
+	}
+}
+
+func TestWebSubLeaseDurationOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.WebSubLeaseDuration().Seconds() != 864000 {
+		t.Fatalf("Expected WEBSUB_LEASE_DURATION to be 864000 seconds by default")
+	}
+
+	if err := configParser.parseLines([]string{"WEBSUB_LEASE_DURATION=86400"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.WebSubLeaseDuration().Seconds() != 86400 {
+		t.Fatalf("Expected WEBSUB_LEASE_DURATION to be 86400 seconds")
+	}
+
+	if err := configParser.parseLines([]string{"WEBSUB_LEASE_DURATION=60"}); err == nil {
+		t.Fatal("Expected error for WEBSUB_LEASE_DURATION lower than one hour")
+	}
+}
+
 func TestWorkerPoolSizeOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -125,6 +201,26 @@ func TestWorkerPoolSizeOptionParsing(t *testing.T) {
 	}
 }
 
+func TestWorkerShutdownTimeoutOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.WorkerShutdownTimeout().Seconds() != 10 {
+		t.Fatal("Expected WORKER_SHUTDOWN_TIMEOUT to be 10 seconds by default")
+	}
+
+	if err := configParser.parseLines([]string{"WORKER_SHUTDOWN_TIMEOUT=0"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.WorkerShutdownTimeout() != 0 {
+		t.Fatal("Expected WORKER_SHUTDOWN_TIMEOUT to be 0 seconds")
+	}
+
+	if err := configParser.parseLines([]string{"WORKER_SHUTDOWN_TIMEOUT=-1"}); err == nil {
+		t.Fatal("Expected an error for WORKER_SHUTDOWN_TIMEOUT=-1")
+	}
+}
+
 func TestYouTubeAPIKeyOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -655,6 +751,22 @@ func TestHTTPSOptionParsing(t *testing.T) {
 	}
 }
 
+func TestInstanceNameOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.InstanceName() != "" {
+		t.Fatal("Expected INSTANCE_NAME to be empty by default")
+	}
+
+	if err := configParser.parseLines([]string{"INSTANCE_NAME=replica-1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.InstanceName() != "replica-1" {
+		t.Fatal("Expected INSTANCE_NAME to be 'replica-1'")
+	}
+}
+
 func TestInvidiousInstanceOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -895,6 +1007,110 @@ func TestMetricsUsernameOptionParsing(t *testing.T) {
 	}
 }
 
+func TestNewsletterDomainOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.NewsletterDomain() != "localhost" {
+		t.Fatalf("Expected NEWSLETTER_DOMAIN to be 'localhost' by default")
+	}
+
+	if err := configParser.parseLines([]string{"BASE_URL=https://Reader.Example.org/miniflux"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.NewsletterDomain() != "reader.example.org" {
+		t.Fatalf("Expected NEWSLETTER_DOMAIN to be the hostname of BASE_URL, got %q", configParser.options.NewsletterDomain())
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_DOMAIN=mail.example.org"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.NewsletterDomain() != "mail.example.org" {
+		t.Fatalf("Expected NEWSLETTER_DOMAIN to be 'mail.example.org'")
+	}
+}
+
+func TestNewsletterListenAddrOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.HasNewsletterListener() {
+		t.Fatalf("Expected the newsletter listener to be disabled by default")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_LISTEN_ADDR=127.0.0.1:2525"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !configParser.options.HasNewsletterListener() {
+		t.Fatalf("Expected the newsletter listener to be enabled")
+	}
+
+	if configParser.options.NewsletterListenAddr() != "127.0.0.1:2525" {
+		t.Fatalf("Expected NEWSLETTER_LISTEN_ADDR to be '127.0.0.1:2525'")
+	}
+}
+
+func TestNewsletterMaxMessageSizeOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.NewsletterMaxMessageSize() != 10*1024*1024 {
+		t.Fatalf("Expected NEWSLETTER_MAX_MESSAGE_SIZE to be 10 MiB by default")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_MAX_MESSAGE_SIZE=25"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.NewsletterMaxMessageSize() != 25*1024*1024 {
+		t.Fatalf("Expected NEWSLETTER_MAX_MESSAGE_SIZE to be 25 MiB")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_MAX_MESSAGE_SIZE=0"}); err == nil {
+		t.Fatal("Expected an error for NEWSLETTER_MAX_MESSAGE_SIZE=0")
+	}
+}
+
+func TestNewsletterMaxSenderFeedsOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.NewsletterMaxSenderFeeds() != 10 {
+		t.Fatal("Expected NEWSLETTER_MAX_SENDER_FEEDS to be 10 by default")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_MAX_SENDER_FEEDS=0"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.NewsletterMaxSenderFeeds() != 0 {
+		t.Fatal("Expected NEWSLETTER_MAX_SENDER_FEEDS to be 0")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_MAX_SENDER_FEEDS=-1"}); err == nil {
+		t.Fatal("Expected an error for NEWSLETTER_MAX_SENDER_FEEDS=-1")
+	}
+}
+
+func TestNewsletterProtocolOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.NewsletterProtocol() != "smtp" {
+		t.Fatalf("Expected NEWSLETTER_PROTOCOL to be 'smtp' by default")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_PROTOCOL=lmtp"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.NewsletterProtocol() != "lmtp" {
+		t.Fatalf("Expected NEWSLETTER_PROTOCOL to be 'lmtp'")
+	}
+
+	if err := configParser.parseLines([]string{"NEWSLETTER_PROTOCOL=imap"}); err == nil {
+		t.Fatal("Expected an error for NEWSLETTER_PROTOCOL=imap")
+	}
+}
+
 func TestOAuth2ClientIDOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -1039,6 +1255,46 @@ func TestOAuth2UserCreationOptionParsing(t *testing.T) {
 	}
 }
 
+func TestPollingHostFailureLimitOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.PollingHostFailureLimit() != 3 {
+		t.Fatalf("Expected POLLING_HOST_FAILURE_LIMIT to be 3 by default")
+	}
+
+	if err := configParser.parseLines([]string{"POLLING_HOST_FAILURE_LIMIT=0"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.PollingHostFailureLimit() != 0 {
+		t.Fatalf("Expected POLLING_HOST_FAILURE_LIMIT to be 0")
+	}
+
+	if err := configParser.parseLines([]string{"POLLING_HOST_FAILURE_LIMIT=-1"}); err == nil {
+		t.Fatal("Expected an error for POLLING_HOST_FAILURE_LIMIT=-1")
+	}
+}
+
+func TestPollingHostPauseDurationOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.PollingHostPauseDuration().Seconds() != 300 {
+		t.Fatalf("Expected POLLING_HOST_PAUSE_DURATION to be 300 seconds by default")
+	}
+
+	if err := configParser.parseLines([]string{"POLLING_HOST_PAUSE_DURATION=60"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.PollingHostPauseDuration().Seconds() != 60 {
+		t.Fatalf("Expected POLLING_HOST_PAUSE_DURATION to be 60 seconds")
+	}
+
+	if err := configParser.parseLines([]string{"POLLING_HOST_PAUSE_DURATION=0"}); err == nil {
+		t.Fatal("Expected an error for POLLING_HOST_PAUSE_DURATION=0")
+	}
+}
+
 func TestPollingLimitPerHostOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -1071,6 +1327,38 @@ func TestPollingParsingErrorLimitOptionParsing(t *testing.T) {
 	}
 }
 
+func TestPollingRediscoveryAutoApplyOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.PollingRediscoveryAutoApply() {
+		t.Fatalf("Expected POLLING_REDISCOVERY_AUTO_APPLY to be disabled by default")
+	}
+
+	if err := configParser.parseLines([]string{"POLLING_REDISCOVERY_AUTO_APPLY=1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !configParser.options.PollingRediscoveryAutoApply() {
+		t.Fatalf("Expected POLLING_REDISCOVERY_AUTO_APPLY to be enabled")
+	}
+}
+
+func TestPollingRediscoveryErrorLimitOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.PollingRediscoveryErrorLimit() != 2 {
+		t.Fatalf("Expected POLLING_REDISCOVERY_ERROR_LIMIT to be 2 by default")
+	}
+
+	if err := configParser.parseLines([]string{"POLLING_REDISCOVERY_ERROR_LIMIT=0"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.PollingRediscoveryErrorLimit() != 0 {
+		t.Fatalf("Expected POLLING_REDISCOVERY_ERROR_LIMIT to be 0")
+	}
+}
+
 func TestPollingSchedulerOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -1085,6 +1373,14 @@ func TestPollingSchedulerOptionParsing(t *testing.T) {
 	if configParser.options.PollingScheduler() != "entry_frequency" {
 		t.Fatalf("Expected POLLING_SCHEDULER to be 'entry_frequency'")
 	}
+
+	if err := configParser.parseLines([]string{"POLLING_SCHEDULER=publication_time"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.PollingScheduler() != "publication_time" {
+		t.Fatalf("Expected POLLING_SCHEDULER to be 'publication_time'")
+	}
 }
 
 func TestPortOptionParsing(t *testing.T) {
@@ -1222,6 +1518,26 @@ func TestCleanupArchiveUnreadIntervalOptionParsing(t *testing.T) {
 	}
 }
 
+func TestCleanupFetchHistorySizeOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.CleanupFetchHistorySize() != 100 {
+		t.Fatal("Expected CLEANUP_FETCH_HISTORY_SIZE to be 100 by default")
+	}
+
+	if err := configParser.parseLines([]string{"CLEANUP_FETCH_HISTORY_SIZE=20"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.CleanupFetchHistorySize() != 20 {
+		t.Fatal("Expected CLEANUP_FETCH_HISTORY_SIZE to be 20")
+	}
+
+	if err := configParser.parseLines([]string{"CLEANUP_FETCH_HISTORY_SIZE=0"}); err == nil {
+		t.Fatal("Expected error for CLEANUP_FETCH_HISTORY_SIZE lower than 1")
+	}
+}
+
 func TestCleanupFrequencyOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -1380,6 +1696,42 @@ func TestHTTPServerTimeoutOptionParsing(t *testing.T) {
 	}
 }
 
+func TestJobQueueLockTimeoutOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.JobQueueLockTimeout().Seconds() != 600 {
+		t.Fatal("Expected JOB_QUEUE_LOCK_TIMEOUT to be 600 seconds by default")
+	}
+
+	if err := configParser.parseLines([]string{"JOB_QUEUE_LOCK_TIMEOUT=120"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.JobQueueLockTimeout().Seconds() != 120 {
+		t.Fatal("Expected JOB_QUEUE_LOCK_TIMEOUT to be 120 seconds")
+	}
+}
+
+func TestJobQueueMaxAttemptsOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.JobQueueMaxAttempts() != 3 {
+		t.Fatal("Expected JOB_QUEUE_MAX_ATTEMPTS to be 3 by default")
+	}
+
+	if err := configParser.parseLines([]string{"JOB_QUEUE_MAX_ATTEMPTS=5"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.JobQueueMaxAttempts() != 5 {
+		t.Fatal("Expected JOB_QUEUE_MAX_ATTEMPTS to be 5")
+	}
+
+	if err := configParser.parseLines([]string{"JOB_QUEUE_MAX_ATTEMPTS=0"}); err == nil {
+		t.Fatal("Expected an error for JOB_QUEUE_MAX_ATTEMPTS=0")
+	}
+}
+
 func TestListenAddrOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
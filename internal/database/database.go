@@ -4,13 +4,30 @@
 package database // import "miniflux.app/v2/internal/database"
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 )
 
+// migrationAdvisoryLockID identifies the lock held while running the migrations.
+const migrationAdvisoryLockID int64 = 0x6d696e69666c7802
+
 // Migrate executes database migrations.
 func Migrate(db *sql.DB) error {
+	// Hold a lock during the migrations, so instances sharing the same database don't run them concurrently.
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get a database connection: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationAdvisoryLockID); err != nil {
+		return fmt.Errorf("unable to acquire the migration lock: %v", err)
+	}
+	defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationAdvisoryLockID)
+
 	var currentVersion int
 	db.QueryRow(`SELECT version FROM schema_version`).Scan(&currentVersion)
 
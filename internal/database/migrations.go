@@ -1341,4 +1341,212 @@ var migrations = [...]func(tx *sql.Tx) error{
 
 		return nil
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN entry_action_rules text not null default '';
+			ALTER TABLE feeds ADD COLUMN entry_action_rules text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE categories
+				ADD COLUMN scraper_rules text not null default '',
+				ADD COLUMN rewrite_rules text not null default '',
+				ADD COLUMN urlrewrite_rules text not null default '',
+				ADD COLUMN block_filter_entry_rules text not null default '',
+				ADD COLUMN keep_filter_entry_rules text not null default '',
+				ADD COLUMN crawler boolean not null default 'f'
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN duplicate_entries_policy text not null default 'none';
+			ALTER TABLE entries
+				ADD COLUMN url_fingerprint text not null default '',
+				ADD COLUMN content_fingerprint text not null default '',
+				ADD COLUMN duplicate_of_entry_id bigint null references entries(id) on delete set null;
+			CREATE INDEX entries_user_id_url_fingerprint_idx ON entries(user_id, url_fingerprint);
+			CREATE INDEX entries_user_id_content_fingerprint_idx ON entries(user_id, content_fingerprint);
+			CREATE INDEX entries_duplicate_of_entry_id_idx ON entries(duplicate_of_entry_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN language text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds
+				ADD COLUMN webpage_item_selector text not null default '',
+				ADD COLUMN webpage_title_selector text not null default '',
+				ADD COLUMN webpage_link_selector text not null default '',
+				ADD COLUMN webpage_date_selector text not null default '',
+				ADD COLUMN webpage_content_selector text not null default ''
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds
+				ADD COLUMN skip_hours int[] not null default '{}',
+				ADD COLUMN skip_days text[] not null default '{}',
+				ADD COLUMN ignore_skip_hints bool not null default 'f'
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE jobs (
+				id bigserial not null,
+				user_id int not null,
+				feed_id bigint not null,
+				priority int not null default 0,
+				status text not null default 'pending',
+				attempts int not null default 0,
+				locked_by text not null default '',
+				locked_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (feed_id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+			CREATE INDEX jobs_status_priority_idx ON jobs(status, priority DESC, created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE websub_subscriptions (
+				feed_id bigint not null,
+				hub_url text not null,
+				topic_url text not null,
+				callback_id text not null,
+				secret text not null,
+				state text not null default 'pending',
+				lease_expires_at timestamp with time zone,
+				updated_at timestamp with time zone not null default now(),
+				primary key (feed_id),
+				unique (callback_id),
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_fetch_history (
+				id bigserial not null,
+				feed_id bigint not null,
+				fetched_at timestamp with time zone not null default now(),
+				status_code int not null default 0,
+				response_time_ms int not null default 0,
+				response_size bigint not null default 0,
+				not_modified bool not null default 'f',
+				new_entries int not null default 0,
+				error_msg text not null default '',
+				primary key (id),
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+			CREATE INDEX feed_fetch_history_feed_id_fetched_at_idx ON feed_fetch_history(feed_id, fetched_at DESC);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN proposed_feed_url text not null default '';
+			ALTER TABLE feed_fetch_history ADD COLUMN previous_feed_url text not null default '';
+			ALTER TABLE feed_fetch_history ADD COLUMN new_feed_url text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN custom_headers jsonb not null default '{}';
+			ALTER TABLE feeds ADD COLUMN client_certificate text not null default '';
+			ALTER TABLE feeds ADD COLUMN client_private_key text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN podcast jsonb;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE public_feeds (
+				id bigserial not null,
+				user_id int not null references users(id) on delete cascade,
+				token text not null unique,
+				title text not null,
+				source text not null,
+				category_id int references categories(id) on delete cascade,
+				tag text not null default '',
+				search_query text not null default '',
+				last_used_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, title)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN newsletter_token text unique;
+			ALTER TABLE feeds ADD COLUMN newsletter_token text unique;
+
+			CREATE TABLE newsletter_attachments (
+				id bigserial not null,
+				entry_id bigint not null references entries(id) on delete cascade,
+				token text not null unique,
+				filename text not null default '',
+				mime_type text not null,
+				content bytea not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (id)
+			);
+			CREATE INDEX newsletter_attachments_entry_id_idx ON newsletter_attachments(entry_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE jobs ADD COLUMN available_at timestamp with time zone not null default now();
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE jobs
+				ADD COLUMN kind text not null default 'refresh',
+				ADD COLUMN archive_page_url text not null default '';
+			ALTER TABLE jobs DROP CONSTRAINT jobs_feed_id_key;
+			ALTER TABLE jobs ADD CONSTRAINT jobs_feed_id_kind_key UNIQUE (feed_id, kind);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
 }
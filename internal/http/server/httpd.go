@@ -18,9 +18,11 @@ import (
 	"miniflux.app/v2/internal/fever"
 	"miniflux.app/v2/internal/googlereader"
 	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/publicfeed"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui"
 	"miniflux.app/v2/internal/version"
+	"miniflux.app/v2/internal/websub"
 	"miniflux.app/v2/internal/worker"
 
 	"github.com/gorilla/mux"
@@ -244,6 +246,13 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 	fever.Serve(subrouter, store)
 	googlereader.Serve(subrouter, store)
 	api.Serve(subrouter, store, pool)
+
+	if config.Opts.WebSub() {
+		websub.Serve(subrouter, store)
+	}
+
+	publicfeed.Serve(subrouter, store)
+
 	ui.Serve(subrouter, store, pool)
 
 	subrouter.HandleFunc("/healthcheck", readinessProbe).Name("healthcheck")
@@ -5,6 +5,7 @@ package integration // import "miniflux.app/v2/internal/integration"
 
 import (
 	"log/slog"
+	"slices"
 
 	"miniflux.app/v2/internal/integration/apprise"
 	"miniflux.app/v2/internal/integration/betula"
@@ -36,6 +37,48 @@ import (
 	"miniflux.app/v2/internal/model"
 )
 
+// saveIntegrations maps the integration names accepted by the SendTo action rule to their enabled flag.
+var saveIntegrations = map[string]func(*model.Integration) *bool{
+	"betula":      func(i *model.Integration) *bool { return &i.BetulaEnabled },
+	"cubox":       func(i *model.Integration) *bool { return &i.CuboxEnabled },
+	"espial":      func(i *model.Integration) *bool { return &i.EspialEnabled },
+	"instapaper":  func(i *model.Integration) *bool { return &i.InstapaperEnabled },
+	"karakeep":    func(i *model.Integration) *bool { return &i.KarakeepEnabled },
+	"linkace":     func(i *model.Integration) *bool { return &i.LinkAceEnabled },
+	"linkding":    func(i *model.Integration) *bool { return &i.LinkdingEnabled },
+	"linktaco":    func(i *model.Integration) *bool { return &i.LinktacoEnabled },
+	"linkwarden":  func(i *model.Integration) *bool { return &i.LinkwardenEnabled },
+	"notion":      func(i *model.Integration) *bool { return &i.NotionEnabled },
+	"nunuxkeeper": func(i *model.Integration) *bool { return &i.NunuxKeeperEnabled },
+	"omnivore":    func(i *model.Integration) *bool { return &i.OmnivoreEnabled },
+	"pinboard":    func(i *model.Integration) *bool { return &i.PinboardEnabled },
+	"raindrop":    func(i *model.Integration) *bool { return &i.RaindropEnabled },
+	"readeck":     func(i *model.Integration) *bool { return &i.ReadeckEnabled },
+	"readwise":    func(i *model.Integration) *bool { return &i.ReadwiseEnabled },
+	"shaarli":     func(i *model.Integration) *bool { return &i.ShaarliEnabled },
+	"shiori":      func(i *model.Integration) *bool { return &i.ShioriEnabled },
+	"wallabag":    func(i *model.Integration) *bool { return &i.WallabagEnabled },
+	"webhook":     func(i *model.Integration) *bool { return &i.WebhookEnabled },
+}
+
+// IsSaveIntegration returns true if the name is an integration supported by SendEntry.
+func IsSaveIntegration(name string) bool {
+	_, found := saveIntegrations[name]
+	return found
+}
+
+// SendEntryToIntegrations sends the entry only to the given third-party providers, if they are enabled.
+func SendEntryToIntegrations(entry *model.Entry, userIntegrations *model.Integration, names []string) {
+	selectedIntegrations := *userIntegrations
+	for name, enabledFlag := range saveIntegrations {
+		if !slices.Contains(names, name) {
+			*enabledFlag(&selectedIntegrations) = false
+		}
+	}
+
+	SendEntry(entry, &selectedIntegrations)
+}
+
 // SendEntry sends the entry to third-party providers when the user click on "Save".
 func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 	if userIntegrations.BetulaEnabled {
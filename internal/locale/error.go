@@ -23,6 +23,11 @@ func (l *LocalizedErrorWrapper) Error() error {
 	return l.originalErr
 }
 
+// TranslationKey returns the key of the translated error message.
+func (l *LocalizedErrorWrapper) TranslationKey() string {
+	return l.translationKey
+}
+
 func (l *LocalizedErrorWrapper) Translate(language string) string {
 	if l.translationKey == "" {
 		return l.originalErr.Error()
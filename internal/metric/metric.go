@@ -7,6 +7,8 @@ import (
 	"log/slog"
 	"time"
 
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,6 +46,15 @@ var (
 		[]string{"status"},
 	)
 
+	BackgroundWorkersGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "background_workers",
+			Help:      "Number of background workers of this instance by state",
+		},
+		[]string{"state"},
+	)
+
 	usersGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -78,6 +89,24 @@ var (
 		[]string{"status"},
 	)
 
+	jobsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "jobs",
+			Help:      "Number of feed refresh jobs in the queue by status",
+		},
+		[]string{"status"},
+	)
+
+	jobQueueDepthGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "job_queue_depth",
+			Help:      "Number of pending feed refresh jobs in the queue by priority",
+		},
+		[]string{"priority"},
+	)
+
 	dbOpenConnectionsGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -143,20 +172,29 @@ type collector struct {
 
 // NewCollector initializes a new metric collector.
 func NewCollector(store *storage.Storage, refreshInterval time.Duration) *collector {
-	prometheus.MustRegister(BackgroundFeedRefreshDuration)
-	prometheus.MustRegister(ScraperRequestDuration)
-	prometheus.MustRegister(ArchiveEntriesDuration)
-	prometheus.MustRegister(usersGauge)
-	prometheus.MustRegister(feedsGauge)
-	prometheus.MustRegister(brokenFeedsGauge)
-	prometheus.MustRegister(entriesGauge)
-	prometheus.MustRegister(dbOpenConnectionsGauge)
-	prometheus.MustRegister(dbConnectionsInUseGauge)
-	prometheus.MustRegister(dbConnectionsIdleGauge)
-	prometheus.MustRegister(dbConnectionsWaitCountGauge)
-	prometheus.MustRegister(dbConnectionsMaxIdleClosedGauge)
-	prometheus.MustRegister(dbConnectionsMaxIdleTimeClosedGauge)
-	prometheus.MustRegister(dbConnectionsMaxLifetimeClosedGauge)
+	// Label the metrics with the instance name to tell apart the instances sharing the same database.
+	var registerer prometheus.Registerer = prometheus.DefaultRegisterer
+	if instanceName := config.Opts.InstanceName(); instanceName != "" {
+		registerer = prometheus.WrapRegistererWith(prometheus.Labels{"instance_name": instanceName}, registerer)
+	}
+
+	registerer.MustRegister(BackgroundFeedRefreshDuration)
+	registerer.MustRegister(ScraperRequestDuration)
+	registerer.MustRegister(ArchiveEntriesDuration)
+	registerer.MustRegister(BackgroundWorkersGauge)
+	registerer.MustRegister(usersGauge)
+	registerer.MustRegister(feedsGauge)
+	registerer.MustRegister(brokenFeedsGauge)
+	registerer.MustRegister(entriesGauge)
+	registerer.MustRegister(jobsGauge)
+	registerer.MustRegister(jobQueueDepthGauge)
+	registerer.MustRegister(dbOpenConnectionsGauge)
+	registerer.MustRegister(dbConnectionsInUseGauge)
+	registerer.MustRegister(dbConnectionsIdleGauge)
+	registerer.MustRegister(dbConnectionsWaitCountGauge)
+	registerer.MustRegister(dbConnectionsMaxIdleClosedGauge)
+	registerer.MustRegister(dbConnectionsMaxIdleTimeClosedGauge)
+	registerer.MustRegister(dbConnectionsMaxLifetimeClosedGauge)
 
 	return &collector{store, refreshInterval}
 }
@@ -179,6 +217,15 @@ func (c *collector) GatherStorageMetrics() {
 			entriesGauge.WithLabelValues(status).Set(float64(count))
 		}
 
+		if jobQueueStats, err := c.store.JobQueueStats(); err != nil {
+			slog.Error("Unable to collect job queue metrics", slog.Any("error", err))
+		} else {
+			jobsGauge.WithLabelValues(model.JobStatusPending).Set(float64(jobQueueStats.Pending))
+			jobsGauge.WithLabelValues(model.JobStatusRunning).Set(float64(jobQueueStats.Running))
+			jobQueueDepthGauge.WithLabelValues("manual").Set(float64(jobQueueStats.PendingManual))
+			jobQueueDepthGauge.WithLabelValues("background").Set(float64(jobQueueStats.Pending - jobQueueStats.PendingManual))
+		}
+
 		dbStats := c.store.DBStats()
 		dbOpenConnectionsGauge.Set(float64(dbStats.OpenConnections))
 		dbConnectionsInUseGauge.Set(float64(dbStats.InUse))
@@ -7,10 +7,16 @@ import "fmt"
 
 // Category represents a feed category.
 type Category struct {
-	ID           int64  `json:"id"`
-	Title        string `json:"title"`
-	UserID       int64  `json:"user_id"`
-	HideGlobally bool   `json:"hide_globally"`
+	ID                    int64  `json:"id"`
+	Title                 string `json:"title"`
+	UserID                int64  `json:"user_id"`
+	HideGlobally          bool   `json:"hide_globally"`
+	ScraperRules          string `json:"scraper_rules"`
+	RewriteRules          string `json:"rewrite_rules"`
+	UrlRewriteRules       string `json:"urlrewrite_rules"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules"`
+	Crawler               bool   `json:"crawler"`
 	// Pointers are needed to avoid breaking /v1/categories?counts=true
 	FeedCount   *int `json:"feed_count,omitempty"`
 	TotalUnread *int `json:"total_unread,omitempty"`
@@ -21,13 +27,25 @@ func (c *Category) String() string {
 }
 
 type CategoryCreationRequest struct {
-	Title        string `json:"title"`
-	HideGlobally bool   `json:"hide_globally"`
+	Title                 string `json:"title"`
+	HideGlobally          bool   `json:"hide_globally"`
+	ScraperRules          string `json:"scraper_rules"`
+	RewriteRules          string `json:"rewrite_rules"`
+	UrlRewriteRules       string `json:"urlrewrite_rules"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules"`
+	Crawler               bool   `json:"crawler"`
 }
 
 type CategoryModificationRequest struct {
-	Title        *string `json:"title"`
-	HideGlobally *bool   `json:"hide_globally"`
+	Title                 *string `json:"title"`
+	HideGlobally          *bool   `json:"hide_globally"`
+	ScraperRules          *string `json:"scraper_rules"`
+	RewriteRules          *string `json:"rewrite_rules"`
+	UrlRewriteRules       *string `json:"urlrewrite_rules"`
+	BlockFilterEntryRules *string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  *string `json:"keep_filter_entry_rules"`
+	Crawler               *bool   `json:"crawler"`
 }
 
 func (c *CategoryModificationRequest) Patch(category *Category) {
@@ -38,6 +56,30 @@ func (c *CategoryModificationRequest) Patch(category *Category) {
 	if c.HideGlobally != nil {
 		category.HideGlobally = *c.HideGlobally
 	}
+
+	if c.ScraperRules != nil {
+		category.ScraperRules = *c.ScraperRules
+	}
+
+	if c.RewriteRules != nil {
+		category.RewriteRules = *c.RewriteRules
+	}
+
+	if c.UrlRewriteRules != nil {
+		category.UrlRewriteRules = *c.UrlRewriteRules
+	}
+
+	if c.BlockFilterEntryRules != nil {
+		category.BlockFilterEntryRules = *c.BlockFilterEntryRules
+	}
+
+	if c.KeepFilterEntryRules != nil {
+		category.KeepFilterEntryRules = *c.KeepFilterEntryRules
+	}
+
+	if c.Crawler != nil {
+		category.Crawler = *c.Crawler
+	}
 }
 
 // Categories represents a list of categories.
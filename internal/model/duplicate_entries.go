@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// Policies applied to entries already published by another feed of the same user.
+const (
+	DuplicateEntriesPolicyNone       = "none"
+	DuplicateEntriesPolicyMarkAsRead = "mark_as_read"
+	DuplicateEntriesPolicyHide       = "hide"
+	DuplicateEntriesPolicyGroup      = "group"
+)
+
+// DuplicateEntriesPolicies returns the list of available policies for duplicate entries.
+func DuplicateEntriesPolicies() map[string]string {
+	return map[string]string{
+		DuplicateEntriesPolicyNone:       "form.prefs.select.duplicate_entries_none",
+		DuplicateEntriesPolicyMarkAsRead: "form.prefs.select.duplicate_entries_mark_as_read",
+		DuplicateEntriesPolicyHide:       "form.prefs.select.duplicate_entries_hide",
+		DuplicateEntriesPolicyGroup:      "form.prefs.select.duplicate_entries_group",
+	}
+}
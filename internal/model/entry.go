@@ -37,6 +37,21 @@ type Entry struct {
 	Enclosures  EnclosureList `json:"enclosures"`
 	Feed        *Feed         `json:"feed,omitempty"`
 	Tags        []string      `json:"tags"`
+	Language    string        `json:"language"`
+
+	// Podcast contains the Podcasting 2.0 metadata of the entry, when the feed provides them.
+	Podcast *PodcastEpisode `json:"podcast,omitempty"`
+
+	// DuplicateOfEntryID references the first occurrence of the entry, the duplicates are excluded from the entry lists.
+	DuplicateOfEntryID int64 `json:"duplicate_of_entry_id,omitempty"`
+
+	// Fingerprints used to detect duplicate entries across feeds (not exposed in the API).
+	URLFingerprint     string `json:"-"`
+	ContentFingerprint string `json:"-"`
+
+	// Internal attributes (not exposed in the API and not persisted in the database)
+	SendToIntegrations []string `json:"-"`
+	Duplicates         Entries  `json:"-"`
 }
 
 func NewEntry() *Entry {
@@ -75,6 +90,11 @@ type EntriesStatusUpdateRequest struct {
 	Status   string  `json:"status"`
 }
 
+// EntriesFilterRequest represents a request to apply the filter rules to the existing unread entries.
+type EntriesFilterRequest struct {
+	Status string `json:"status"`
+}
+
 // EntryUpdateRequest represents a request to update an entry.
 type EntryUpdateRequest struct {
 	Title   *string `json:"title"`
@@ -6,6 +6,7 @@ package model // import "miniflux.app/v2/internal/model"
 import (
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"miniflux.app/v2/internal/config"
@@ -13,8 +14,9 @@ import (
 
 // List of supported schedulers.
 const (
-	SchedulerRoundRobin     = "round_robin"
-	SchedulerEntryFrequency = "entry_frequency"
+	SchedulerRoundRobin      = "round_robin"
+	SchedulerEntryFrequency  = "entry_frequency"
+	SchedulerPublicationTime = "publication_time"
 	// Default settings for the feed query builder
 	DefaultFeedSorting          = "parsing_error_count"
 	DefaultFeedSortingDirection = "desc"
@@ -22,45 +24,59 @@ const (
 
 // Feed represents a feed in the application.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	Description                 string    `json:"description"`
-	CheckedAt                   time.Time `json:"checked_at"`
-	NextCheckAt                 time.Time `json:"next_check_at"`
-	EtagHeader                  string    `json:"etag_header"`
-	LastModifiedHeader          string    `json:"last_modified_header"`
-	ParsingErrorMsg             string    `json:"parsing_error_message"`
-	ParsingErrorCount           int       `json:"parsing_error_count"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	BlockFilterEntryRules       string    `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        string    `json:"keep_filter_entry_rules"`
-	UrlRewriteRules             string    `json:"urlrewrite_rules"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Disabled                    bool      `json:"disabled"`
-	NoMediaPlayer               bool      `json:"no_media_player"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	HideGlobally                bool      `json:"hide_globally"`
-	DisableHTTP2                bool      `json:"disable_http2"`
-	PushoverEnabled             bool      `json:"pushover_enabled"`
-	NtfyEnabled                 bool      `json:"ntfy_enabled"`
-	Crawler                     bool      `json:"crawler"`
-	AppriseServiceURLs          string    `json:"apprise_service_urls"`
-	WebhookURL                  string    `json:"webhook_url"`
-	NtfyPriority                int       `json:"ntfy_priority"`
-	NtfyTopic                   string    `json:"ntfy_topic"`
-	PushoverPriority            int       `json:"pushover_priority"`
-	ProxyURL                    string    `json:"proxy_url"`
+	ID                          int64       `json:"id"`
+	UserID                      int64       `json:"user_id"`
+	FeedURL                     string      `json:"feed_url"`
+	SiteURL                     string      `json:"site_url"`
+	Title                       string      `json:"title"`
+	Description                 string      `json:"description"`
+	CheckedAt                   time.Time   `json:"checked_at"`
+	NextCheckAt                 time.Time   `json:"next_check_at"`
+	EtagHeader                  string      `json:"etag_header"`
+	LastModifiedHeader          string      `json:"last_modified_header"`
+	ParsingErrorMsg             string      `json:"parsing_error_message"`
+	ParsingErrorCount           int         `json:"parsing_error_count"`
+	ScraperRules                string      `json:"scraper_rules"`
+	RewriteRules                string      `json:"rewrite_rules"`
+	BlocklistRules              string      `json:"blocklist_rules"`
+	KeeplistRules               string      `json:"keeplist_rules"`
+	BlockFilterEntryRules       string      `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        string      `json:"keep_filter_entry_rules"`
+	EntryActionRules            string      `json:"entry_action_rules"`
+	UrlRewriteRules             string      `json:"urlrewrite_rules"`
+	UserAgent                   string      `json:"user_agent"`
+	Cookie                      string      `json:"cookie"`
+	Username                    string      `json:"username"`
+	Password                    string      `json:"password"`
+	Disabled                    bool        `json:"disabled"`
+	NoMediaPlayer               bool        `json:"no_media_player"`
+	IgnoreHTTPCache             bool        `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool        `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool        `json:"fetch_via_proxy"`
+	HideGlobally                bool        `json:"hide_globally"`
+	DisableHTTP2                bool        `json:"disable_http2"`
+	PushoverEnabled             bool        `json:"pushover_enabled"`
+	NtfyEnabled                 bool        `json:"ntfy_enabled"`
+	Crawler                     bool        `json:"crawler"`
+	AppriseServiceURLs          string      `json:"apprise_service_urls"`
+	WebhookURL                  string      `json:"webhook_url"`
+	NtfyPriority                int         `json:"ntfy_priority"`
+	NtfyTopic                   string      `json:"ntfy_topic"`
+	PushoverPriority            int         `json:"pushover_priority"`
+	ProxyURL                    string      `json:"proxy_url"`
+	WebPageItemSelector         string      `json:"webpage_item_selector"`
+	WebPageTitleSelector        string      `json:"webpage_title_selector"`
+	WebPageLinkSelector         string      `json:"webpage_link_selector"`
+	WebPageDateSelector         string      `json:"webpage_date_selector"`
+	WebPageContentSelector      string      `json:"webpage_content_selector"`
+	SkipHours                   []int64     `json:"skip_hours"`
+	SkipDays                    []string    `json:"skip_days"`
+	IgnoreSkipHints             bool        `json:"ignore_skip_hints"`
+	ProposedFeedURL             string      `json:"proposed_feed_url"`
+	CustomHeaders               FeedHeaders `json:"custom_headers"`
+	ClientCertificate           string      `json:"client_certificate"`
+	ClientPrivateKey            string      `json:"client_private_key"`
+	NewsletterToken             string      `json:"newsletter_token,omitempty"`
 
 	// Non-persisted attributes
 	Category *Category `json:"category,omitempty"`
@@ -68,11 +84,15 @@ type Feed struct {
 	Entries  Entries   `json:"entries,omitempty"`
 
 	// Internal attributes (not exposed in the API and not persisted in the database)
-	TTL                    time.Duration `json:"-"`
-	IconURL                string        `json:"-"`
-	UnreadCount            int           `json:"-"`
-	ReadCount              int           `json:"-"`
-	NumberOfVisibleEntries int           `json:"-"`
+	TTL                    time.Duration         `json:"-"`
+	PublicationHistogram   *PublicationHistogram `json:"-"`
+	WebSubSubscription     *WebSubSubscription   `json:"-"`
+	HubURL                 string                `json:"-"`
+	ArchivePageURL         string                `json:"-"`
+	IconURL                string                `json:"-"`
+	UnreadCount            int                   `json:"-"`
+	ReadCount              int                   `json:"-"`
+	NumberOfVisibleEntries int                   `json:"-"`
 }
 
 type FeedCounters struct {
@@ -103,9 +123,103 @@ func (f *Feed) WithTranslatedErrorMessage(message string) {
 }
 
 // ResetErrorCounter removes all previous errors.
+// The replacement feed URL proposed after the errors is not relevant anymore.
 func (f *Feed) ResetErrorCounter() {
 	f.ParsingErrorCount = 0
 	f.ParsingErrorMsg = ""
+	f.ProposedFeedURL = ""
+}
+
+// WithFeedURL replaces the feed URL and discards the proposed replacement URL.
+func (f *Feed) WithFeedURL(feedURL string) {
+	if f.FeedURL != feedURL {
+		f.FeedURL = feedURL
+		f.ProposedFeedURL = ""
+	}
+}
+
+// WithRedactedSecrets hides the custom header values and the client private key, before sending the feed to API clients.
+func (f *Feed) WithRedactedSecrets() {
+	f.CustomHeaders = f.CustomHeaders.Redacted()
+	if f.ClientPrivateKey != "" {
+		f.ClientPrivateKey = RedactedValue
+	}
+}
+
+// IsWebPageFeed returns true if the entries are extracted from a web page with CSS selectors.
+func (f *Feed) IsWebPageFeed() bool {
+	return f.WebPageItemSelector != ""
+}
+
+// IsNewsletter returns true if the entries are received by email instead of being fetched.
+func (f *Feed) IsNewsletter() bool {
+	return f.NewsletterToken != ""
+}
+
+// NewsletterAddress returns the email address that delivers entries to this feed.
+func (f *Feed) NewsletterAddress() string {
+	if f.NewsletterToken == "" {
+		return ""
+	}
+	return NewsletterAddress(f.NewsletterToken)
+}
+
+// EffectiveScraperRules returns the feed scraper rules, or the category scraper rules if the feed has none.
+func (f *Feed) EffectiveScraperRules() string {
+	if f.ScraperRules == "" && f.Category != nil {
+		return f.Category.ScraperRules
+	}
+	return f.ScraperRules
+}
+
+// EffectiveRewriteRules returns the category rewrite rules followed by the feed rewrite rules.
+func (f *Feed) EffectiveRewriteRules() string {
+	if f.Category == nil || f.Category.RewriteRules == "" {
+		return f.RewriteRules
+	}
+	if f.RewriteRules == "" {
+		return f.Category.RewriteRules
+	}
+	return f.Category.RewriteRules + "," + f.RewriteRules
+}
+
+// EffectiveUrlRewriteRules returns the feed URL rewrite rules, or the category URL rewrite rules if the feed has none.
+func (f *Feed) EffectiveUrlRewriteRules() string {
+	if f.UrlRewriteRules == "" && f.Category != nil {
+		return f.Category.UrlRewriteRules
+	}
+	return f.UrlRewriteRules
+}
+
+// EffectiveBlockFilterEntryRules returns the category block filter rules followed by the feed block filter rules.
+func (f *Feed) EffectiveBlockFilterEntryRules() string {
+	if f.Category == nil {
+		return f.BlockFilterEntryRules
+	}
+	return joinFilterEntryRules(f.Category.BlockFilterEntryRules, f.BlockFilterEntryRules)
+}
+
+// EffectiveKeepFilterEntryRules returns the category keep filter rules followed by the feed keep filter rules.
+func (f *Feed) EffectiveKeepFilterEntryRules() string {
+	if f.Category == nil {
+		return f.KeepFilterEntryRules
+	}
+	return joinFilterEntryRules(f.Category.KeepFilterEntryRules, f.KeepFilterEntryRules)
+}
+
+func joinFilterEntryRules(categoryRules, feedRules string) string {
+	if categoryRules == "" {
+		return feedRules
+	}
+	if feedRules == "" {
+		return categoryRules
+	}
+	return categoryRules + "\n" + feedRules
+}
+
+// IsCrawlerEnabled returns true if the crawler is enabled on the feed or on its category.
+func (f *Feed) IsCrawlerEnabled() bool {
+	return f.Crawler || (f.Category != nil && f.Category.Crawler)
 }
 
 // CheckedNow set attribute values when the feed is refreshed.
@@ -122,13 +236,32 @@ func (f *Feed) ScheduleNextCheck(weeklyCount int, refreshDelay time.Duration) ti
 	// Default to the global config Polling Frequency.
 	interval := config.Opts.SchedulerRoundRobinMinInterval()
 
-	if config.Opts.PollingScheduler() == SchedulerEntryFrequency {
-		if weeklyCount <= 0 {
-			interval = config.Opts.SchedulerEntryFrequencyMaxInterval()
-		} else {
-			interval = (7 * 24 * time.Hour) / time.Duration(weeklyCount*config.Opts.SchedulerEntryFrequencyFactor())
-			interval = min(interval, config.Opts.SchedulerEntryFrequencyMaxInterval())
-			interval = max(interval, config.Opts.SchedulerEntryFrequencyMinInterval())
+	switch config.Opts.PollingScheduler() {
+	case SchedulerEntryFrequency:
+		interval = entryFrequencyInterval(weeklyCount)
+	case SchedulerPublicationTime:
+		interval = entryFrequencyInterval(weeklyCount)
+
+		// Check the feed shortly after the next time it usually publishes, unless the entry frequency is higher.
+		if f.PublicationHistogram != nil {
+			now := time.Now()
+			if nextPublication, found := f.PublicationHistogram.NextPublication(now); found {
+				interval = min(interval, nextPublication.Sub(now)+publicationTimeGracePeriod)
+				interval = max(interval, config.Opts.SchedulerEntryFrequencyMinInterval())
+			}
+		}
+	}
+
+	maxInterval := config.Opts.SchedulerRoundRobinMaxInterval()
+	switch config.Opts.PollingScheduler() {
+	case SchedulerEntryFrequency, SchedulerPublicationTime:
+		maxInterval = config.Opts.SchedulerEntryFrequencyMaxInterval()
+	}
+
+	// Feeds delivered by a WebSub hub are polled as rarely as possible, but soon enough to renew the lease.
+	if f.WebSubSubscription != nil && f.WebSubSubscription.IsActive() {
+		if untilRenewal := f.WebSubSubscription.TimeUntilRenewal(); untilRenewal > interval {
+			interval = min(untilRenewal, maxInterval)
 		}
 	}
 
@@ -136,41 +269,85 @@ func (f *Feed) ScheduleNextCheck(weeklyCount int, refreshDelay time.Duration) ti
 	interval = max(interval, refreshDelay)
 
 	// Limit the max interval value for misconfigured feeds.
-	switch config.Opts.PollingScheduler() {
-	case SchedulerRoundRobin:
-		interval = min(interval, config.Opts.SchedulerRoundRobinMaxInterval())
-	case SchedulerEntryFrequency:
-		interval = min(interval, config.Opts.SchedulerEntryFrequencyMaxInterval())
+	interval = min(interval, maxInterval)
+
+	now := time.Now()
+	f.NextCheckAt = now.Add(interval)
+
+	// The skip hints are applied last: the publisher knows better than our heuristics when nothing will be published.
+	if !f.IgnoreSkipHints {
+		f.NextCheckAt = nextCheckOutsideSkippedPeriods(f.NextCheckAt, f.SkipHours, f.SkipDays)
+		interval = f.NextCheckAt.Sub(now)
 	}
 
-	f.NextCheckAt = time.Now().Add(interval)
 	return interval
 }
 
+func entryFrequencyInterval(weeklyCount int) time.Duration {
+	if weeklyCount <= 0 {
+		return config.Opts.SchedulerEntryFrequencyMaxInterval()
+	}
+
+	interval := (7 * 24 * time.Hour) / time.Duration(weeklyCount*config.Opts.SchedulerEntryFrequencyFactor())
+	interval = min(interval, config.Opts.SchedulerEntryFrequencyMaxInterval())
+	return max(interval, config.Opts.SchedulerEntryFrequencyMinInterval())
+}
+
+// nextCheckOutsideSkippedPeriods postpones the check to the beginning of the first hour that is not skipped.
+// Skipped hours and days are expressed in GMT, as defined by the RSS specification.
+// The hints are ignored if they cover the whole week.
+func nextCheckOutsideSkippedPeriods(nextCheckAt time.Time, skipHours []int64, skipDays []string) time.Time {
+	if len(skipHours) == 0 && len(skipDays) == 0 {
+		return nextCheckAt
+	}
+
+	candidate := nextCheckAt
+	for range 7 * 24 {
+		utc := candidate.UTC()
+		if !slices.Contains(skipHours, int64(utc.Hour())) && !slices.Contains(skipDays, utc.Weekday().String()) {
+			return candidate
+		}
+		candidate = utc.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	return nextCheckAt
+}
+
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
-	FeedURL                     string `json:"feed_url"`
-	CategoryID                  int64  `json:"category_id"`
-	UserAgent                   string `json:"user_agent"`
-	Cookie                      string `json:"cookie"`
-	Username                    string `json:"username"`
-	Password                    string `json:"password"`
-	Crawler                     bool   `json:"crawler"`
-	Disabled                    bool   `json:"disabled"`
-	NoMediaPlayer               bool   `json:"no_media_player"`
-	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool   `json:"fetch_via_proxy"`
-	HideGlobally                bool   `json:"hide_globally"`
-	DisableHTTP2                bool   `json:"disable_http2"`
-	ScraperRules                string `json:"scraper_rules"`
-	RewriteRules                string `json:"rewrite_rules"`
-	BlocklistRules              string `json:"blocklist_rules"`
-	KeeplistRules               string `json:"keeplist_rules"`
-	BlockFilterEntryRules       string `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        string `json:"keep_filter_entry_rules"`
-	UrlRewriteRules             string `json:"urlrewrite_rules"`
-	ProxyURL                    string `json:"proxy_url"`
+	FeedURL                     string      `json:"feed_url"`
+	CategoryID                  int64       `json:"category_id"`
+	UserAgent                   string      `json:"user_agent"`
+	Cookie                      string      `json:"cookie"`
+	Username                    string      `json:"username"`
+	Password                    string      `json:"password"`
+	Crawler                     bool        `json:"crawler"`
+	Disabled                    bool        `json:"disabled"`
+	NoMediaPlayer               bool        `json:"no_media_player"`
+	IgnoreHTTPCache             bool        `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool        `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool        `json:"fetch_via_proxy"`
+	HideGlobally                bool        `json:"hide_globally"`
+	DisableHTTP2                bool        `json:"disable_http2"`
+	ScraperRules                string      `json:"scraper_rules"`
+	RewriteRules                string      `json:"rewrite_rules"`
+	BlocklistRules              string      `json:"blocklist_rules"`
+	KeeplistRules               string      `json:"keeplist_rules"`
+	BlockFilterEntryRules       string      `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        string      `json:"keep_filter_entry_rules"`
+	EntryActionRules            string      `json:"entry_action_rules"`
+	UrlRewriteRules             string      `json:"urlrewrite_rules"`
+	ProxyURL                    string      `json:"proxy_url"`
+	WebPageItemSelector         string      `json:"webpage_item_selector"`
+	WebPageTitleSelector        string      `json:"webpage_title_selector"`
+	WebPageLinkSelector         string      `json:"webpage_link_selector"`
+	WebPageDateSelector         string      `json:"webpage_date_selector"`
+	WebPageContentSelector      string      `json:"webpage_content_selector"`
+	IgnoreSkipHints             bool        `json:"ignore_skip_hints"`
+	CustomHeaders               FeedHeaders `json:"custom_headers"`
+	ClientCertificate           string      `json:"client_certificate"`
+	ClientPrivateKey            string      `json:"client_private_key"`
+	BackfillHistory             bool        `json:"backfill_history"`
 }
 
 type FeedCreationRequestFromSubscriptionDiscovery struct {
@@ -183,37 +360,47 @@ type FeedCreationRequestFromSubscriptionDiscovery struct {
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string `json:"feed_url"`
-	SiteURL                     *string `json:"site_url"`
-	Title                       *string `json:"title"`
-	Description                 *string `json:"description"`
-	ScraperRules                *string `json:"scraper_rules"`
-	RewriteRules                *string `json:"rewrite_rules"`
-	BlocklistRules              *string `json:"blocklist_rules"`
-	UrlRewriteRules             *string `json:"urlrewrite_rules"`
-	KeeplistRules               *string `json:"keeplist_rules"`
-	BlockFilterEntryRules       *string `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        *string `json:"keep_filter_entry_rules"`
-	Crawler                     *bool   `json:"crawler"`
-	UserAgent                   *string `json:"user_agent"`
-	Cookie                      *string `json:"cookie"`
-	Username                    *string `json:"username"`
-	Password                    *string `json:"password"`
-	CategoryID                  *int64  `json:"category_id"`
-	Disabled                    *bool   `json:"disabled"`
-	NoMediaPlayer               *bool   `json:"no_media_player"`
-	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
-	HideGlobally                *bool   `json:"hide_globally"`
-	DisableHTTP2                *bool   `json:"disable_http2"`
-	ProxyURL                    *string `json:"proxy_url"`
+	FeedURL                     *string      `json:"feed_url"`
+	SiteURL                     *string      `json:"site_url"`
+	Title                       *string      `json:"title"`
+	Description                 *string      `json:"description"`
+	ScraperRules                *string      `json:"scraper_rules"`
+	RewriteRules                *string      `json:"rewrite_rules"`
+	BlocklistRules              *string      `json:"blocklist_rules"`
+	UrlRewriteRules             *string      `json:"urlrewrite_rules"`
+	KeeplistRules               *string      `json:"keeplist_rules"`
+	BlockFilterEntryRules       *string      `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        *string      `json:"keep_filter_entry_rules"`
+	EntryActionRules            *string      `json:"entry_action_rules"`
+	Crawler                     *bool        `json:"crawler"`
+	UserAgent                   *string      `json:"user_agent"`
+	Cookie                      *string      `json:"cookie"`
+	Username                    *string      `json:"username"`
+	Password                    *string      `json:"password"`
+	CategoryID                  *int64       `json:"category_id"`
+	Disabled                    *bool        `json:"disabled"`
+	NoMediaPlayer               *bool        `json:"no_media_player"`
+	IgnoreHTTPCache             *bool        `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool        `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool        `json:"fetch_via_proxy"`
+	HideGlobally                *bool        `json:"hide_globally"`
+	DisableHTTP2                *bool        `json:"disable_http2"`
+	ProxyURL                    *string      `json:"proxy_url"`
+	WebPageItemSelector         *string      `json:"webpage_item_selector"`
+	WebPageTitleSelector        *string      `json:"webpage_title_selector"`
+	WebPageLinkSelector         *string      `json:"webpage_link_selector"`
+	WebPageDateSelector         *string      `json:"webpage_date_selector"`
+	WebPageContentSelector      *string      `json:"webpage_content_selector"`
+	IgnoreSkipHints             *bool        `json:"ignore_skip_hints"`
+	CustomHeaders               *FeedHeaders `json:"custom_headers"`
+	ClientCertificate           *string      `json:"client_certificate"`
+	ClientPrivateKey            *string      `json:"client_private_key"`
 }
 
 // Patch updates a feed with modified values.
 func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.FeedURL != nil && *f.FeedURL != "" {
-		feed.FeedURL = *f.FeedURL
+		feed.WithFeedURL(*f.FeedURL)
 	}
 
 	if f.SiteURL != nil && *f.SiteURL != "" {
@@ -256,6 +443,10 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 		feed.KeepFilterEntryRules = *f.KeepFilterEntryRules
 	}
 
+	if f.EntryActionRules != nil {
+		feed.EntryActionRules = *f.EntryActionRules
+	}
+
 	if f.Crawler != nil {
 		feed.Crawler = *f.Crawler
 	}
@@ -311,7 +502,79 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.ProxyURL != nil {
 		feed.ProxyURL = *f.ProxyURL
 	}
+
+	if f.WebPageItemSelector != nil {
+		feed.WebPageItemSelector = *f.WebPageItemSelector
+	}
+
+	if f.WebPageTitleSelector != nil {
+		feed.WebPageTitleSelector = *f.WebPageTitleSelector
+	}
+
+	if f.WebPageLinkSelector != nil {
+		feed.WebPageLinkSelector = *f.WebPageLinkSelector
+	}
+
+	if f.WebPageDateSelector != nil {
+		feed.WebPageDateSelector = *f.WebPageDateSelector
+	}
+
+	if f.WebPageContentSelector != nil {
+		feed.WebPageContentSelector = *f.WebPageContentSelector
+	}
+
+	if f.IgnoreSkipHints != nil {
+		feed.IgnoreSkipHints = *f.IgnoreSkipHints
+	}
+
+	if f.CustomHeaders != nil {
+		feed.CustomHeaders = f.CustomHeaders.WithSecretsFrom(feed.CustomHeaders)
+	}
+
+	if f.ClientCertificate != nil {
+		feed.ClientCertificate = *f.ClientCertificate
+	}
+
+	if f.ClientPrivateKey != nil && *f.ClientPrivateKey != RedactedValue {
+		feed.ClientPrivateKey = *f.ClientPrivateKey
+	}
+}
+
+// FeedFilterTestRequest represents the request to test filter rules against the entries of a feed.
+// Rules left empty in the request default to the rules saved on the feed.
+type FeedFilterTestRequest struct {
+	BlocklistRules        *string `json:"blocklist_rules"`
+	KeeplistRules         *string `json:"keeplist_rules"`
+	BlockFilterEntryRules *string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  *string `json:"keep_filter_entry_rules"`
+	Limit                 int     `json:"limit"`
+}
+
+// Patch updates a feed with the rules to test.
+func (f *FeedFilterTestRequest) Patch(feed *Feed) {
+	if f.BlocklistRules != nil {
+		feed.BlocklistRules = *f.BlocklistRules
+	}
+
+	if f.KeeplistRules != nil {
+		feed.KeeplistRules = *f.KeeplistRules
+	}
+
+	if f.BlockFilterEntryRules != nil {
+		feed.BlockFilterEntryRules = *f.BlockFilterEntryRules
+	}
+
+	if f.KeepFilterEntryRules != nil {
+		feed.KeepFilterEntryRules = *f.KeepFilterEntryRules
+	}
 }
 
 // Feeds is a list of feed
 type Feeds []*Feed
+
+// WithRedactedSecrets hides the secrets of all the feeds of the list.
+func (f Feeds) WithRedactedSecrets() {
+	for _, feed := range f {
+		feed.WithRedactedSecrets()
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+)
+
+// FeedFetch represents a refresh attempt of a feed, kept in the fetch history.
+type FeedFetch struct {
+	ID              int64     `json:"id"`
+	FeedID          int64     `json:"feed_id"`
+	FetchedAt       time.Time `json:"fetched_at"`
+	StatusCode      int       `json:"status_code"`
+	ResponseTimeMs  int64     `json:"response_time_ms"`
+	ResponseSize    int64     `json:"response_size"`
+	NotModified     bool      `json:"not_modified"`
+	NewEntries      int       `json:"new_entries"`
+	ErrorMsg        string    `json:"error_message"`
+	PreviousFeedURL string    `json:"previous_feed_url"`
+	NewFeedURL      string    `json:"new_feed_url"`
+}
+
+// HasError returns true if the refresh attempt failed.
+func (f *FeedFetch) HasError() bool {
+	return f.ErrorMsg != ""
+}
+
+// HasFeedURLChanged returns true if the feed URL was replaced during the refresh attempt.
+func (f *FeedFetch) HasFeedURLChanged() bool {
+	return f.NewFeedURL != ""
+}
+
+// FeedFetches represents a list of refresh attempts.
+type FeedFetches []*FeedFetch
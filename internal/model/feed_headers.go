@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// RedactedValue replaces the secrets of a feed in API responses.
+// A secret sent back with this value is left unchanged.
+const RedactedValue = "********"
+
+// FeedHeaders represents the custom HTTP headers sent when fetching a feed.
+type FeedHeaders map[string]string
+
+// ParseFeedHeaders reads the headers from a text with one "Name: Value" header per line.
+// Lines without a colon are kept with an empty value, so they can be reported by the validator.
+func ParseFeedHeaders(text string) FeedHeaders {
+	headers := make(FeedHeaders)
+	for line := range strings.Lines(text) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(line, ":")
+		headers[http.CanonicalHeaderKey(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// String returns the headers with one "Name: Value" header per line, sorted by name.
+func (h FeedHeaders) String() string {
+	var builder strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(h)) {
+		builder.WriteString(name)
+		builder.WriteString(": ")
+		builder.WriteString(h[name])
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// Redacted returns a copy of the headers where the values are hidden.
+func (h FeedHeaders) Redacted() FeedHeaders {
+	redactedHeaders := make(FeedHeaders, len(h))
+	for name := range h {
+		redactedHeaders[name] = RedactedValue
+	}
+	return redactedHeaders
+}
+
+// WithSecretsFrom returns a copy of the headers where the redacted values are restored from the previous headers.
+func (h FeedHeaders) WithSecretsFrom(previousHeaders FeedHeaders) FeedHeaders {
+	headers := make(FeedHeaders, len(h))
+	for name, value := range h {
+		if previousValue, found := previousHeaders[name]; found && value == RedactedValue {
+			value = previousValue
+		}
+		headers[name] = value
+	}
+	return headers
+}
+
+// Value converts the headers to JSON.
+func (h FeedHeaders) Value() (driver.Value, error) {
+	if h == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(h)
+}
+
+// Scan converts raw JSON data.
+func (h *FeedHeaders) Scan(src any) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("feed headers: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, h); err != nil {
+		return fmt.Errorf("feed headers: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+)
+
+func TestParseFeedHeaders(t *testing.T) {
+	headers := ParseFeedHeaders("authorization: Bearer token\r\n\n  X-Api-Key:1234:5678  \ninvalid\n")
+
+	if len(headers) != 3 {
+		t.Fatalf(`Unexpected number of headers, got %d`, len(headers))
+	}
+
+	if headers["Authorization"] != "Bearer token" {
+		t.Errorf(`Unexpected Authorization header, got %q`, headers["Authorization"])
+	}
+
+	if headers["X-Api-Key"] != "1234:5678" {
+		t.Errorf(`Unexpected X-Api-Key header, got %q`, headers["X-Api-Key"])
+	}
+
+	if value, found := headers["Invalid"]; !found || value != "" {
+		t.Errorf(`Lines without a colon should be kept with an empty value, got %q`, value)
+	}
+}
+
+func TestFeedHeadersString(t *testing.T) {
+	headers := FeedHeaders{"X-Api-Key": "1234", "Authorization": "Bearer token"}
+
+	if text := headers.String(); text != "Authorization: Bearer token\nX-Api-Key: 1234\n" {
+		t.Errorf(`Unexpected text, got %q`, text)
+	}
+
+	if parsedHeaders := ParseFeedHeaders(headers.String()); len(parsedHeaders) != 2 || parsedHeaders["X-Api-Key"] != "1234" {
+		t.Errorf(`The text should be parsed back to the same headers, got %v`, parsedHeaders)
+	}
+}
+
+func TestFeedHeadersRedacted(t *testing.T) {
+	headers := FeedHeaders{"Authorization": "Bearer token"}
+	redactedHeaders := headers.Redacted()
+
+	if redactedHeaders["Authorization"] != RedactedValue {
+		t.Errorf(`The header value should be redacted, got %q`, redactedHeaders["Authorization"])
+	}
+
+	if headers["Authorization"] != "Bearer token" {
+		t.Error(`The original headers should not be modified`)
+	}
+}
+
+func TestFeedHeadersWithSecretsFrom(t *testing.T) {
+	previousHeaders := FeedHeaders{"Authorization": "Bearer token", "X-Api-Key": "1234"}
+	headers := FeedHeaders{"Authorization": RedactedValue, "X-Api-Key": "5678", "X-Other": RedactedValue}.WithSecretsFrom(previousHeaders)
+
+	if headers["Authorization"] != "Bearer token" {
+		t.Errorf(`The redacted value should be restored, got %q`, headers["Authorization"])
+	}
+
+	if headers["X-Api-Key"] != "5678" {
+		t.Errorf(`The new value should be kept, got %q`, headers["X-Api-Key"])
+	}
+
+	if headers["X-Other"] != RedactedValue {
+		t.Errorf(`A redacted value without previous value should be kept, got %q`, headers["X-Other"])
+	}
+}
+
+func TestFeedModificationRequestKeepsRedactedSecrets(t *testing.T) {
+	feed := &Feed{
+		CustomHeaders:    FeedHeaders{"Authorization": "Bearer token"},
+		ClientPrivateKey: "private key",
+	}
+
+	redactedFeed := &Feed{CustomHeaders: feed.CustomHeaders, ClientPrivateKey: feed.ClientPrivateKey}
+	redactedFeed.WithRedactedSecrets()
+
+	modificationRequest := &FeedModificationRequest{
+		CustomHeaders:    &redactedFeed.CustomHeaders,
+		ClientPrivateKey: &redactedFeed.ClientPrivateKey,
+	}
+	modificationRequest.Patch(feed)
+
+	if feed.CustomHeaders["Authorization"] != "Bearer token" {
+		t.Errorf(`The header value should not be replaced by the redacted value, got %q`, feed.CustomHeaders["Authorization"])
+	}
+
+	if feed.ClientPrivateKey != "private key" {
+		t.Errorf(`The private key should not be replaced by the redacted value, got %q`, feed.ClientPrivateKey)
+	}
+}
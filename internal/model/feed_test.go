@@ -53,6 +53,97 @@ func TestFeedErrorCounter(t *testing.T) {
 	}
 }
 
+func TestFeedProposedURLIsDiscardedWithErrors(t *testing.T) {
+	feed := &Feed{ProposedFeedURL: "https://example.org/new-feed.xml"}
+	feed.WithTranslatedErrorMessage("Some Error")
+	feed.ResetErrorCounter()
+
+	if feed.ProposedFeedURL != "" {
+		t.Error(`The proposed feed URL must be removed with the errors`)
+	}
+}
+
+func TestFeedWithFeedURL(t *testing.T) {
+	feed := &Feed{FeedURL: "https://example.org/feed.xml", ProposedFeedURL: "https://example.org/new-feed.xml"}
+
+	feed.WithFeedURL("https://example.org/feed.xml")
+	if feed.ProposedFeedURL == "" {
+		t.Error(`The proposed feed URL must be kept when the feed URL is not modified`)
+	}
+
+	feed.WithFeedURL("https://example.org/new-feed.xml")
+	if feed.FeedURL != "https://example.org/new-feed.xml" {
+		t.Errorf(`Unexpected feed URL, got %q`, feed.FeedURL)
+	}
+
+	if feed.ProposedFeedURL != "" {
+		t.Error(`The proposed feed URL must be removed when the feed URL is modified`)
+	}
+}
+
+func TestFeedCategoryRules(t *testing.T) {
+	feed := &Feed{}
+	if feed.EffectiveScraperRules() != "" || feed.EffectiveRewriteRules() != "" || feed.EffectiveUrlRewriteRules() != "" || feed.IsCrawlerEnabled() {
+		t.Error(`A feed without category should not have any rules`)
+	}
+
+	feed.Category = &Category{
+		ScraperRules:    "article",
+		RewriteRules:    "add_image_title",
+		UrlRewriteRules: `rewrite("^http:"|"https:")`,
+		Crawler:         true,
+	}
+
+	if feed.EffectiveScraperRules() != "article" {
+		t.Errorf(`The category scraper rules should be used, got %q`, feed.EffectiveScraperRules())
+	}
+
+	if feed.EffectiveRewriteRules() != "add_image_title" {
+		t.Errorf(`The category rewrite rules should be used, got %q`, feed.EffectiveRewriteRules())
+	}
+
+	if feed.EffectiveUrlRewriteRules() != `rewrite("^http:"|"https:")` {
+		t.Errorf(`The category URL rewrite rules should be used, got %q`, feed.EffectiveUrlRewriteRules())
+	}
+
+	if !feed.IsCrawlerEnabled() {
+		t.Error(`The crawler should be enabled by the category`)
+	}
+
+	feed.ScraperRules = "main"
+	feed.RewriteRules = "nl2br"
+	feed.UrlRewriteRules = `rewrite("^https://old"|"https://new")`
+
+	if feed.EffectiveScraperRules() != "main" {
+		t.Errorf(`The feed scraper rules should take precedence, got %q`, feed.EffectiveScraperRules())
+	}
+
+	if feed.EffectiveRewriteRules() != "add_image_title,nl2br" {
+		t.Errorf(`The feed rewrite rules should be applied after the category rules, got %q`, feed.EffectiveRewriteRules())
+	}
+
+	if feed.EffectiveUrlRewriteRules() != `rewrite("^https://old"|"https://new")` {
+		t.Errorf(`The feed URL rewrite rules should take precedence, got %q`, feed.EffectiveUrlRewriteRules())
+	}
+}
+
+func TestFeedCategoryFilterEntryRules(t *testing.T) {
+	feed := &Feed{BlockFilterEntryRules: "EntryTitle=(?i)ads", KeepFilterEntryRules: "EntryTitle=(?i)go"}
+	if feed.EffectiveBlockFilterEntryRules() != "EntryTitle=(?i)ads" || feed.EffectiveKeepFilterEntryRules() != "EntryTitle=(?i)go" {
+		t.Error(`A feed without category should only have its own filter rules`)
+	}
+
+	feed.Category = &Category{BlockFilterEntryRules: "EntryAuthor=bot"}
+
+	if feed.EffectiveBlockFilterEntryRules() != "EntryAuthor=bot\nEntryTitle=(?i)ads" {
+		t.Errorf(`The category block rules should be applied before the feed rules, got %q`, feed.EffectiveBlockFilterEntryRules())
+	}
+
+	if feed.EffectiveKeepFilterEntryRules() != "EntryTitle=(?i)go" {
+		t.Errorf(`The feed keep rules should be used when the category has none, got %q`, feed.EffectiveKeepFilterEntryRules())
+	}
+}
+
 func TestFeedCheckedNow(t *testing.T) {
 	feed := &Feed{}
 	feed.FeedURL = "https://example.org/feed"
@@ -376,3 +467,226 @@ func TestFeedScheduleNextCheckEntryFrequencyLargeNewTTL(t *testing.T) {
 		t.Error(`The next_check_at should be after timeBefore + entry frequency min interval`)
 	}
 }
+
+func TestNextCheckOutsideSkippedPeriods(t *testing.T) {
+	// 2024-03-02 is a Saturday.
+	nextCheckAt := time.Date(2024, time.March, 2, 22, 30, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		skipHours []int64
+		skipDays  []string
+		expected  time.Time
+	}{
+		{nil, nil, nextCheckAt},
+		{[]int64{1, 2}, nil, nextCheckAt},
+		{[]int64{22}, nil, time.Date(2024, time.March, 2, 23, 0, 0, 0, time.UTC)},
+		{[]int64{22, 23, 0}, nil, time.Date(2024, time.March, 3, 1, 0, 0, 0, time.UTC)},
+		{nil, []string{"Saturday"}, time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)},
+		{[]int64{0, 1}, []string{"Saturday", "Sunday"}, time.Date(2024, time.March, 4, 2, 0, 0, 0, time.UTC)},
+		{nil, []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}, nextCheckAt},
+	}
+
+	for _, scenario := range scenarios {
+		result := nextCheckOutsideSkippedPeriods(nextCheckAt, scenario.skipHours, scenario.skipDays)
+		if !result.Equal(scenario.expected) {
+			t.Errorf(`Unexpected next check for hours %v and days %v: got %v instead of %v`, scenario.skipHours, scenario.skipDays, result, scenario.expected)
+		}
+	}
+}
+
+func TestNextCheckOutsideSkippedPeriodsUsesGMT(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	nextCheckAt := time.Date(2024, time.March, 2, 10, 15, 0, 0, location)
+
+	result := nextCheckOutsideSkippedPeriods(nextCheckAt, []int64{8}, nil)
+	expected := time.Date(2024, time.March, 2, 9, 0, 0, 0, time.UTC)
+	if !result.Equal(expected) {
+		t.Errorf(`The skipped hours should be in GMT, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestFeedScheduleNextCheckWithSkipHours(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	targetInterval := config.Opts.SchedulerRoundRobinMinInterval()
+	skippedHour := time.Now().Add(targetInterval).UTC()
+
+	feed := &Feed{SkipHours: []int64{int64(skippedHour.Hour())}}
+	interval := feed.ScheduleNextCheck(0, noRefreshDelay)
+
+	if feed.NextCheckAt.UTC().Hour() == skippedHour.Hour() {
+		t.Errorf(`The next_check_at should not be during a skipped hour, got %v`, feed.NextCheckAt)
+	}
+
+	if feed.NextCheckAt.Before(skippedHour.Truncate(time.Hour).Add(time.Hour)) {
+		t.Errorf(`The next_check_at should be postponed to the next hour, got %v`, feed.NextCheckAt)
+	}
+
+	if interval <= targetInterval {
+		t.Errorf(`The returned interval should include the skipped period, got %v`, interval)
+	}
+}
+
+func TestFeedScheduleNextCheckIgnoringSkipHints(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	targetInterval := config.Opts.SchedulerRoundRobinMinInterval()
+	skippedHour := timeBefore.Add(targetInterval).UTC().Hour()
+
+	feed := &Feed{SkipHours: []int64{int64(skippedHour)}, IgnoreSkipHints: true}
+	feed.ScheduleNextCheck(0, noRefreshDelay)
+
+	checkTargetInterval(t, feed, targetInterval, timeBefore, "TestFeedScheduleNextCheckIgnoringSkipHints")
+}
+
+func TestFeedScheduleNextCheckPublicationTimeWithoutHistogram(t *testing.T) {
+	maxInterval := 120
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "publication_time")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", strconv.Itoa(maxInterval))
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{}
+	feed.ScheduleNextCheck(0, noRefreshDelay)
+
+	targetInterval := time.Duration(maxInterval) * time.Minute
+	checkTargetInterval(t, feed, targetInterval, timeBefore, "publication time without histogram")
+}
+
+func TestFeedScheduleNextCheckPublicationTime(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "publication_time")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	slot := time.Now().UTC().Add(3 * time.Hour).Truncate(time.Hour)
+	histogram := &PublicationHistogram{}
+	histogram.Add(slot.Weekday(), slot.Hour(), 4, 30)
+
+	// A daily feed would be checked every 24 hours by the entry frequency scheduler.
+	feed := &Feed{PublicationHistogram: histogram}
+	feed.ScheduleNextCheck(7, noRefreshDelay)
+
+	expected := slot.Add(30*time.Minute + publicationTimeGracePeriod)
+	if feed.NextCheckAt.Before(expected.Add(-time.Second)) || feed.NextCheckAt.After(expected.Add(time.Second)) {
+		t.Errorf(`The next_check_at should be shortly after the usual publication time, got %v instead of %v`, feed.NextCheckAt, expected)
+	}
+}
+
+func TestFeedScheduleNextCheckPublicationTimeWithHigherEntryFrequency(t *testing.T) {
+	minInterval := 5
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "publication_time")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", strconv.Itoa(minInterval))
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	slot := time.Now().UTC().Add(3 * time.Hour).Truncate(time.Hour)
+	histogram := &PublicationHistogram{}
+	histogram.Add(slot.Weekday(), slot.Hour(), 4, 30)
+
+	timeBefore := time.Now()
+	feed := &Feed{PublicationHistogram: histogram}
+	feed.ScheduleNextCheck(largeWeeklyCount, noRefreshDelay)
+
+	targetInterval := time.Duration(minInterval) * time.Minute
+	checkTargetInterval(t, feed, targetInterval, timeBefore, "publication time with higher entry frequency")
+}
+
+func TestFeedScheduleNextCheckWithActiveWebSubSubscription(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{WebSubSubscription: &WebSubSubscription{
+		State:          WebSubStateActive,
+		LeaseExpiresAt: timeBefore.Add(10 * 24 * time.Hour),
+	}}
+	feed.ScheduleNextCheck(0, noRefreshDelay)
+
+	targetInterval := config.Opts.SchedulerRoundRobinMaxInterval()
+	checkTargetInterval(t, feed, targetInterval, timeBefore, "TestFeedScheduleNextCheckWithActiveWebSubSubscription")
+}
+
+func TestFeedScheduleNextCheckWithWebSubLeaseToRenew(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{WebSubSubscription: &WebSubSubscription{
+		State:          WebSubStateActive,
+		LeaseExpiresAt: timeBefore.Add(webSubRenewalMargin + 3*time.Hour),
+	}}
+	feed.ScheduleNextCheck(0, noRefreshDelay)
+
+	// The feed must be checked before the renewal time to send a new subscription request.
+	if feed.NextCheckAt.After(timeBefore.Add(3*time.Hour + time.Second)) {
+		t.Errorf(`The next_check_at should be before the lease renewal, got %v`, feed.NextCheckAt)
+	}
+
+	if feed.NextCheckAt.Before(timeBefore.Add(config.Opts.SchedulerRoundRobinMinInterval())) {
+		t.Errorf(`The next_check_at should not be before the min interval, got %v`, feed.NextCheckAt)
+	}
+}
+
+func TestFeedScheduleNextCheckWithPendingWebSubSubscription(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	timeBefore := time.Now()
+	feed := &Feed{WebSubSubscription: &WebSubSubscription{State: WebSubStatePending}}
+	feed.ScheduleNextCheck(0, noRefreshDelay)
+
+	targetInterval := config.Opts.SchedulerRoundRobinMinInterval()
+	checkTargetInterval(t, feed, targetInterval, timeBefore, "TestFeedScheduleNextCheckWithPendingWebSubSubscription")
+}
@@ -3,11 +3,35 @@
 
 package model // import "miniflux.app/v2/internal/model"
 
+// Job priorities: manual refreshes are processed before the background ones.
+const (
+	JobPriorityBackground = 0
+	JobPriorityManual     = 10
+)
+
+// Job statuses.
+const (
+	JobStatusPending = "pending"
+	JobStatusRunning = "running"
+)
+
+// Job kinds: a feed has at most one job of each kind in the queue.
+const (
+	JobKindRefresh  = "refresh"
+	JobKindBackfill = "backfill"
+)
+
 // Job represents a payload sent to the processing queue.
+// The archive page URL is the first page walked by a backfill job.
 type Job struct {
-	UserID  int64
-	FeedID  int64
-	FeedURL string
+	ID             int64
+	UserID         int64
+	FeedID         int64
+	FeedURL        string
+	Kind           string
+	ArchivePageURL string
+	Priority       int
+	Attempts       int
 }
 
 // JobList represents a list of jobs.
@@ -22,3 +46,10 @@ func (jl *JobList) FeedURLs() []string {
 	}
 	return feedURLs
 }
+
+// JobQueueStats represents the number of jobs in the queue by status and priority.
+type JobQueueStats struct {
+	Pending       int64 `json:"pending"`
+	PendingManual int64 `json:"pending_manual"`
+	Running       int64 `json:"running"`
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+
+	"miniflux.app/v2/internal/config"
+)
+
+// NewsletterAddress returns the email address identified by the given token.
+func NewsletterAddress(token string) string {
+	return token + "@" + config.Opts.NewsletterDomain()
+}
+
+// NewsletterAttachment represents a file attached to a newsletter.
+// The file is stored in the database because it is not available elsewhere, the enclosures of the entry point to it.
+type NewsletterAttachment struct {
+	ID        int64
+	EntryID   int64
+	Token     string
+	Filename  string
+	MimeType  string
+	Content   []byte
+	CreatedAt time.Time
+}
+
+// URL returns the address of the attachment, anyone knowing the token can download it like any other enclosure.
+func (a *NewsletterAttachment) URL() string {
+	return config.Opts.BaseURL() + "/newsletter/attachment/" + a.Token
+}
+
+// NewsletterFeedCreationRequest represents the request to create a feed with its own newsletter address.
+type NewsletterFeedCreationRequest struct {
+	Title      string
+	CategoryID int64
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PodcastEpisode represents the Podcasting 2.0 metadata of an entry.
+type PodcastEpisode struct {
+	Season       int                  `json:"season,omitempty"`
+	SeasonName   string               `json:"season_name,omitempty"`
+	Episode      float64              `json:"episode,omitempty"`
+	EpisodeName  string               `json:"episode_name,omitempty"`
+	Transcripts  []*PodcastTranscript `json:"transcripts,omitempty"`
+	ChaptersURL  string               `json:"chapters_url,omitempty"`
+	ChaptersType string               `json:"chapters_type,omitempty"`
+	Chapters     []*PodcastChapter    `json:"chapters,omitempty"`
+	Persons      []*PodcastPerson     `json:"persons,omitempty"`
+	Funding      []*PodcastFunding    `json:"funding,omitempty"`
+	Soundbites   []*PodcastSoundbite  `json:"soundbites,omitempty"`
+}
+
+// PodcastTranscript represents a transcript or closed captions file of an episode.
+type PodcastTranscript struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Language string `json:"language,omitempty"`
+	Rel      string `json:"rel,omitempty"`
+}
+
+// PodcastChapter represents a chapter of an episode, the start time is in seconds.
+type PodcastChapter struct {
+	StartTime float64 `json:"start_time"`
+	Title     string  `json:"title"`
+	URL       string  `json:"url,omitempty"`
+}
+
+// FormattedStartTime returns the start time of the chapter as a clock.
+func (c *PodcastChapter) FormattedStartTime() string {
+	return formatMediaTime(c.StartTime)
+}
+
+// PodcastPerson represents a person taking part in an episode or in the whole podcast.
+type PodcastPerson struct {
+	Name     string `json:"name"`
+	Role     string `json:"role,omitempty"`
+	Group    string `json:"group,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// PodcastFunding represents a link to donate to or support the podcast.
+type PodcastFunding struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// PodcastSoundbite represents a highlight of an episode, the start time and the duration are in seconds.
+type PodcastSoundbite struct {
+	StartTime float64 `json:"start_time"`
+	Duration  float64 `json:"duration"`
+	Title     string  `json:"title,omitempty"`
+}
+
+// FormattedStartTime returns the start time of the soundbite as a clock.
+func (s *PodcastSoundbite) FormattedStartTime() string {
+	return formatMediaTime(s.StartTime)
+}
+
+// IsEmpty returns true when the entry has no podcast metadata.
+func (p *PodcastEpisode) IsEmpty() bool {
+	return p.Season == 0 && p.SeasonName == "" && p.Episode == 0 && p.EpisodeName == "" &&
+		len(p.Transcripts) == 0 && p.ChaptersURL == "" && len(p.Chapters) == 0 &&
+		len(p.Persons) == 0 && len(p.Funding) == 0 && len(p.Soundbites) == 0
+}
+
+// Value converts the podcast metadata to JSON.
+func (p PodcastEpisode) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan converts raw JSON data.
+func (p *PodcastEpisode) Scan(src any) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("podcast episode: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, p); err != nil {
+		return fmt.Errorf("podcast episode: %v", err)
+	}
+
+	return nil
+}
+
+func formatMediaTime(seconds float64) string {
+	totalSeconds := int(max(seconds, 0))
+	hours, minutes, remainingSeconds := totalSeconds/3600, totalSeconds%3600/60, totalSeconds%60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, remainingSeconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, remainingSeconds)
+}
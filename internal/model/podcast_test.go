@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestPodcastChapterFormattedStartTime(t *testing.T) {
+	scenarios := map[float64]string{
+		0:      "0:00",
+		65.9:   "1:05",
+		3725:   "1:02:05",
+		-10:    "0:00",
+		36000:  "10:00:00",
+		599.99: "9:59",
+	}
+
+	for startTime, expected := range scenarios {
+		chapter := &PodcastChapter{StartTime: startTime}
+		if result := chapter.FormattedStartTime(); result != expected {
+			t.Errorf(`Unexpected start time for %v, got %q instead of %q`, startTime, result, expected)
+		}
+	}
+}
+
+func TestPodcastEpisodeValueAndScan(t *testing.T) {
+	podcastEpisode := PodcastEpisode{
+		Season:   1,
+		Chapters: []*PodcastChapter{{StartTime: 30, Title: "Introduction"}},
+	}
+
+	value, err := podcastEpisode.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scannedPodcastEpisode PodcastEpisode
+	if err := scannedPodcastEpisode.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+
+	if scannedPodcastEpisode.Season != 1 || len(scannedPodcastEpisode.Chapters) != 1 || scannedPodcastEpisode.Chapters[0].Title != "Introduction" {
+		t.Errorf(`Unexpected podcast metadata: %+v`, scannedPodcastEpisode)
+	}
+
+	if err := scannedPodcastEpisode.Scan("invalid"); err == nil {
+		t.Error(`Scanning a string should return an error`)
+	}
+
+	if !new(PodcastEpisode).IsEmpty() || scannedPodcastEpisode.IsEmpty() {
+		t.Error(`Unexpected IsEmpty result`)
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+
+	"miniflux.app/v2/internal/config"
+)
+
+// Sources of the entries published by a public feed.
+const (
+	PublicFeedSourceCategory = "category"
+	PublicFeedSourceTag      = "tag"
+	PublicFeedSourceStarred  = "starred"
+	PublicFeedSourceSearch   = "search"
+)
+
+// Formats of a public feed.
+const (
+	PublicFeedFormatRSS  = "rss"
+	PublicFeedFormatAtom = "atom"
+	PublicFeedFormatJSON = "json"
+)
+
+// PublicFeed represents a stream of entries of a user published as a feed.
+// The feed is available to anyone knowing its token, until it is removed.
+type PublicFeed struct {
+	ID            int64             `json:"id"`
+	UserID        int64             `json:"user_id"`
+	Token         string            `json:"token"`
+	Title         string            `json:"title"`
+	Source        string            `json:"source"`
+	CategoryID    int64             `json:"category_id,omitempty"`
+	CategoryTitle string            `json:"category_title,omitempty"`
+	Tag           string            `json:"tag,omitempty"`
+	SearchQuery   string            `json:"search_query,omitempty"`
+	LastUsedAt    *time.Time        `json:"last_used_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	URLs          map[string]string `json:"urls"`
+}
+
+// URL returns the address of the feed in the given format.
+func (p *PublicFeed) URL(format string) string {
+	return config.Opts.BaseURL() + "/public/" + p.Token + "/" + format
+}
+
+// FeedURLs returns the addresses of the feed in each format.
+func (p *PublicFeed) FeedURLs() map[string]string {
+	return map[string]string{
+		PublicFeedFormatRSS:  p.URL(PublicFeedFormatRSS),
+		PublicFeedFormatAtom: p.URL(PublicFeedFormatAtom),
+		PublicFeedFormatJSON: p.URL(PublicFeedFormatJSON),
+	}
+}
+
+// PublicFeeds represents a collection of public feeds.
+type PublicFeeds []*PublicFeed
+
+// PublicFeedCreationRequest represents the request to publish a new feed.
+type PublicFeedCreationRequest struct {
+	Title       string `json:"title"`
+	Source      string `json:"source"`
+	CategoryID  int64  `json:"category_id"`
+	Tag         string `json:"tag"`
+	SearchQuery string `json:"search_query"`
+}
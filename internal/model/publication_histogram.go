@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+)
+
+const (
+	// minPublicationsPerSlot is the number of entries that must have been published during the same hour of the week
+	// to consider that the feed usually publishes at this time.
+	minPublicationsPerSlot = 2
+
+	// publicationTimeGracePeriod leaves some time to the publisher to update the feed after the usual publication time.
+	publicationTimeGracePeriod = 10 * time.Minute
+)
+
+// PublicationHistogram counts the entries of a feed published during each hour of the week, in UTC.
+type PublicationHistogram struct {
+	// Counts is indexed by weekday (Sunday is 0) and hour.
+	Counts [7][24]int
+
+	// AverageMinutes is the average minute of the hour when the entries of the slot were published.
+	AverageMinutes [7][24]int
+}
+
+// Add records the publications of one slot.
+func (h *PublicationHistogram) Add(weekday time.Weekday, hour, count, averageMinute int) {
+	if weekday < time.Sunday || weekday > time.Saturday || hour < 0 || hour > 23 {
+		return
+	}
+
+	h.Counts[weekday][hour] = count
+	h.AverageMinutes[weekday][hour] = min(max(averageMinute, 0), 59)
+}
+
+// NextPublication returns the next time after the given date when the feed usually publishes new entries.
+func (h *PublicationHistogram) NextPublication(after time.Time) (time.Time, bool) {
+	slot := after.UTC().Truncate(time.Hour)
+
+	// One extra slot is checked because the usual publication time of the current hour may already be over.
+	for range 7*24 + 1 {
+		weekday, hour := slot.Weekday(), slot.Hour()
+		if h.Counts[weekday][hour] >= minPublicationsPerSlot {
+			publication := slot.Add(time.Duration(h.AverageMinutes[weekday][hour]) * time.Minute)
+			if publication.After(after) {
+				return publication, true
+			}
+		}
+		slot = slot.Add(time.Hour)
+	}
+
+	return time.Time{}, false
+}
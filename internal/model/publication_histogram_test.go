@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublicationHistogramNextPublication(t *testing.T) {
+	histogram := &PublicationHistogram{}
+
+	// A feed published every weekday around 9:00 UTC, and once on Saturday at 14:00.
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		histogram.Add(weekday, 9, 4, 2)
+	}
+	histogram.Add(time.Saturday, 14, 1, 0)
+
+	// 2024-03-04 is a Monday.
+	scenarios := []struct {
+		after    time.Time
+		expected time.Time
+	}{
+		{time.Date(2024, time.March, 4, 6, 0, 0, 0, time.UTC), time.Date(2024, time.March, 4, 9, 2, 0, 0, time.UTC)},
+		{time.Date(2024, time.March, 4, 9, 1, 0, 0, time.UTC), time.Date(2024, time.March, 4, 9, 2, 0, 0, time.UTC)},
+		{time.Date(2024, time.March, 4, 9, 30, 0, 0, time.UTC), time.Date(2024, time.March, 5, 9, 2, 0, 0, time.UTC)},
+		{time.Date(2024, time.March, 8, 10, 0, 0, 0, time.UTC), time.Date(2024, time.March, 11, 9, 2, 0, 0, time.UTC)},
+		{time.Date(2024, time.March, 4, 15, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60)), time.Date(2024, time.March, 5, 9, 2, 0, 0, time.UTC)},
+	}
+
+	for _, scenario := range scenarios {
+		result, found := histogram.NextPublication(scenario.after)
+		if !found {
+			t.Errorf(`A publication should be found after %v`, scenario.after)
+			continue
+		}
+
+		if !result.Equal(scenario.expected) {
+			t.Errorf(`Unexpected next publication after %v: got %v instead of %v`, scenario.after, result, scenario.expected)
+		}
+	}
+}
+
+func TestPublicationHistogramWithoutRegularPublication(t *testing.T) {
+	histogram := &PublicationHistogram{}
+	histogram.Add(time.Monday, 9, 1, 0)
+	histogram.Add(time.Tuesday, 17, 1, 45)
+
+	if _, found := histogram.NextPublication(time.Now()); found {
+		t.Error(`Isolated publications should not be considered as a regular publication time`)
+	}
+}
+
+func TestPublicationHistogramIgnoresInvalidSlots(t *testing.T) {
+	histogram := &PublicationHistogram{}
+	histogram.Add(time.Weekday(7), 9, 10, 0)
+	histogram.Add(time.Monday, 24, 10, 0)
+	histogram.Add(time.Monday, -1, 10, 0)
+
+	if _, found := histogram.NextPublication(time.Now()); found {
+		t.Error(`Invalid slots should be ignored`)
+	}
+}
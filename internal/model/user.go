@@ -41,6 +41,8 @@ type User struct {
 	MediaPlaybackRate               float64    `json:"media_playback_rate"`
 	BlockFilterEntryRules           string     `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules            string     `json:"keep_filter_entry_rules"`
+	EntryActionRules                string     `json:"entry_action_rules"`
+	DuplicateEntriesPolicy          string     `json:"duplicate_entries_policy"`
 	AlwaysOpenExternalLinks         bool       `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab       bool       `json:"open_external_links_in_new_tab"`
 }
@@ -84,6 +86,8 @@ type UserModificationRequest struct {
 	MediaPlaybackRate               *float64 `json:"media_playback_rate"`
 	BlockFilterEntryRules           *string  `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules            *string  `json:"keep_filter_entry_rules"`
+	EntryActionRules                *string  `json:"entry_action_rules"`
+	DuplicateEntriesPolicy          *string  `json:"duplicate_entries_policy"`
 	AlwaysOpenExternalLinks         *bool    `json:"always_open_external_links"`
 	OpenExternalLinksInNewTab       *bool    `json:"open_external_links_in_new_tab"`
 }
@@ -202,6 +206,14 @@ func (u *UserModificationRequest) Patch(user *User) {
 		user.KeepFilterEntryRules = *u.KeepFilterEntryRules
 	}
 
+	if u.EntryActionRules != nil {
+		user.EntryActionRules = *u.EntryActionRules
+	}
+
+	if u.DuplicateEntriesPolicy != nil {
+		user.DuplicateEntriesPolicy = *u.DuplicateEntriesPolicy
+	}
+
 	if u.AlwaysOpenExternalLinks != nil {
 		user.AlwaysOpenExternalLinks = *u.AlwaysOpenExternalLinks
 	}
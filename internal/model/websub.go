@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+)
+
+// List of WebSub subscription states.
+const (
+	WebSubStatePending = "pending"
+	WebSubStateActive  = "active"
+	WebSubStateDenied  = "denied"
+)
+
+const (
+	// webSubRenewalMargin is how long before the end of the lease the subscription is renewed.
+	webSubRenewalMargin = 24 * time.Hour
+
+	// webSubRetryDelay is the delay before subscribing again when the hub did not verify or denied the previous request.
+	webSubRetryDelay = 24 * time.Hour
+)
+
+// WebSubSubscription represents the subscription of a feed to a WebSub hub.
+type WebSubSubscription struct {
+	FeedID         int64
+	UserID         int64
+	HubURL         string
+	TopicURL       string
+	CallbackID     string
+	Secret         string
+	State          string
+	LeaseExpiresAt time.Time
+	UpdatedAt      time.Time
+}
+
+// IsActive returns true if the hub verified the subscription and the lease is not expired.
+func (s *WebSubSubscription) IsActive() bool {
+	return s.State == WebSubStateActive && s.LeaseExpiresAt.After(time.Now())
+}
+
+// TimeUntilRenewal returns the remaining time before the subscription must be renewed.
+func (s *WebSubSubscription) TimeUntilRenewal() time.Duration {
+	return time.Until(s.LeaseExpiresAt) - webSubRenewalMargin
+}
+
+// NeedsRenewal returns true if a subscription request must be sent to the hub.
+func (s *WebSubSubscription) NeedsRenewal(hubURL, topicURL string) bool {
+	switch {
+	case s.HubURL != hubURL || s.TopicURL != topicURL:
+		return true
+	case s.State == WebSubStateActive:
+		return s.TimeUntilRenewal() <= 0
+	default:
+		return time.Since(s.UpdatedAt) >= webSubRetryDelay
+	}
+}
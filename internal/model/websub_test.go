@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebSubSubscriptionIsActive(t *testing.T) {
+	scenarios := []struct {
+		subscription *WebSubSubscription
+		expected     bool
+	}{
+		{&WebSubSubscription{State: WebSubStateActive, LeaseExpiresAt: time.Now().Add(time.Hour)}, true},
+		{&WebSubSubscription{State: WebSubStateActive, LeaseExpiresAt: time.Now().Add(-time.Hour)}, false},
+		{&WebSubSubscription{State: WebSubStatePending, LeaseExpiresAt: time.Now().Add(time.Hour)}, false},
+		{&WebSubSubscription{State: WebSubStateDenied}, false},
+	}
+
+	for i, scenario := range scenarios {
+		if actual := scenario.subscription.IsActive(); actual != scenario.expected {
+			t.Errorf(`Scenario #%d: got %v instead of %v`, i, actual, scenario.expected)
+		}
+	}
+}
+
+func TestWebSubSubscriptionNeedsRenewal(t *testing.T) {
+	hubURL := "https://hub.example.org/"
+	topicURL := "https://example.org/feed.xml"
+
+	scenarios := []struct {
+		name         string
+		subscription *WebSubSubscription
+		expected     bool
+	}{
+		{
+			"active subscription",
+			&WebSubSubscription{HubURL: hubURL, TopicURL: topicURL, State: WebSubStateActive, LeaseExpiresAt: time.Now().Add(5 * 24 * time.Hour)},
+			false,
+		},
+		{
+			"lease about to expire",
+			&WebSubSubscription{HubURL: hubURL, TopicURL: topicURL, State: WebSubStateActive, LeaseExpiresAt: time.Now().Add(time.Hour)},
+			true,
+		},
+		{
+			"different hub",
+			&WebSubSubscription{HubURL: "https://other-hub.example.org/", TopicURL: topicURL, State: WebSubStateActive, LeaseExpiresAt: time.Now().Add(5 * 24 * time.Hour)},
+			true,
+		},
+		{
+			"different topic",
+			&WebSubSubscription{HubURL: hubURL, TopicURL: "https://example.org/atom.xml", State: WebSubStateActive, LeaseExpiresAt: time.Now().Add(5 * 24 * time.Hour)},
+			true,
+		},
+		{
+			"recent pending subscription",
+			&WebSubSubscription{HubURL: hubURL, TopicURL: topicURL, State: WebSubStatePending, UpdatedAt: time.Now().Add(-time.Hour)},
+			false,
+		},
+		{
+			"old pending subscription",
+			&WebSubSubscription{HubURL: hubURL, TopicURL: topicURL, State: WebSubStatePending, UpdatedAt: time.Now().Add(-2 * webSubRetryDelay)},
+			true,
+		},
+		{
+			"old denied subscription",
+			&WebSubSubscription{HubURL: hubURL, TopicURL: topicURL, State: WebSubStateDenied, UpdatedAt: time.Now().Add(-2 * webSubRetryDelay)},
+			true,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		if actual := scenario.subscription.NeedsRenewal(hubURL, topicURL); actual != scenario.expected {
+			t.Errorf(`%s: got %v instead of %v`, scenario.name, actual, scenario.expected)
+		}
+	}
+}
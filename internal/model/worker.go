@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+
+	"miniflux.app/v2/internal/timezone"
+)
+
+// WorkerStatus represents what a background worker of this instance is doing.
+type WorkerStatus struct {
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	Busy          bool       `json:"busy"`
+	JobID         int64      `json:"job_id,omitempty"`
+	UserID        int64      `json:"user_id,omitempty"`
+	FeedID        int64      `json:"feed_id,omitempty"`
+	FeedURL       string     `json:"feed_url,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	ProcessedJobs int64      `json:"processed_jobs"`
+}
+
+// SchedulerStatus represents the state of the feed scheduler of this instance.
+type SchedulerStatus struct {
+	Frequency     int64      `json:"frequency"` // in seconds
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastBatchSize int        `json:"last_batch_size"`
+}
+
+// WorkerPoolStatus represents the background workers, the scheduler and the hosts limited by this instance, and the shared job queue.
+type WorkerPoolStatus struct {
+	InstanceName string          `json:"instance_name"`
+	Workers      []*WorkerStatus `json:"workers"`
+	Queue        *JobQueueStats  `json:"queue"`
+	Scheduler    SchedulerStatus `json:"scheduler"`
+	Hosts        []*HostStatus   `json:"hosts"`
+}
+
+// BusyWorkers returns the number of workers processing a job.
+func (w *WorkerPoolStatus) BusyWorkers() int {
+	busyWorkers := 0
+	for _, worker := range w.Workers {
+		if worker.Busy {
+			busyWorkers++
+		}
+	}
+	return busyWorkers
+}
+
+// UseTimezone converts the dates to the given timezone.
+func (w *WorkerPoolStatus) UseTimezone(tz string) {
+	for _, worker := range w.Workers {
+		if worker.StartedAt != nil {
+			*worker.StartedAt = timezone.Convert(tz, *worker.StartedAt)
+		}
+	}
+
+	if w.Scheduler.LastRunAt != nil {
+		*w.Scheduler.LastRunAt = timezone.Convert(tz, *w.Scheduler.LastRunAt)
+	}
+
+	if w.Scheduler.NextRunAt != nil {
+		*w.Scheduler.NextRunAt = timezone.Convert(tz, *w.Scheduler.NextRunAt)
+	}
+
+	for _, host := range w.Hosts {
+		if host.PausedUntil != nil {
+			*host.PausedUntil = timezone.Convert(tz, *host.PausedUntil)
+		}
+	}
+}
+
+// HostStatus represents the state of a host receiving feed requests from this instance.
+// The host is paused when it asked to slow down or failed repeatedly.
+type HostStatus struct {
+	Host                string     `json:"host"`
+	ActiveRequests      int        `json:"active_requests"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	PausedUntil         *time.Time `json:"paused_until,omitempty"`
+	PauseReason         string     `json:"pause_reason,omitempty"`
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolStatusBusyWorkers(t *testing.T) {
+	poolStatus := &WorkerPoolStatus{
+		Workers: []*WorkerStatus{
+			{ID: 0, Busy: true},
+			{ID: 1},
+			{ID: 2, Busy: true},
+		},
+	}
+
+	if busyWorkers := poolStatus.BusyWorkers(); busyWorkers != 2 {
+		t.Errorf(`Unexpected number of busy workers, got %d instead of 2`, busyWorkers)
+	}
+}
+
+func TestWorkerPoolStatusUseTimezone(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	lastRunAt := startedAt.Add(-time.Minute)
+	poolStatus := &WorkerPoolStatus{
+		Workers:   []*WorkerStatus{{ID: 0, Busy: true, StartedAt: &startedAt}, {ID: 1}},
+		Scheduler: SchedulerStatus{LastRunAt: &lastRunAt},
+	}
+
+	poolStatus.UseTimezone("Europe/Paris")
+
+	if location := poolStatus.Workers[0].StartedAt.Location().String(); location != "Europe/Paris" {
+		t.Errorf(`Unexpected timezone for the start date of the job, got %q`, location)
+	}
+
+	if poolStatus.Workers[1].StartedAt != nil {
+		t.Error(`The start date of an idle worker should remain empty`)
+	}
+
+	if location := poolStatus.Scheduler.LastRunAt.Location().String(); location != "Europe/Paris" {
+		t.Errorf(`Unexpected timezone for the last run of the scheduler, got %q`, location)
+	}
+
+	if poolStatus.Scheduler.NextRunAt != nil {
+		t.Error(`The next run of the scheduler should remain empty`)
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+)
+
+var (
+	errUnknownRecipient = errors.New("newsletter: unknown recipient")
+	errRejectedMessage  = errors.New("newsletter: rejected message")
+)
+
+// isPermanentError returns true when sending the message again would fail the same way.
+func isPermanentError(err error) bool {
+	return errors.Is(err, errUnknownRecipient) || errors.Is(err, errRejectedMessage)
+}
+
+// NewServer returns a server storing the newsletters in the database, it is configured with the NEWSLETTER_* options.
+func NewServer(store *storage.Storage) *Server {
+	return newServer(
+		&storeBackend{store: store},
+		config.Opts.NewsletterProtocol(),
+		config.Opts.NewsletterDomain(),
+		config.Opts.NewsletterMaxMessageSize(),
+	)
+}
+
+// CreateFeed creates an empty feed with its own newsletter address.
+func CreateFeed(store *storage.Storage, userID int64, request *model.NewsletterFeedCreationRequest) (*model.Feed, error) {
+	token := crypto.GenerateRandomStringHex(16)
+	return createFeed(store, userID, request.CategoryID, request.Title, "mailto:"+model.NewsletterAddress(token), token)
+}
+
+func createFeed(store *storage.Storage, userID, categoryID int64, title, feedURL, token string) (*model.Feed, error) {
+	feed := &model.Feed{
+		UserID:          userID,
+		Title:           title,
+		FeedURL:         feedURL,
+		SiteURL:         feedURL,
+		NewsletterToken: token,
+	}
+	feed.WithCategoryID(categoryID)
+
+	if err := store.CreateFeed(feed); err != nil {
+		return nil, err
+	}
+
+	return store.FeedByID(userID, feed.ID)
+}
+
+// storeBackend delivers the messages sent to a feed address to this feed.
+// The messages sent to the personal address of a user are delivered to a feed per sender, created on the first message.
+// The number of sender feeds is capped because the sender is easily forged, the messages of the other senders
+// are delivered to a single feed.
+type storeBackend struct {
+	store *storage.Storage
+}
+
+func (b *storeBackend) checkRecipient(address string) error {
+	token, err := addressToken(address)
+	if err != nil {
+		return err
+	}
+
+	feed, err := b.store.FeedByNewsletterToken(token)
+	if err != nil {
+		return err
+	}
+	if feed != nil {
+		return nil
+	}
+
+	userID, err := b.store.UserIDByNewsletterToken(token)
+	if err != nil {
+		return err
+	}
+	if userID == 0 {
+		return errUnknownRecipient
+	}
+
+	return nil
+}
+
+func (b *storeBackend) deliver(address string, data []byte) error {
+	token, err := addressToken(address)
+	if err != nil {
+		return err
+	}
+
+	m, err := parseMessage(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errRejectedMessage, err)
+	}
+
+	feed, err := b.store.FeedByNewsletterToken(token)
+	if err != nil {
+		return err
+	}
+
+	if feed == nil {
+		userID, err := b.store.UserIDByNewsletterToken(token)
+		if err != nil {
+			return err
+		}
+		if userID == 0 {
+			return errUnknownRecipient
+		}
+
+		if feed, err = b.senderFeed(userID, token, m); err != nil {
+			return err
+		}
+	}
+
+	entry, attachments := newEntry(m)
+	if localizedError := handler.ProcessNewsletterEntry(b.store, feed, entry, attachments); localizedError != nil {
+		return localizedError.Error()
+	}
+
+	return nil
+}
+
+// senderFeed returns the feed of the messages sent by the author of the message, the feed is created if needed.
+// Past the NEWSLETTER_MAX_SENDER_FEEDS limit, the shared feed of the personal address is returned instead.
+func (b *storeBackend) senderFeed(userID int64, token string, m *message) (*model.Feed, error) {
+	if m.From == nil || m.From.Address == "" {
+		return nil, fmt.Errorf("%w: the sender is missing", errRejectedMessage)
+	}
+
+	feedURL := "mailto:" + strings.ToLower(m.From.Address)
+	feed, err := b.store.NewsletterFeedByURL(userID, feedURL)
+	if err != nil || feed != nil {
+		return feed, err
+	}
+
+	title := m.From.Name
+	if title == "" {
+		title = m.From.Address
+	}
+
+	nbSenderFeeds, err := b.store.CountNewsletterSenderFeeds(userID, config.Opts.NewsletterDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	if nbSenderFeeds >= config.Opts.NewsletterMaxSenderFeeds() {
+		feedURL = "mailto:" + model.NewsletterAddress(token)
+		title = "Newsletters"
+
+		feed, err := b.store.NewsletterFeedByURL(userID, feedURL)
+		if err != nil || feed != nil {
+			return feed, err
+		}
+	}
+
+	category, err := b.store.FirstCategory(userID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("newsletter: user #%d has no category", userID)
+	}
+
+	return createFeed(b.store, userID, category.ID, title, feedURL, crypto.GenerateRandomStringHex(16))
+}
+
+// addressToken returns the token identifying the feed or the user in an address of the newsletter domain.
+func addressToken(address string) (string, error) {
+	localPart, domain, found := strings.Cut(strings.ToLower(address), "@")
+	if !found || localPart == "" || domain != config.Opts.NewsletterDomain() {
+		return "", errUnknownRecipient
+	}
+
+	return localPart, nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+)
+
+var paragraphSeparator = regexp.MustCompile(`\n[ \t]*\n`)
+
+// newEntry converts the message to an entry, the attachments are returned separately because they are stored once the entry is created.
+// The images displayed in the HTML body are served like the attached files, they are not listed as enclosures.
+func newEntry(m *message) (*model.Entry, []*model.NewsletterAttachment) {
+	entry := model.NewEntry()
+	entry.URL = m.ArchivedAt
+	entry.Title = m.Subject
+	entry.Date = m.Date
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	if m.From != nil {
+		entry.Author = m.From.Name
+		if entry.Author == "" {
+			entry.Author = m.From.Address
+		}
+	}
+
+	entry.Content = m.HTML
+	if entry.Content == "" {
+		entry.Content = textToHTML(m.Text)
+	}
+
+	var attachments []*model.NewsletterAttachment
+	var contentIDReplacements []string
+	for _, part := range m.Attachments {
+		attachment := &model.NewsletterAttachment{
+			Token:    crypto.GenerateRandomStringHex(20),
+			Filename: part.Filename,
+			MimeType: part.MimeType,
+			Content:  part.Content,
+		}
+		attachments = append(attachments, attachment)
+
+		if part.ContentID != "" && strings.Contains(entry.Content, "cid:"+part.ContentID) {
+			contentIDReplacements = append(contentIDReplacements, "cid:"+part.ContentID, attachment.URL())
+			continue
+		}
+
+		entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
+			URL:      attachment.URL(),
+			MimeType: attachment.MimeType,
+			Size:     int64(len(attachment.Content)),
+		})
+	}
+
+	if len(contentIDReplacements) > 0 {
+		entry.Content = strings.NewReplacer(contentIDReplacements...).Replace(entry.Content)
+	}
+
+	if entry.Title == "" {
+		entry.Title = entry.Date.Format(time.DateOnly)
+	}
+
+	// A message delivered twice, to several addresses or retried by the sender, has the same Message-ID.
+	if m.MessageID != "" {
+		entry.Hash = crypto.SHA256(m.MessageID)
+	} else {
+		entry.Hash = crypto.SHA256(entry.Author + ":" + entry.Title + ":" + strconv.FormatInt(entry.Date.Unix(), 10) + ":" + entry.Content)
+	}
+
+	return entry, attachments
+}
+
+func textToHTML(text string) string {
+	var builder strings.Builder
+	for _, paragraph := range paragraphSeparator.Split(strings.ReplaceAll(text, "\r\n", "\n"), -1) {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		builder.WriteString("<p>")
+		builder.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>"))
+		builder.WriteString("</p>")
+	}
+	return builder.String()
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/reader/encoding"
+)
+
+// Messages nested deeper than this are not worth reading, the parts are ignored.
+const maxPartDepth = 10
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: encoding.CharsetReader}
+
+// message represents a newsletter decoded from a MIME message.
+type message struct {
+	MessageID   string
+	Subject     string
+	From        *mail.Address
+	Date        time.Time
+	ArchivedAt  string
+	HTML        string
+	Text        string
+	Attachments []*attachment
+}
+
+// attachment represents a part of the message that is not its body: an attached file or an image displayed in the body.
+type attachment struct {
+	Filename  string
+	MimeType  string
+	ContentID string
+	Content   []byte
+}
+
+type partHeader interface {
+	Get(key string) string
+}
+
+// parseMessage decodes the headers, the body and the attachments of a MIME message.
+func parseMessage(r io.Reader) (*message, error) {
+	rawMessage, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("newsletter: unable to read message: %w", err)
+	}
+
+	m := &message{
+		MessageID:  strings.TrimSpace(rawMessage.Header.Get("Message-Id")),
+		Subject:    decodeHeader(rawMessage.Header.Get("Subject")),
+		ArchivedAt: archivedAtURL(rawMessage.Header.Get("Archived-At")),
+	}
+
+	addressParser := mail.AddressParser{WordDecoder: wordDecoder}
+	if from, err := addressParser.Parse(rawMessage.Header.Get("From")); err == nil {
+		m.From = from
+	}
+
+	if date, err := rawMessage.Header.Date(); err == nil {
+		m.Date = date
+	}
+
+	if err := m.readPart(rawMessage.Header, rawMessage.Body, 0); err != nil {
+		return nil, err
+	}
+
+	if m.HTML == "" && m.Text == "" && len(m.Attachments) == 0 {
+		return nil, errors.New("newsletter: the message is empty")
+	}
+
+	return m, nil
+}
+
+func (m *message) readPart(header partHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	body = decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return fmt.Errorf("newsletter: missing boundary for %s part", mediaType)
+		}
+
+		reader := multipart.NewReader(body, boundary)
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("newsletter: unable to read %s part: %w", mediaType, err)
+			}
+
+			if err := m.readPart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = decodeHeader(filename)
+
+	// The first text parts that are not attached files are the body, the multipart/alternative parts are equivalent.
+	isBody := disposition != "attachment" && filename == ""
+	if isBody && (mediaType == "text/html" && m.HTML == "" || mediaType == "text/plain" && m.Text == "") {
+		text, err := readText(body, params["charset"])
+		if err != nil {
+			return fmt.Errorf("newsletter: unable to read %s part: %w", mediaType, err)
+		}
+
+		if mediaType == "text/html" {
+			m.HTML = text
+		} else {
+			m.Text = text
+		}
+		return nil
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("newsletter: unable to read %s attachment: %w", mediaType, err)
+	}
+
+	if len(content) > 0 {
+		m.Attachments = append(m.Attachments, &attachment{
+			Filename:  filename,
+			MimeType:  mediaType,
+			ContentID: strings.Trim(header.Get("Content-Id"), "<> "),
+			Content:   content,
+		})
+	}
+
+	return nil
+}
+
+func decodeTransferEncoding(transferEncoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(transferEncoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+func readText(body io.Reader, charset string) (string, error) {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	// The text is kept as is when the charset is unknown, the invalid characters are replaced.
+	if charset != "" && !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "us-ascii") {
+		if charsetReader, err := encoding.CharsetReader(charset, bytes.NewReader(content)); err == nil {
+			if convertedContent, err := io.ReadAll(charsetReader); err == nil {
+				content = convertedContent
+			}
+		}
+	}
+
+	return strings.ToValidUTF8(string(content), "\uFFFD"), nil
+}
+
+func decodeHeader(value string) string {
+	decodedValue, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decodedValue)
+}
+
+// archivedAtURL returns the web page of the message, from the header defined in RFC 5064.
+func archivedAtURL(value string) string {
+	archivedAt := strings.Trim(value, "<> \t")
+	if strings.HasPrefix(archivedAt, "https://") || strings.HasPrefix(archivedAt, "http://") {
+		return archivedAt
+	}
+	return ""
+}
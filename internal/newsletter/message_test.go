@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+)
+
+func parseTestMessage(t *testing.T, data string) *message {
+	t.Helper()
+	m, err := parseMessage(strings.NewReader(strings.ReplaceAll(data, "\n", "\r\n")))
+	if err != nil {
+		t.Fatalf(`Unable to parse message: %v`, err)
+	}
+	return m
+}
+
+func TestParseMessageWithPlainText(t *testing.T) {
+	m := parseTestMessage(t, `From: "Weekly News" <news@example.org>
+To: abc@newsletter.example.org
+Subject: =?utf-8?q?Caf=C3=A9_weekly?=
+Date: Mon, 02 Jan 2006 15:04:05 +0000
+Message-ID: <123@example.org>
+Archived-At: <https://example.org/archive/123>
+
+Hello
+`)
+
+	if m.MessageID != "<123@example.org>" {
+		t.Errorf(`Unexpected Message-ID: %q`, m.MessageID)
+	}
+	if m.Subject != "Café weekly" {
+		t.Errorf(`Unexpected subject: %q`, m.Subject)
+	}
+	if m.From == nil || m.From.Name != "Weekly News" || m.From.Address != "news@example.org" {
+		t.Errorf(`Unexpected sender: %v`, m.From)
+	}
+	if m.Date.Unix() != 1136214245 {
+		t.Errorf(`Unexpected date: %v`, m.Date)
+	}
+	if m.ArchivedAt != "https://example.org/archive/123" {
+		t.Errorf(`Unexpected web page: %q`, m.ArchivedAt)
+	}
+	if m.Text != "Hello\r\n" || m.HTML != "" {
+		t.Errorf(`Unexpected body: %q / %q`, m.Text, m.HTML)
+	}
+}
+
+func TestParseMessageWithMultipartAlternative(t *testing.T) {
+	m := parseTestMessage(t, `From: news@example.org
+Subject: Test
+Content-Type: multipart/alternative; boundary="b1"
+
+--b1
+Content-Type: text/plain; charset=utf-8
+
+Plain version
+--b1
+Content-Type: text/html; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+<p>Caf=E9</p>
+--b1--
+`)
+
+	if m.Text != "Plain version" {
+		t.Errorf(`Unexpected text body: %q`, m.Text)
+	}
+	if m.HTML != "<p>Café</p>" {
+		t.Errorf(`Unexpected HTML body: %q`, m.HTML)
+	}
+	if len(m.Attachments) != 0 {
+		t.Errorf(`Unexpected attachments: %d`, len(m.Attachments))
+	}
+}
+
+func TestParseMessageWithAttachments(t *testing.T) {
+	m := parseTestMessage(t, `From: news@example.org
+Subject: Test
+Content-Type: multipart/mixed; boundary="b1"
+
+--b1
+Content-Type: multipart/related; boundary="b2"
+
+--b2
+Content-Type: text/html
+
+<img src="cid:logo@example.org">
+--b2
+Content-Type: image/png
+Content-Transfer-Encoding: base64
+Content-ID: <logo@example.org>
+
+aW1hZ2U=
+--b2--
+--b1
+Content-Type: application/pdf; name="issue.pdf"
+Content-Disposition: attachment; filename="=?utf-8?q?num=C3=A9ro.pdf?="
+Content-Transfer-Encoding: base64
+
+cGRm
+--b1--
+`)
+
+	if m.HTML != `<img src="cid:logo@example.org">` {
+		t.Errorf(`Unexpected HTML body: %q`, m.HTML)
+	}
+
+	if len(m.Attachments) != 2 {
+		t.Fatalf(`Unexpected number of attachments: %d`, len(m.Attachments))
+	}
+
+	if m.Attachments[0].ContentID != "logo@example.org" || m.Attachments[0].MimeType != "image/png" || string(m.Attachments[0].Content) != "image" {
+		t.Errorf(`Unexpected image: %+v`, m.Attachments[0])
+	}
+
+	if m.Attachments[1].Filename != "numéro.pdf" || m.Attachments[1].MimeType != "application/pdf" || string(m.Attachments[1].Content) != "pdf" {
+		t.Errorf(`Unexpected file: %+v`, m.Attachments[1])
+	}
+}
+
+func TestParseMessageWithUnknownCharset(t *testing.T) {
+	m := parseTestMessage(t, `From: news@example.org
+Content-Type: text/plain; charset=x-unknown
+
+Hello
+`)
+
+	if m.Text != "Hello\r\n" {
+		t.Errorf(`Unexpected text body: %q`, m.Text)
+	}
+}
+
+func TestParseMessageWithoutBoundary(t *testing.T) {
+	_, err := parseMessage(strings.NewReader("From: news@example.org\r\nContent-Type: multipart/mixed\r\n\r\nHello\r\n"))
+	if err == nil {
+		t.Fatal(`A multipart message without boundary should be rejected`)
+	}
+}
+
+func TestParseEmptyMessage(t *testing.T) {
+	_, err := parseMessage(strings.NewReader("From: news@example.org\r\nSubject: Empty\r\n\r\n"))
+	if err == nil {
+		t.Fatal(`An empty message should be rejected`)
+	}
+}
+
+func TestArchivedAtURL(t *testing.T) {
+	scenarios := map[string]string{
+		"<https://example.org/1>":   "https://example.org/1",
+		"http://example.org/1":      "http://example.org/1",
+		"<javascript:alert(1)>":     "",
+		"<mailto:news@example.org>": "",
+		"":                          "",
+	}
+
+	for input, expected := range scenarios {
+		if result := archivedAtURL(input); result != expected {
+			t.Errorf(`Unexpected URL for %q: got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://miniflux.example.org")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	m := &message{
+		MessageID: "<123@example.org>",
+		Subject:   "Weekly",
+		HTML:      `<img src="cid:logo">`,
+		Attachments: []*attachment{
+			{MimeType: "image/png", ContentID: "logo", Content: []byte("image")},
+			{Filename: "issue.pdf", MimeType: "application/pdf", Content: []byte("pdf")},
+		},
+	}
+
+	entry, attachments := newEntry(m)
+
+	if entry.Title != "Weekly" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+	if entry.Hash != crypto.SHA256("<123@example.org>") {
+		t.Errorf(`The hash should be computed from the Message-ID`)
+	}
+	if entry.Date.IsZero() {
+		t.Errorf(`The date should default to the current time`)
+	}
+
+	if len(attachments) != 2 {
+		t.Fatalf(`Unexpected number of attachments: %d`, len(attachments))
+	}
+
+	expectedContent := `<img src="https://miniflux.example.org/newsletter/attachment/` + attachments[0].Token + `">`
+	if entry.Content != expectedContent {
+		t.Errorf(`Unexpected content: got %q instead of %q`, entry.Content, expectedContent)
+	}
+
+	if len(entry.Enclosures) != 1 {
+		t.Fatalf(`Only the attached file should be an enclosure, got %d enclosures`, len(entry.Enclosures))
+	}
+	if entry.Enclosures[0].URL != attachments[1].URL() || entry.Enclosures[0].MimeType != "application/pdf" || entry.Enclosures[0].Size != 3 {
+		t.Errorf(`Unexpected enclosure: %+v`, entry.Enclosures[0])
+	}
+}
+
+func TestNewEntryWithoutSubject(t *testing.T) {
+	m := parseTestMessage(t, `From: Jane <jane@example.org>
+Date: Mon, 02 Jan 2006 15:04:05 +0000
+
+First paragraph
+with <two> lines
+
+Second paragraph
+`)
+
+	entry, attachments := newEntry(m)
+
+	if entry.Title != "2006-01-02" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+	if entry.Author != "Jane" {
+		t.Errorf(`Unexpected author: %q`, entry.Author)
+	}
+	if entry.Content != "<p>First paragraph<br>with &lt;two&gt; lines</p><p>Second paragraph</p>" {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+	if len(attachments) != 0 {
+		t.Errorf(`Unexpected attachments: %d`, len(attachments))
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package newsletter receives newsletters by email and stores them as entries.
+//
+// The listener speaks SMTP or LMTP without TLS nor authentication: it is meant to be reached
+// through the mail server of the newsletter domain, or directly on a trusted network.
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Protocols of the listener.
+const (
+	ProtocolSMTP = "smtp"
+	ProtocolLMTP = "lmtp"
+)
+
+// ErrServerClosed is returned by Serve after a call to Shutdown.
+var ErrServerClosed = errors.New("newsletter: server closed")
+
+// backend accepts the recipients and stores the messages.
+type backend interface {
+	// checkRecipient returns an error when the address doesn't deliver to any feed.
+	checkRecipient(address string) error
+
+	// deliver stores the message sent to the address.
+	deliver(address string, data []byte) error
+}
+
+// Server receives the newsletters sent to the generated addresses.
+type Server struct {
+	backend        backend
+	protocol       string
+	domain         string
+	maxMessageSize int64
+	timeout        time.Duration
+
+	closed   atomic.Bool
+	mu       sync.Mutex
+	listener net.Listener
+	sessions map[*session]struct{}
+	wg       sync.WaitGroup
+}
+
+func newServer(backend backend, protocol, domain string, maxMessageSize int64) *Server {
+	return &Server{
+		backend:        backend,
+		protocol:       protocol,
+		domain:         domain,
+		maxMessageSize: maxMessageSize,
+		timeout:        5 * time.Minute,
+		sessions:       make(map[*session]struct{}),
+	}
+}
+
+// ListenAndServe listens on the TCP address and handles the incoming connections until Shutdown is called.
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve handles the connections accepted by the listener until Shutdown is called.
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	if s.closed.Load() {
+		s.mu.Unlock()
+		listener.Close()
+		return ErrServerClosed
+	}
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if s.closed.Load() {
+				return ErrServerClosed
+			}
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				time.Sleep(time.Second)
+				continue
+			}
+			return err
+		}
+
+		session := newSession(s, conn)
+
+		s.mu.Lock()
+		if s.closed.Load() {
+			s.mu.Unlock()
+			conn.Close()
+			return ErrServerClosed
+		}
+		s.sessions[session] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.sessions, session)
+				s.mu.Unlock()
+			}()
+
+			session.serve()
+		}()
+	}
+}
+
+// Shutdown stops accepting connections and closes the idle ones.
+// The messages being received are delivered before closing their connection, unless the context expires first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.closed.Store(true)
+
+	s.mu.Lock()
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for session := range s.sessions {
+		session.closeIfIdle()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		for session := range s.sessions {
+			session.conn.Close()
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (s *Server) logDeliveryError(address string, err error) {
+	slog.Warn("Unable to deliver newsletter",
+		slog.String("protocol", s.protocol),
+		slog.String("recipient", address),
+		slog.Any("error", err),
+	)
+}
@@ -0,0 +1,371 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxLineLength = 4096
+	maxRecipients = 100
+)
+
+var errLineTooLong = errors.New("newsletter: line too long")
+
+// session handles the commands of a client connection, as defined in RFC 5321 for SMTP and RFC 2033 for LMTP.
+type session struct {
+	server *Server
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+
+	mu   sync.Mutex
+	idle bool
+
+	greeted    bool
+	hasSender  bool
+	recipients []string
+}
+
+func newSession(server *Server, conn net.Conn) *session {
+	return &session{
+		server: server,
+		conn:   conn,
+		reader: bufio.NewReaderSize(conn, maxLineLength),
+		writer: bufio.NewWriter(conn),
+	}
+}
+
+func (s *session) serve() {
+	defer s.conn.Close()
+
+	if s.server.protocol == ProtocolLMTP {
+		s.reply(220, s.server.domain+" LMTP Miniflux")
+	} else {
+		s.reply(220, s.server.domain+" ESMTP Miniflux")
+	}
+
+	for {
+		if !s.waitForCommand() {
+			s.reply(421, "4.3.2 Service shutting down")
+			return
+		}
+
+		line, err := s.readLine()
+		s.setBusy()
+
+		if errors.Is(err, errLineTooLong) {
+			s.reply(500, "5.5.2 Line too long")
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		if !s.handleCommand(line) {
+			return
+		}
+	}
+}
+
+// waitForCommand marks the session as idle, the connection can then be closed by a shutdown.
+// It returns false when the server is already shutting down.
+func (s *session) waitForCommand() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idle = true
+	return !s.server.closed.Load()
+}
+
+func (s *session) setBusy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idle = false
+}
+
+func (s *session) closeIfIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idle {
+		s.conn.Close()
+	}
+}
+
+func (s *session) handleCommand(line string) bool {
+	verb, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch strings.ToUpper(verb) {
+	case "HELO", "EHLO":
+		if s.server.protocol == ProtocolLMTP {
+			s.reply(500, "5.5.1 Use LHLO with LMTP")
+			return true
+		}
+		s.hello(strings.ToUpper(verb), arg)
+	case "LHLO":
+		if s.server.protocol != ProtocolLMTP {
+			s.reply(500, "5.5.1 Unknown command")
+			return true
+		}
+		s.hello("LHLO", arg)
+	case "MAIL":
+		s.mail(arg)
+	case "RCPT":
+		s.recipient(arg)
+	case "DATA":
+		return s.data()
+	case "RSET":
+		s.reset()
+		s.reply(250, "2.0.0 OK")
+	case "NOOP":
+		s.reply(250, "2.0.0 OK")
+	case "VRFY":
+		s.reply(252, "2.5.0 Cannot verify the address")
+	case "QUIT":
+		s.reply(221, "2.0.0 Bye")
+		return false
+	default:
+		s.reply(500, "5.5.1 Unknown command")
+	}
+
+	return true
+}
+
+func (s *session) hello(verb, domain string) {
+	if domain == "" {
+		s.reply(501, "5.5.4 Syntax: "+verb+" hostname")
+		return
+	}
+
+	s.reset()
+	s.greeted = true
+
+	if verb == "HELO" {
+		s.reply(250, s.server.domain)
+		return
+	}
+
+	s.replyLines(250, []string{
+		s.server.domain,
+		"PIPELINING",
+		"8BITMIME",
+		"ENHANCEDSTATUSCODES",
+		"SIZE " + strconv.FormatInt(s.server.maxMessageSize, 10),
+	})
+}
+
+func (s *session) mail(arg string) {
+	if !s.greeted {
+		s.reply(503, "5.5.1 Say hello first")
+		return
+	}
+
+	if s.hasSender {
+		s.reply(503, "5.5.1 Sender already specified")
+		return
+	}
+
+	_, params, ok := parsePath(arg, "FROM:")
+	if !ok {
+		s.reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+		return
+	}
+
+	if size, err := strconv.ParseInt(params["SIZE"], 10, 64); err == nil && size > s.server.maxMessageSize {
+		s.reply(552, "5.3.4 Message size exceeds the limit")
+		return
+	}
+
+	s.hasSender = true
+	s.reply(250, "2.1.0 OK")
+}
+
+func (s *session) recipient(arg string) {
+	if !s.hasSender {
+		s.reply(503, "5.5.1 Specify the sender first")
+		return
+	}
+
+	address, _, ok := parsePath(arg, "TO:")
+	if !ok || address == "" {
+		s.reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+		return
+	}
+
+	if len(s.recipients) >= maxRecipients {
+		s.reply(452, "4.5.3 Too many recipients")
+		return
+	}
+
+	if err := s.server.backend.checkRecipient(address); err != nil {
+		if isPermanentError(err) {
+			s.reply(550, "5.1.1 Unknown recipient")
+		} else {
+			s.server.logDeliveryError(address, err)
+			s.reply(451, "4.3.0 Temporary failure, try again later")
+		}
+		return
+	}
+
+	s.recipients = append(s.recipients, address)
+	s.reply(250, "2.1.5 OK")
+}
+
+func (s *session) data() bool {
+	if len(s.recipients) == 0 {
+		s.reply(503, "5.5.1 Specify the recipients first")
+		return true
+	}
+
+	s.reply(354, "End data with <CR><LF>.<CR><LF>")
+
+	s.conn.SetReadDeadline(time.Now().Add(s.server.timeout))
+	dotReader := textproto.NewReader(s.reader).DotReader()
+	data, err := io.ReadAll(io.LimitReader(dotReader, s.server.maxMessageSize+1))
+	if err != nil {
+		return false
+	}
+
+	recipients := s.recipients
+	s.reset()
+
+	if int64(len(data)) > s.server.maxMessageSize {
+		if _, err := io.Copy(io.Discard, dotReader); err != nil {
+			return false
+		}
+		s.reply(552, "5.3.4 Message size exceeds the limit")
+		return true
+	}
+
+	// LMTP returns the status of each recipient, SMTP a single status for the message.
+	if s.server.protocol == ProtocolLMTP {
+		for _, recipient := range recipients {
+			s.reply(deliveryStatus(s.deliver(recipient, data)))
+		}
+		return true
+	}
+
+	var temporaryErr bool
+	var deliveredCount int
+	for _, recipient := range recipients {
+		switch err := s.deliver(recipient, data); {
+		case err == nil:
+			deliveredCount++
+		case !isPermanentError(err):
+			temporaryErr = true
+		}
+	}
+
+	switch {
+	case temporaryErr:
+		// The recipients that already received the message are not affected by a retry, its Message-ID is already known.
+		s.reply(451, "4.3.0 Temporary failure, try again later")
+	case deliveredCount == 0:
+		s.reply(554, "5.6.0 Message rejected")
+	default:
+		s.reply(250, "2.0.0 Message accepted")
+	}
+
+	return true
+}
+
+func (s *session) deliver(recipient string, data []byte) error {
+	err := s.server.backend.deliver(recipient, data)
+	if err != nil {
+		s.server.logDeliveryError(recipient, err)
+	}
+	return err
+}
+
+func (s *session) reset() {
+	s.hasSender = false
+	s.recipients = nil
+}
+
+func (s *session) readLine() (string, error) {
+	s.conn.SetReadDeadline(time.Now().Add(s.server.timeout))
+
+	line, err := s.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		for err == bufio.ErrBufferFull {
+			_, err = s.reader.ReadSlice('\n')
+		}
+		if err != nil {
+			return "", err
+		}
+		return "", errLineTooLong
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+func (s *session) reply(code int, text string) {
+	s.replyLines(code, []string{text})
+}
+
+func (s *session) replyLines(code int, lines []string) {
+	s.conn.SetWriteDeadline(time.Now().Add(s.server.timeout))
+	for i, line := range lines {
+		separator := "-"
+		if i == len(lines)-1 {
+			separator = " "
+		}
+		fmt.Fprintf(s.writer, "%d%s%s\r\n", code, separator, line)
+	}
+	s.writer.Flush()
+}
+
+func deliveryStatus(err error) (int, string) {
+	switch {
+	case err == nil:
+		return 250, "2.0.0 Message accepted"
+	case isPermanentError(err):
+		return 550, "5.6.0 Message rejected"
+	default:
+		return 451, "4.3.0 Temporary failure, try again later"
+	}
+}
+
+// parsePath returns the address and the parameters of the MAIL and RCPT commands, like "FROM:<user@example.org> SIZE=1024".
+func parsePath(arg, prefix string) (string, map[string]string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+
+	arg = strings.TrimSpace(arg[len(prefix):])
+	end := strings.IndexByte(arg, '>')
+	if !strings.HasPrefix(arg, "<") || end < 0 {
+		return "", nil, false
+	}
+
+	address := arg[1:end]
+
+	// The source routes are obsolete and ignored, like "<@relay.example.org:user@example.org>".
+	if strings.HasPrefix(address, "@") {
+		if _, mailbox, found := strings.Cut(address, ":"); found {
+			address = mailbox
+		}
+	}
+
+	params := make(map[string]string)
+	for _, param := range strings.Fields(arg[end+1:]) {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = value
+	}
+
+	return address, params, true
+}
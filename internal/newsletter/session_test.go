@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package newsletter // import "miniflux.app/v2/internal/newsletter"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeBackend struct {
+	mu         sync.Mutex
+	failures   map[string]error
+	deliveries map[string]string
+}
+
+func (b *fakeBackend) checkRecipient(address string) error {
+	if address == "unknown@example.org" {
+		return errUnknownRecipient
+	}
+	return nil
+}
+
+func (b *fakeBackend) deliver(address string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.failures[address]; err != nil {
+		return err
+	}
+	b.deliveries[address] = string(data)
+	return nil
+}
+
+func startTestServer(t *testing.T, protocol string, failures map[string]error) (*Server, *fakeBackend, *textproto.Conn) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf(`Unable to listen: %v`, err)
+	}
+
+	backend := &fakeBackend{failures: failures, deliveries: make(map[string]string)}
+	server := newServer(backend, protocol, "example.org", 64)
+	go server.Serve(listener)
+
+	conn, err := textproto.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf(`Unable to connect: %v`, err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Shutdown(context.Background())
+	})
+
+	expectReply(t, conn, 220)
+	return server, backend, conn
+}
+
+func expectReply(t *testing.T, conn *textproto.Conn, expectedCode int) {
+	t.Helper()
+	if code, message, err := conn.ReadResponse(expectedCode); err != nil {
+		t.Fatalf(`Unexpected reply: %d %s (%v)`, code, message, err)
+	}
+}
+
+func sendCommand(t *testing.T, conn *textproto.Conn, expectedCode int, format string, args ...any) {
+	t.Helper()
+	if err := conn.PrintfLine(format, args...); err != nil {
+		t.Fatalf(`Unable to send command: %v`, err)
+	}
+	expectReply(t, conn, expectedCode)
+}
+
+func sendData(t *testing.T, conn *textproto.Conn, data string) {
+	t.Helper()
+	sendCommand(t, conn, 354, "DATA")
+	writer := conn.DotWriter()
+	writer.Write([]byte(data))
+	if err := writer.Close(); err != nil {
+		t.Fatalf(`Unable to send data: %v`, err)
+	}
+}
+
+func TestSMTPSession(t *testing.T) {
+	_, backend, conn := startTestServer(t, ProtocolSMTP, nil)
+
+	sendCommand(t, conn, 503, "MAIL FROM:<news@example.org>")
+	sendCommand(t, conn, 500, "LHLO client.example.org")
+	sendCommand(t, conn, 250, "EHLO client.example.org")
+	sendCommand(t, conn, 503, "RCPT TO:<abc@example.org>")
+	sendCommand(t, conn, 250, "MAIL FROM:<news@example.org> BODY=8BITMIME")
+	sendCommand(t, conn, 550, "RCPT TO:<unknown@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<abc@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<@relay.example.org:def@example.org>")
+	sendData(t, conn, "Subject: Test\r\n\r\n.Hello\r\n")
+	expectReply(t, conn, 250)
+
+	for _, address := range []string{"abc@example.org", "def@example.org"} {
+		if data := backend.deliveries[address]; data != "Subject: Test\n\n.Hello\n" {
+			t.Errorf(`Unexpected message delivered to %s: %q`, address, data)
+		}
+	}
+
+	sendCommand(t, conn, 503, "DATA")
+	sendCommand(t, conn, 221, "QUIT")
+}
+
+func TestSMTPSessionWithTemporaryFailure(t *testing.T) {
+	_, backend, conn := startTestServer(t, ProtocolSMTP, map[string]error{
+		"abc@example.org": errors.New("database unavailable"),
+	})
+
+	sendCommand(t, conn, 250, "HELO client.example.org")
+	sendCommand(t, conn, 250, "MAIL FROM:<news@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<abc@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<def@example.org>")
+	sendData(t, conn, "Subject: Test\r\n\r\nHello\r\n")
+	expectReply(t, conn, 451)
+
+	if _, found := backend.deliveries["def@example.org"]; !found {
+		t.Error(`The message should be delivered to the other recipients`)
+	}
+}
+
+func TestSMTPSessionWithRejectedMessage(t *testing.T) {
+	_, _, conn := startTestServer(t, ProtocolSMTP, map[string]error{
+		"abc@example.org": errRejectedMessage,
+	})
+
+	sendCommand(t, conn, 250, "EHLO client.example.org")
+	sendCommand(t, conn, 250, "MAIL FROM:<news@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<abc@example.org>")
+	sendData(t, conn, "Subject: Test\r\n\r\nHello\r\n")
+	expectReply(t, conn, 554)
+}
+
+func TestSMTPSessionWithMessageTooLarge(t *testing.T) {
+	_, backend, conn := startTestServer(t, ProtocolSMTP, nil)
+
+	sendCommand(t, conn, 250, "EHLO client.example.org")
+	sendCommand(t, conn, 552, "MAIL FROM:<news@example.org> SIZE=65")
+	sendCommand(t, conn, 250, "MAIL FROM:<news@example.org> SIZE=64")
+	sendCommand(t, conn, 250, "RCPT TO:<abc@example.org>")
+	sendData(t, conn, "Subject: Test\r\n\r\nThis message is longer than the limit of the test server.\r\n")
+	expectReply(t, conn, 552)
+
+	if len(backend.deliveries) != 0 {
+		t.Error(`The message should not be delivered`)
+	}
+
+	sendCommand(t, conn, 250, "NOOP")
+}
+
+func TestLMTPSession(t *testing.T) {
+	_, backend, conn := startTestServer(t, ProtocolLMTP, map[string]error{
+		"def@example.org": errRejectedMessage,
+		"ghi@example.org": errors.New("database unavailable"),
+	})
+
+	sendCommand(t, conn, 500, "EHLO client.example.org")
+	sendCommand(t, conn, 250, "LHLO client.example.org")
+	sendCommand(t, conn, 250, "MAIL FROM:<news@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<abc@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<def@example.org>")
+	sendCommand(t, conn, 250, "RCPT TO:<ghi@example.org>")
+	sendData(t, conn, "Subject: Test\r\n\r\nHello\r\n")
+	expectReply(t, conn, 250)
+	expectReply(t, conn, 550)
+	expectReply(t, conn, 451)
+
+	if len(backend.deliveries) != 1 {
+		t.Errorf(`Unexpected number of deliveries: %d`, len(backend.deliveries))
+	}
+}
+
+func TestServerShutdownClosesIdleSessions(t *testing.T) {
+	server, _, conn := startTestServer(t, ProtocolSMTP, nil)
+
+	sendCommand(t, conn, 250, "EHLO client.example.org")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf(`Unable to shutdown the server: %v`, err)
+	}
+
+	if _, err := conn.ReadLine(); err == nil {
+		t.Error(`The idle session should be closed`)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf(`Unable to listen: %v`, err)
+	}
+
+	if err := server.Serve(listener); !errors.Is(err, ErrServerClosed) {
+		t.Errorf(`Serve should return ErrServerClosed after a shutdown, got %v`, err)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	address, params, ok := parsePath("from:<news@example.org> SIZE=1024 body=8BITMIME", "FROM:")
+	if !ok || address != "news@example.org" || params["SIZE"] != "1024" || params["BODY"] != "8BITMIME" {
+		t.Errorf(`Unexpected result: %q %v %v`, address, params, ok)
+	}
+
+	if address, _, ok := parsePath("FROM:<>", "FROM:"); !ok || address != "" {
+		t.Errorf(`The null sender should be accepted: %q %v`, address, ok)
+	}
+
+	if _, _, ok := parsePath("TO:news@example.org", "TO:"); ok {
+		t.Error(`An address without angle brackets should be rejected`)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicfeed // import "miniflux.app/v2/internal/publicfeed"
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Specs: https://datatracker.ietf.org/doc/html/rfc4287
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Links      []atomLink     `xml:"link"`
+	Author     *atomAuthor    `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func serializeAtom(publicFeed *model.PublicFeed, entries model.Entries) ([]byte, error) {
+	updated := lastPublicationDate(entries)
+	if updated.IsZero() {
+		updated = publicFeed.CreatedAt
+	}
+
+	feed := &atomFeed{
+		ID:      publicFeed.URL(model.PublicFeedFormatAtom),
+		Title:   publicFeed.Title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: homePageURL(), Rel: "alternate", Type: "text/html"},
+			{Href: publicFeed.URL(model.PublicFeedFormatAtom), Rel: "self", Type: "application/atom+xml"},
+		},
+		Generator: generator,
+	}
+
+	for _, entry := range entries {
+		atomEntry := atomEntry{
+			ID:        entryID(entry),
+			Title:     entry.Title,
+			Published: entry.Date.UTC().Format(time.RFC3339),
+			Updated:   entry.Date.UTC().Format(time.RFC3339),
+			Links:     []atomLink{{Href: entry.URL, Rel: "alternate", Type: "text/html"}},
+			Content:   atomContent{Type: "html", Value: entry.Content},
+		}
+
+		if entry.Author != "" {
+			atomEntry.Author = &atomAuthor{Name: entry.Author}
+		}
+
+		if entry.CommentsURL != "" {
+			atomEntry.Links = append(atomEntry.Links, atomLink{Href: entry.CommentsURL, Rel: "replies", Type: "text/html"})
+		}
+
+		for _, enclosure := range entry.Enclosures {
+			atomEntry.Links = append(atomEntry.Links, atomLink{
+				Href:   enclosure.URL,
+				Rel:    "enclosure",
+				Type:   enclosure.MimeType,
+				Length: strconv.FormatInt(enclosure.Size, 10),
+			})
+		}
+
+		for _, tag := range entry.Tags {
+			atomEntry.Categories = append(atomEntry.Categories, atomCategory{Term: tag})
+		}
+
+		feed.Entries = append(feed.Entries, atomEntry)
+	}
+
+	return encodeXML(feed)
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package publicfeed publishes the entries of a user as RSS 2.0, Atom 1.0 or JSON Feed 1.1 documents.
+// The feeds are identified by a random token and do not require authentication.
+package publicfeed // import "miniflux.app/v2/internal/publicfeed"
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	generator = "Miniflux"
+
+	// entriesLimit is the number of most recent entries published in a feed.
+	entriesLimit = 100
+
+	// cacheDuration is kept short because a feed can be removed at any time.
+	cacheDuration = 15 * time.Minute
+)
+
+// Serve declares the public feed routes.
+func Serve(router *mux.Router, store *storage.Storage) {
+	handler := &handler{store}
+	router.HandleFunc("/public/{token}/{format:rss|atom|json}", handler.showPublicFeed).Methods(http.MethodGet).Name("publicFeed")
+}
+
+type handler struct {
+	store *storage.Storage
+}
+
+func (h *handler) showPublicFeed(w http.ResponseWriter, r *http.Request) {
+	publicFeed, err := h.store.PublicFeedByToken(request.RouteStringParam(r, "token"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if publicFeed == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	entries, err := publicFeedEntries(h.store, publicFeed)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if err := h.store.SetPublicFeedUsedTimestamp(publicFeed.ID); err != nil {
+		slog.Error("Unable to update the last used date of the public feed",
+			slog.Int64("user_id", publicFeed.UserID),
+			slog.Int64("public_feed_id", publicFeed.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	format := request.RouteStringParam(r, "format")
+	response.New(w, r).WithCaching(entriesETag(publicFeed, format, entries), cacheDuration, func(b *response.Builder) {
+		body, contentType, err := serialize(publicFeed, format, entries)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		b.WithHeader("Content-Type", contentType)
+		b.WithBody(body)
+		b.Write()
+	})
+}
+
+// publicFeedEntries returns the most recent entries matching the source of the feed.
+func publicFeedEntries(store *storage.Storage, publicFeed *model.PublicFeed) (model.Entries, error) {
+	builder := store.NewEntryQueryBuilder(publicFeed.UserID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithEnclosures()
+
+	switch publicFeed.Source {
+	case model.PublicFeedSourceCategory:
+		builder.WithCategoryID(publicFeed.CategoryID)
+	case model.PublicFeedSourceTag:
+		builder.WithTags([]string{publicFeed.Tag})
+	case model.PublicFeedSourceStarred:
+		builder.WithStarred(true)
+	case model.PublicFeedSourceSearch:
+		// The search results are sorted by relevance.
+		builder.WithSearchQuery(publicFeed.SearchQuery)
+	default:
+		return nil, fmt.Errorf("publicfeed: unknown source %q", publicFeed.Source)
+	}
+
+	builder.WithSorting("published_at", "DESC")
+	builder.WithSorting("id", "DESC")
+	builder.WithLimit(entriesLimit)
+
+	return builder.GetEntries()
+}
+
+func serialize(publicFeed *model.PublicFeed, format string, entries model.Entries) ([]byte, string, error) {
+	switch format {
+	case model.PublicFeedFormatRSS:
+		body, err := serializeRSS(publicFeed, entries)
+		return body, "application/rss+xml; charset=utf-8", err
+	case model.PublicFeedFormatAtom:
+		body, err := serializeAtom(publicFeed, entries)
+		return body, "application/atom+xml; charset=utf-8", err
+	case model.PublicFeedFormatJSON:
+		body, err := serializeJSON(publicFeed, entries)
+		return body, "application/feed+json; charset=utf-8", err
+	default:
+		return nil, "", fmt.Errorf("publicfeed: unknown format %q", format)
+	}
+}
+
+// entriesETag changes when an entry is added to the feed, removed from it or modified.
+func entriesETag(publicFeed *model.PublicFeed, format string, entries model.Entries) string {
+	var builder strings.Builder
+	builder.WriteString(publicFeed.Token + ":" + format + ":" + publicFeed.Title)
+	for _, entry := range entries {
+		builder.WriteString(":" + strconv.FormatInt(entry.ID, 10) + "@" + strconv.FormatInt(entry.ChangedAt.Unix(), 10))
+	}
+	return crypto.SHA256(builder.String())
+}
+
+func lastPublicationDate(entries model.Entries) time.Time {
+	var lastPublicationDate time.Time
+	for _, entry := range entries {
+		if entry.Date.After(lastPublicationDate) {
+			lastPublicationDate = entry.Date
+		}
+	}
+	return lastPublicationDate
+}
+
+// entryID returns a permanent identifier of the entry, its URL is not unique across feeds.
+func entryID(entry *model.Entry) string {
+	return "urn:miniflux:entry:" + strconv.FormatInt(entry.ID, 10)
+}
+
+func homePageURL() string {
+	return config.Opts.BaseURL() + "/"
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicfeed // import "miniflux.app/v2/internal/publicfeed"
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/atom"
+	"miniflux.app/v2/internal/reader/json"
+	"miniflux.app/v2/internal/reader/rss"
+)
+
+func setupConfig(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://miniflux.example.org")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func testPublicFeed() *model.PublicFeed {
+	return &model.PublicFeed{
+		ID:     1,
+		Token:  "0123456789abcdef",
+		Title:  "My <Starred> Entries",
+		Source: model.PublicFeedSourceStarred,
+	}
+}
+
+func testEntries() model.Entries {
+	return model.Entries{
+		{
+			ID:          42,
+			Title:       "Entry & Title",
+			URL:         "https://example.org/entry",
+			CommentsURL: "https://example.org/entry#comments",
+			Author:      "Jane Doe",
+			Content:     `<p>Some <strong>content</strong></p>`,
+			Date:        time.Date(2024, 3, 10, 12, 30, 0, 0, time.UTC),
+			ChangedAt:   time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC),
+			Tags:        []string{"go", "web"},
+			Enclosures: model.EnclosureList{
+				{URL: "https://example.org/episode.mp3", MimeType: "audio/mpeg", Size: 1234},
+			},
+		},
+		{
+			ID:        43,
+			Title:     "Second Entry",
+			URL:       "https://example.org/second",
+			Content:   `<p>Other content</p>`,
+			Date:      time.Date(2024, 3, 9, 7, 0, 0, 0, time.UTC),
+			ChangedAt: time.Date(2024, 3, 9, 7, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func checkParsedFeed(t *testing.T, feed *model.Feed) {
+	t.Helper()
+
+	if feed.Title != "My <Starred> Entries" {
+		t.Errorf(`Unexpected feed title, got %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://miniflux.example.org/" {
+		t.Errorf(`Unexpected site URL, got %q`, feed.SiteURL)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Unexpected number of entries, got %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Entry & Title" {
+		t.Errorf(`Unexpected entry title, got %q`, entry.Title)
+	}
+
+	if entry.URL != "https://example.org/entry" {
+		t.Errorf(`Unexpected entry URL, got %q`, entry.URL)
+	}
+
+	if entry.Author != "Jane Doe" {
+		t.Errorf(`Unexpected entry author, got %q`, entry.Author)
+	}
+
+	if entry.Content != `<p>Some <strong>content</strong></p>` {
+		t.Errorf(`Unexpected entry content, got %q`, entry.Content)
+	}
+
+	if !entry.Date.Equal(time.Date(2024, 3, 10, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected entry date, got %v`, entry.Date)
+	}
+
+	if len(entry.Tags) != 2 || entry.Tags[0] != "go" || entry.Tags[1] != "web" {
+		t.Errorf(`Unexpected entry tags, got %v`, entry.Tags)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].URL != "https://example.org/episode.mp3" || entry.Enclosures[0].MimeType != "audio/mpeg" {
+		t.Errorf(`Unexpected entry enclosures, got %v`, entry.Enclosures)
+	}
+}
+
+func TestSerializeRSS(t *testing.T) {
+	setupConfig(t)
+
+	data, err := serializeRSS(testPublicFeed(), testEntries())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := rss.Parse("https://miniflux.example.org/public/0123456789abcdef/rss", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf(`Unable to parse the generated feed: %v`, err)
+	}
+
+	checkParsedFeed(t, feed)
+
+	if feed.Entries[0].CommentsURL != "https://example.org/entry#comments" {
+		t.Errorf(`Unexpected comments URL, got %q`, feed.Entries[0].CommentsURL)
+	}
+}
+
+func TestSerializeAtom(t *testing.T) {
+	setupConfig(t)
+
+	data, err := serializeAtom(testPublicFeed(), testEntries())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := atom.Parse("https://miniflux.example.org/public/0123456789abcdef/atom", bytes.NewReader(data), "10")
+	if err != nil {
+		t.Fatalf(`Unable to parse the generated feed: %v`, err)
+	}
+
+	checkParsedFeed(t, feed)
+}
+
+func TestSerializeJSON(t *testing.T) {
+	setupConfig(t)
+
+	data, err := serializeJSON(testPublicFeed(), testEntries())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := json.Parse("https://miniflux.example.org/public/0123456789abcdef/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf(`Unable to parse the generated feed: %v`, err)
+	}
+
+	checkParsedFeed(t, feed)
+
+	if feed.FeedURL != "https://miniflux.example.org/public/0123456789abcdef/json" {
+		t.Errorf(`Unexpected feed URL, got %q`, feed.FeedURL)
+	}
+}
+
+func TestSerializeEmptyFeed(t *testing.T) {
+	setupConfig(t)
+
+	for _, format := range []string{model.PublicFeedFormatRSS, model.PublicFeedFormatAtom, model.PublicFeedFormatJSON} {
+		data, _, err := serialize(testPublicFeed(), format, model.Entries{})
+		if err != nil {
+			t.Errorf(`Unable to serialize an empty %s feed: %v`, format, err)
+		}
+
+		if len(data) == 0 {
+			t.Errorf(`The %s feed should not be empty`, format)
+		}
+	}
+}
+
+func TestSerializeUnknownFormat(t *testing.T) {
+	if _, _, err := serialize(testPublicFeed(), "html", testEntries()); err == nil {
+		t.Error(`An unknown format should return an error`)
+	}
+}
+
+func TestEntriesETag(t *testing.T) {
+	publicFeed := testPublicFeed()
+	entries := testEntries()
+
+	etag := entriesETag(publicFeed, model.PublicFeedFormatRSS, entries)
+	if etag != entriesETag(publicFeed, model.PublicFeedFormatRSS, testEntries()) {
+		t.Error(`The ETag should be stable`)
+	}
+
+	if etag == entriesETag(publicFeed, model.PublicFeedFormatAtom, entries) {
+		t.Error(`The ETag should depend on the format`)
+	}
+
+	if etag == entriesETag(publicFeed, model.PublicFeedFormatRSS, entries[:1]) {
+		t.Error(`The ETag should change when an entry is removed`)
+	}
+
+	entries[1].ChangedAt = entries[1].ChangedAt.Add(time.Minute)
+	if etag == entriesETag(publicFeed, model.PublicFeedFormatRSS, entries) {
+		t.Error(`The ETag should change when an entry is modified`)
+	}
+}
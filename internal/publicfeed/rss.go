@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publicfeed // import "miniflux.app/v2/internal/publicfeed"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Specs: https://www.rssboard.org/rss-specification
+type rssDocument struct {
+	XMLName        xml.Name   `xml:"rss"`
+	Version        string     `xml:"version,attr"`
+	AtomNamespace  string     `xml:"xmlns:atom,attr"`
+	DublinCoreName string     `xml:"xmlns:dc,attr"`
+	Channel        rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	SelfLink      rssAtomLink `xml:"atom:link"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Generator     string      `xml:"generator"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Comments    string        `xml:"comments,omitempty"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+	Description string        `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+func serializeRSS(publicFeed *model.PublicFeed, entries model.Entries) ([]byte, error) {
+	document := &rssDocument{
+		Version:        "2.0",
+		AtomNamespace:  "http://www.w3.org/2005/Atom",
+		DublinCoreName: "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       publicFeed.Title,
+			Link:        homePageURL(),
+			Description: publicFeed.Title,
+			SelfLink:    rssAtomLink{Href: publicFeed.URL(model.PublicFeedFormatRSS), Rel: "self", Type: "application/rss+xml"},
+			Generator:   generator,
+		},
+	}
+
+	if lastUpdate := lastPublicationDate(entries); !lastUpdate.IsZero() {
+		document.Channel.LastBuildDate = lastUpdate.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, entry := range entries {
+		item := rssItem{
+			Title:       entry.Title,
+			Link:        entry.URL,
+			GUID:        rssGUID{IsPermaLink: "false", Value: entryID(entry)},
+			PubDate:     entry.Date.UTC().Format(time.RFC1123Z),
+			Creator:     entry.Author,
+			Comments:    entry.CommentsURL,
+			Categories:  entry.Tags,
+			Description: entry.Content,
+		}
+
+		// RSS allows a single enclosure per item, the media files are preferred.
+		enclosure := entry.Enclosures.FindMediaPlayerEnclosure()
+		if enclosure == nil && len(entry.Enclosures) > 0 {
+			enclosure = entry.Enclosures[0]
+		}
+		if enclosure != nil {
+			item.Enclosure = &rssEnclosure{
+				URL:    enclosure.URL,
+				Type:   enclosure.MimeType,
+				Length: strconv.FormatInt(enclosure.Size, 10),
+			}
+		}
+
+		document.Channel.Items = append(document.Channel.Items, item)
+	}
+
+	return encodeXML(document)
+}
+
+func encodeXML(document any) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+
+	encoder := xml.NewEncoder(&buffer)
+	encoder.Indent("", "    ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
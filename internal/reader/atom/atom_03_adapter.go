@@ -41,6 +41,20 @@ func (a *atom03Adapter) buildFeed(baseURL string) *model.Feed {
 		feed.SiteURL = baseURL
 	}
 
+	// Populate the WebSub hub URL.
+	if hubURL := a.atomFeed.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, hubURL); err == nil {
+			feed.HubURL = absoluteHubURL
+		}
+	}
+
+	// Populate the URL of the page with older entries (RFC 5005).
+	if archivePageURL := a.atomFeed.Links.archivePageURL(); archivePageURL != "" {
+		if absoluteArchivePageURL, err := urllib.AbsoluteURL(baseURL, archivePageURL); err == nil {
+			feed.ArchivePageURL = absoluteArchivePageURL
+		}
+	}
+
 	// Populate the feed title.
 	feed.Title = a.atomFeed.Title.content()
 	if feed.Title == "" {
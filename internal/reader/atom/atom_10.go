@@ -82,9 +82,15 @@ type atom10Feed struct {
 	Categories atomCategories `xml:"http://www.w3.org/2005/Atom category"`
 
 	Entries []atom10Entry `xml:"http://www.w3.org/2005/Atom entry"`
+
+	// The "xml:lang" attribute indicates the natural language of the feed.
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
 }
 
 type atom10Entry struct {
+	// The "xml:lang" attribute indicates the natural language of the entry.
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+
 	// The "atom:id" element conveys a permanent, universally unique
 	// identifier for an entry or feed.
 	//
@@ -49,6 +49,20 @@ func (a *atom10Adapter) BuildFeed(baseURL string) *model.Feed {
 		feed.SiteURL = baseURL
 	}
 
+	// Populate the WebSub hub URL.
+	if hubURL := a.atomFeed.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, hubURL); err == nil {
+			feed.HubURL = absoluteHubURL
+		}
+	}
+
+	// Populate the URL of the page with older entries (RFC 5005).
+	if archivePageURL := a.atomFeed.Links.archivePageURL(); archivePageURL != "" {
+		if absoluteArchivePageURL, err := urllib.AbsoluteURL(baseURL, archivePageURL); err == nil {
+			feed.ArchivePageURL = absoluteArchivePageURL
+		}
+	}
+
 	// Populate the feed title.
 	feed.Title = a.atomFeed.Title.body()
 	if feed.Title == "" {
@@ -104,6 +118,14 @@ func (a *atom10Adapter) populateEntries(siteURL string) model.Entries {
 			}
 		}
 
+		// Populate the entry language.
+		for _, language := range []string{atomEntry.Lang, a.atomFeed.Lang} {
+			if language = strings.TrimSpace(language); language != "" {
+				entry.Language = strings.ToLower(language)
+				break
+			}
+		}
+
 		// Populate the entry author.
 		authors := atomEntry.Authors.personNames()
 		if len(authors) == 0 {
@@ -82,6 +82,14 @@ func (a atomLinks) firstLinkWithRelation(relation string) string {
 	return ""
 }
 
+// archivePageURL returns the link to the older entries of an archived feed, or to the next page of a paged feed (RFC 5005).
+func (a atomLinks) archivePageURL() string {
+	if archivePageURL := a.firstLinkWithRelation("prev-archive"); archivePageURL != "" {
+		return archivePageURL
+	}
+	return a.firstLinkWithRelation("next")
+}
+
 func (a atomLinks) firstLinkWithRelationAndType(relation string, contentTypes ...string) string {
 	for _, link := range a {
 		if strings.EqualFold(link.Rel, relation) {
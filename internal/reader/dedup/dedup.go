@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dedup computes the fingerprints used to detect the same article published in several feeds.
+package dedup // import "miniflux.app/v2/internal/reader/dedup"
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+const (
+	// Only the beginning of the content is used, feeds often append their own footer to the same article.
+	maxContentWords = 64
+
+	// Short texts are too generic to be compared across feeds.
+	minContentWords = 20
+)
+
+// URLFingerprint returns a fingerprint of the entry URL that ignores the scheme,
+// the "www." prefix, the fragment, the trailing slash and the order of the query parameters.
+// The tracking parameters are expected to be already removed.
+// An empty string is returned for the home page of a website, it is not specific to an article.
+func URLFingerprint(entryURL string) string {
+	parsedURL, err := url.Parse(strings.TrimSpace(entryURL))
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+
+	if strings.Trim(parsedURL.EscapedPath(), "/") == "" && parsedURL.RawQuery == "" {
+		return ""
+	}
+
+	hostname := strings.TrimPrefix(strings.ToLower(parsedURL.Hostname()), "www.")
+	if port := parsedURL.Port(); port != "" && port != "80" && port != "443" {
+		hostname += ":" + port
+	}
+
+	path := strings.TrimRight(parsedURL.EscapedPath(), "/")
+	normalizedURL := hostname + path
+
+	if parsedURL.RawQuery != "" {
+		normalizedURL += "?" + parsedURL.Query().Encode()
+	}
+
+	return crypto.SHA256(normalizedURL)
+}
+
+// ContentFingerprint returns a fingerprint of the entry title and the beginning of its content.
+// The comparison is case insensitive and ignores markup, punctuation and whitespace.
+// An empty string is returned when the content is too short to be meaningful.
+func ContentFingerprint(title, content string) string {
+	contentWords := normalizedWords(sanitizer.StripTags(content))
+	if len(contentWords) < minContentWords {
+		return ""
+	}
+
+	if len(contentWords) > maxContentWords {
+		contentWords = contentWords[:maxContentWords]
+	}
+
+	titleWords := normalizedWords(sanitizer.StripTags(title))
+	return crypto.SHA256(strings.Join(titleWords, " ") + "\n" + strings.Join(contentWords, " "))
+}
+
+func normalizedWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup // import "miniflux.app/v2/internal/reader/dedup"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestURLFingerprintOfHomePage(t *testing.T) {
+	for _, homePageURL := range []string{"https://example.org", "https://example.org/", "https://example.org/#top"} {
+		if fingerprint := URLFingerprint(homePageURL); fingerprint != "" {
+			t.Errorf(`The home page %q should not have a fingerprint`, homePageURL)
+		}
+	}
+
+	if URLFingerprint("https://example.org/?p=123") == "" {
+		t.Error(`An article identified by its query string should have a fingerprint`)
+	}
+}
+
+func TestURLFingerprint(t *testing.T) {
+	reference := URLFingerprint("https://example.org/articles/123?a=1&b=2")
+	if reference == "" {
+		t.Fatal(`The fingerprint should not be empty`)
+	}
+
+	equivalentURLs := []string{
+		"http://example.org/articles/123?a=1&b=2",
+		"https://www.example.org/articles/123?a=1&b=2",
+		"https://EXAMPLE.org/articles/123/?a=1&b=2",
+		"https://example.org:443/articles/123?b=2&a=1",
+		"https://example.org/articles/123?a=1&b=2#comments",
+	}
+
+	for _, equivalentURL := range equivalentURLs {
+		if fingerprint := URLFingerprint(equivalentURL); fingerprint != reference {
+			t.Errorf(`The URL %q should have the same fingerprint as the reference`, equivalentURL)
+		}
+	}
+
+	differentURLs := []string{
+		"https://example.org/articles/124?a=1&b=2",
+		"https://example.org/articles/123?a=1",
+		"https://example.com/articles/123?a=1&b=2",
+		"https://example.org:8080/articles/123?a=1&b=2",
+	}
+
+	for _, differentURL := range differentURLs {
+		if fingerprint := URLFingerprint(differentURL); fingerprint == reference {
+			t.Errorf(`The URL %q should not have the same fingerprint as the reference`, differentURL)
+		}
+	}
+}
+
+func TestURLFingerprintWithInvalidURL(t *testing.T) {
+	for _, input := range []string{"", "/relative/path", "://invalid"} {
+		if fingerprint := URLFingerprint(input); fingerprint != "" {
+			t.Errorf(`The fingerprint of %q should be empty, got %q`, input, fingerprint)
+		}
+	}
+}
+
+func TestContentFingerprint(t *testing.T) {
+	content := "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 10) + "</p>"
+	reference := ContentFingerprint("Some Title", content)
+	if reference == "" {
+		t.Fatal(`The fingerprint should not be empty`)
+	}
+
+	if fingerprint := ContentFingerprint("some title!", "<div>"+strings.ToUpper(content)+"</div>"); fingerprint != reference {
+		t.Error(`Case, punctuation and markup should be ignored`)
+	}
+
+	longContent := content + strings.Repeat("<p>Some footer added by the aggregator.</p>", 20)
+	if fingerprint := ContentFingerprint("Some Title", longContent); fingerprint != reference {
+		t.Error(`Only the beginning of the content should be compared`)
+	}
+
+	if fingerprint := ContentFingerprint("Another Title", content); fingerprint == reference {
+		t.Error(`A different title should produce a different fingerprint`)
+	}
+}
+
+func TestContentFingerprintWithShortContent(t *testing.T) {
+	if fingerprint := ContentFingerprint("Some Title", "<p>Too short to be compared.</p>"); fingerprint != "" {
+		t.Errorf(`The fingerprint should be empty, got %q`, fingerprint)
+	}
+}
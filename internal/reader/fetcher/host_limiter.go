@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// maxHostPause limits the pause requested by a server or caused by its repeated failures.
+const maxHostPause = 24 * time.Hour
+
+// Reasons of a host pause.
+const (
+	HostPauseReasonRetryAfter = "retry_after"
+	HostPauseReasonFailures   = "failures"
+)
+
+// HostLimiterInstance is shared by the feed fetches of all the workers of the process.
+var HostLimiterInstance = NewHostLimiter(0, 0, 0)
+
+// HostPausedError is returned instead of sending a request to a paused host.
+type HostPausedError struct {
+	Host  string
+	Until time.Time
+}
+
+func (e *HostPausedError) Error() string {
+	return fmt.Sprintf("fetcher: requests to %s are paused until %s", e.Host, e.Until.Format(time.RFC3339))
+}
+
+// HostLimiter limits the number of concurrent requests sent to each host, and works as a circuit breaker:
+// the requests to a host are paused when the server asks to slow down or fails repeatedly.
+type HostLimiter struct {
+	maxConcurrentRequests int
+	failureLimit          int
+	pauseDuration         time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+type hostState struct {
+	slots               chan struct{}
+	waitingRequests     int
+	activeRequests      int
+	consecutiveFailures int
+	consecutivePauses   int
+	pausedUntil         time.Time
+	pauseReason         string
+}
+
+// NewHostLimiter creates a limiter allowing maxConcurrentRequests requests per host at the same time.
+// A host is paused for pauseDuration after failureLimit consecutive failures, and this pause doubles each time
+// the host fails again. A limit of 0 disables the corresponding feature.
+func NewHostLimiter(maxConcurrentRequests, failureLimit int, pauseDuration time.Duration) *HostLimiter {
+	return &HostLimiter{
+		maxConcurrentRequests: maxConcurrentRequests,
+		failureLimit:          failureLimit,
+		pauseDuration:         pauseDuration,
+		hosts:                 make(map[string]*hostState),
+	}
+}
+
+// acquire waits until a request can be sent to the host, for at most the given timeout.
+// The returned function must be called once the response is received.
+func (l *HostLimiter) acquire(host string, timeout time.Duration) (func(), error) {
+	l.mu.Lock()
+	state := l.hostState(host)
+	if pausedUntil := state.pausedUntil; time.Now().Before(pausedUntil) {
+		l.mu.Unlock()
+		return nil, &HostPausedError{Host: host, Until: pausedUntil}
+	}
+	slots := state.slots
+
+	// The waiting requests keep the state of the host, and its slots, from being evicted.
+	state.waitingRequests++
+	l.mu.Unlock()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-time.After(timeout):
+			l.mu.Lock()
+			state.waitingRequests--
+			l.evictIfIdle(host, state)
+			l.mu.Unlock()
+			return nil, fmt.Errorf("fetcher: too many concurrent requests to %s", host)
+		}
+	}
+
+	l.mu.Lock()
+	state.waitingRequests--
+	state.activeRequests++
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if slots != nil {
+				<-slots
+			}
+
+			l.mu.Lock()
+			state.activeRequests--
+			l.evictIfIdle(host, state)
+			l.mu.Unlock()
+		})
+	}, nil
+}
+
+// record updates the state of the host with the outcome of a request.
+func (l *HostLimiter) record(host string, response *http.Response, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state := l.hostState(host)
+
+	var retryAfter time.Duration
+	failed := err != nil && os.IsTimeout(err)
+	if response != nil {
+		switch response.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			retryAfter = parseRetryAfter(response.Header.Get("Retry-After"))
+			failed = retryAfter <= 0
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			failed = true
+		}
+	}
+
+	switch {
+	case retryAfter > 0:
+		state.consecutiveFailures = 0
+		l.pause(host, state, min(retryAfter, maxHostPause), HostPauseReasonRetryAfter)
+	case failed:
+		state.consecutiveFailures++
+		if l.failureLimit > 0 && state.consecutiveFailures >= l.failureLimit {
+			state.consecutiveFailures = 0
+			state.consecutivePauses++
+			pauseDuration := l.pauseDuration << min(state.consecutivePauses-1, 16)
+			l.pause(host, state, min(pauseDuration, maxHostPause), HostPauseReasonFailures)
+		}
+	case err == nil:
+		state.consecutiveFailures = 0
+		state.consecutivePauses = 0
+		state.pauseReason = ""
+	}
+}
+
+// pause stops sending requests to the host for the given duration.
+func (l *HostLimiter) pause(host string, state *hostState, duration time.Duration, reason string) {
+	state.pausedUntil = time.Now().Add(duration)
+	state.pauseReason = reason
+
+	slog.Warn("Requests to host paused",
+		slog.String("host", host),
+		slog.String("reason", reason),
+		slog.Duration("pause_duration", duration),
+		slog.Time("paused_until", state.pausedUntil),
+	)
+}
+
+func (l *HostLimiter) hostState(host string) *hostState {
+	state, found := l.hosts[host]
+	if !found {
+		// The hosts paused or failing in the past are forgotten once they are idle again.
+		for idleHost, idleState := range l.hosts {
+			l.evictIfIdle(idleHost, idleState)
+		}
+
+		state = &hostState{}
+		if l.maxConcurrentRequests > 0 {
+			state.slots = make(chan struct{}, l.maxConcurrentRequests)
+		}
+		l.hosts[host] = state
+	}
+	return state
+}
+
+// evictIfIdle forgets a host without requests, failures nor pause, its state is created again on the next request.
+// The mutex must be held by the caller.
+func (l *HostLimiter) evictIfIdle(host string, state *hostState) {
+	if state.waitingRequests == 0 && state.activeRequests == 0 &&
+		state.consecutiveFailures == 0 && state.consecutivePauses == 0 &&
+		!time.Now().Before(state.pausedUntil) {
+		delete(l.hosts, host)
+	}
+}
+
+// Status returns the hosts receiving requests, failing or paused, sorted by name.
+func (l *HostLimiter) Status() []*model.HostStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	hostsStatus := make([]*model.HostStatus, 0)
+	for _, host := range slices.Sorted(maps.Keys(l.hosts)) {
+		state := l.hosts[host]
+		isPaused := now.Before(state.pausedUntil)
+		if state.activeRequests == 0 && state.consecutiveFailures == 0 && !isPaused {
+			continue
+		}
+
+		hostStatus := &model.HostStatus{
+			Host:                host,
+			ActiveRequests:      state.activeRequests,
+			ConsecutiveFailures: state.consecutiveFailures,
+		}
+		if isPaused {
+			pausedUntil := state.pausedUntil
+			hostStatus.PausedUntil = &pausedUntil
+			hostStatus.PauseReason = state.pauseReason
+		}
+		hostsStatus = append(hostsStatus, hostStatus)
+	}
+	return hostsStatus
+}
+
+// hostKey returns the name identifying the host of a request URL.
+func hostKey(request *http.Request) string {
+	return strings.ToLower(request.URL.Host)
+}
+
+// releaseOnClose frees the slot of the host once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
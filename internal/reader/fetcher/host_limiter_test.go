@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func newTestResponse(statusCode int, retryAfter string) *http.Response {
+	response := &http.Response{StatusCode: statusCode, Header: make(http.Header)}
+	if retryAfter != "" {
+		response.Header.Set("Retry-After", retryAfter)
+	}
+	return response
+}
+
+func TestHostLimiterConcurrentRequests(t *testing.T) {
+	limiter := NewHostLimiter(1, 0, 0)
+
+	release, err := limiter.acquire("example.org", time.Second)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if _, err := limiter.acquire("example.org", 10*time.Millisecond); err == nil {
+		t.Fatal(`A second request to the same host should not be allowed`)
+	}
+
+	otherRelease, err := limiter.acquire("example.com", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf(`The requests to another host should be allowed: %v`, err)
+	}
+	otherRelease()
+
+	if hosts := limiter.Status(); len(hosts) != 1 || hosts[0].Host != "example.org" || hosts[0].ActiveRequests != 1 {
+		t.Fatalf(`Unexpected hosts status: %+v`, hosts)
+	}
+
+	// Releasing twice must not free two slots.
+	release()
+	release()
+
+	release, err = limiter.acquire("example.org", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf(`The request should be allowed once the previous one is completed: %v`, err)
+	}
+	release()
+
+	if hosts := limiter.Status(); len(hosts) != 0 {
+		t.Fatalf(`Idle hosts should not be reported, got %+v`, hosts)
+	}
+}
+
+func TestHostLimiterPausesHostWithRetryAfter(t *testing.T) {
+	limiter := NewHostLimiter(0, 0, 0)
+	limiter.record("example.org", newTestResponse(http.StatusTooManyRequests, "120"), nil)
+
+	_, err := limiter.acquire("example.org", time.Second)
+	var hostPausedErr *HostPausedError
+	if !errors.As(err, &hostPausedErr) {
+		t.Fatalf(`Expected a paused host error, got %v`, err)
+	}
+
+	if delay := time.Until(hostPausedErr.Until); delay < 110*time.Second || delay > 120*time.Second {
+		t.Errorf(`Unexpected pause duration: %v`, delay)
+	}
+
+	hosts := limiter.Status()
+	if len(hosts) != 1 || hosts[0].PausedUntil == nil || hosts[0].PauseReason != HostPauseReasonRetryAfter {
+		t.Fatalf(`Unexpected hosts status: %+v`, hosts)
+	}
+
+	if _, err := limiter.acquire("example.com", time.Second); err != nil {
+		t.Errorf(`The other hosts should not be paused: %v`, err)
+	}
+}
+
+func TestHostLimiterPausesHostAfterRepeatedFailures(t *testing.T) {
+	limiter := NewHostLimiter(0, 2, time.Minute)
+	timeoutErr := &url.Error{Op: "Get", URL: "https://example.org/feed.xml", Err: os.ErrDeadlineExceeded}
+
+	limiter.record("example.org", nil, timeoutErr)
+	if _, err := limiter.acquire("example.org", time.Second); err != nil {
+		t.Fatalf(`The host should not be paused after a single failure: %v`, err)
+	}
+
+	limiter.record("example.org", newTestResponse(http.StatusServiceUnavailable, ""), nil)
+	_, err := limiter.acquire("example.org", time.Second)
+	var hostPausedErr *HostPausedError
+	if !errors.As(err, &hostPausedErr) {
+		t.Fatalf(`Expected a paused host error, got %v`, err)
+	}
+
+	if delay := time.Until(hostPausedErr.Until); delay < 50*time.Second || delay > time.Minute {
+		t.Errorf(`Unexpected pause duration: %v`, delay)
+	}
+
+	if hosts := limiter.Status(); len(hosts) != 1 || hosts[0].PauseReason != HostPauseReasonFailures {
+		t.Fatalf(`Unexpected hosts status: %+v`, hosts)
+	}
+
+	// The pause doubles when the host fails again after the pause.
+	limiter.hosts["example.org"].pausedUntil = time.Now()
+	limiter.record("example.org", nil, timeoutErr)
+	limiter.record("example.org", newTestResponse(http.StatusGatewayTimeout, ""), nil)
+
+	_, err = limiter.acquire("example.org", time.Second)
+	if !errors.As(err, &hostPausedErr) {
+		t.Fatalf(`Expected a paused host error, got %v`, err)
+	}
+
+	if delay := time.Until(hostPausedErr.Until); delay < 110*time.Second || delay > 2*time.Minute {
+		t.Errorf(`Unexpected pause duration: %v`, delay)
+	}
+}
+
+func TestHostLimiterSuccessResetsFailures(t *testing.T) {
+	limiter := NewHostLimiter(0, 2, time.Minute)
+	timeoutErr := &url.Error{Op: "Get", URL: "https://example.org/feed.xml", Err: os.ErrDeadlineExceeded}
+
+	limiter.record("example.org", nil, timeoutErr)
+	limiter.record("example.org", newTestResponse(http.StatusNotFound, ""), nil)
+	limiter.record("example.org", nil, timeoutErr)
+
+	if _, err := limiter.acquire("example.org", time.Second); err != nil {
+		t.Fatalf(`The failures should not be consecutive: %v`, err)
+	}
+}
+
+func TestHostLimiterEvictsIdleHosts(t *testing.T) {
+	limiter := NewHostLimiter(2, 2, time.Minute)
+
+	release, err := limiter.acquire("example.org", time.Second)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+	limiter.record("example.org", newTestResponse(http.StatusOK, ""), nil)
+	release()
+
+	release, err = limiter.acquire("example.com", time.Second)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+	limiter.record("example.com", newTestResponse(http.StatusTooManyRequests, "60"), nil)
+	release()
+
+	if len(limiter.hosts) != 1 {
+		t.Fatalf(`Only the paused host should be kept, got %d hosts`, len(limiter.hosts))
+	}
+
+	if _, found := limiter.hosts["example.com"]; !found {
+		t.Fatal(`The paused host should be kept`)
+	}
+
+	// The pause is over, the host is forgotten when another host is contacted.
+	limiter.hosts["example.com"].pausedUntil = time.Now().Add(-time.Second)
+	limiter.record("example.net", newTestResponse(http.StatusOK, ""), nil)
+
+	if _, found := limiter.hosts["example.com"]; found {
+		t.Fatal(`The host should be evicted once its pause is over`)
+	}
+}
+
+func TestRequestBuilderWithHostLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rate-limited" {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	limiter := NewHostLimiter(1, 0, 0)
+	builder := NewRequestBuilder().WithHostLimiter(limiter).WithTimeout(time.Second)
+
+	// The slot of the host is released when the body is closed.
+	for range 2 {
+		responseHandler := NewResponseHandler(builder.ExecuteRequest(server.URL + "/feed.xml"))
+		if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+			t.Fatalf(`Unexpected error: %v`, localizedError.Error())
+		}
+		responseHandler.Close()
+	}
+
+	responseHandler := NewResponseHandler(builder.ExecuteRequest(server.URL + "/rate-limited"))
+	responseHandler.Close()
+
+	responseHandler = NewResponseHandler(builder.ExecuteRequest(server.URL + "/feed.xml"))
+	defer responseHandler.Close()
+
+	hostPauseDelay, isHostPaused := responseHandler.HostPauseDelay()
+	if !isHostPaused || hostPauseDelay <= 0 || hostPauseDelay > time.Minute {
+		t.Fatalf(`The host should be paused for about a minute, got %v`, hostPauseDelay)
+	}
+
+	localizedError := responseHandler.LocalizedError()
+	if localizedError == nil || localizedError.Translate("en_US") == "" {
+		t.Fatal(`A localized error should be returned for a paused host`)
+	}
+}
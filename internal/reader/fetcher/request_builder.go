@@ -4,9 +4,11 @@
 package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
@@ -20,9 +22,14 @@ import (
 const (
 	defaultHTTPClientTimeout = 20 * time.Second
 	defaultAcceptHeader      = "application/xml, application/atom+xml, application/rss+xml, application/rdf+xml, application/feed+json, text/html, */*;q=0.9"
+	redactedHeaderValue      = "[REDACTED]"
 )
 
+// sensitiveHeaders are never written to the logs.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
 type RequestBuilder struct {
+	ctx                context.Context
 	headers            http.Header
 	clientProxyURL     *url.URL
 	clientTimeout      time.Duration
@@ -33,15 +40,26 @@ type RequestBuilder struct {
 	disableCompression bool
 	proxyRotator       *proxyrotator.ProxyRotator
 	feedProxyURL       string
+	customHeaderNames  []string
+	clientCertificate  string
+	clientPrivateKey   string
+	hostLimiter        *HostLimiter
 }
 
 func NewRequestBuilder() *RequestBuilder {
 	return &RequestBuilder{
+		ctx:           context.Background(),
 		headers:       make(http.Header),
 		clientTimeout: defaultHTTPClientTimeout,
 	}
 }
 
+// WithContext cancels the requests in progress when the context is canceled.
+func (r *RequestBuilder) WithContext(ctx context.Context) *RequestBuilder {
+	r.ctx = ctx
+	return r
+}
+
 func (r *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
 	r.headers.Set(key, value)
 	return r
@@ -61,6 +79,13 @@ func (r *RequestBuilder) WithLastModified(lastModified string) *RequestBuilder {
 	return r
 }
 
+// WithoutConditionalHeaders removes the cache validators, they only apply to the URL they were received from.
+func (r *RequestBuilder) WithoutConditionalHeaders() *RequestBuilder {
+	r.headers.Del("If-None-Match")
+	r.headers.Del("If-Modified-Since")
+	return r
+}
+
 func (r *RequestBuilder) WithUserAgent(userAgent string, defaultUserAgent string) *RequestBuilder {
 	if userAgent != "" {
 		r.headers.Set("User-Agent", userAgent)
@@ -84,6 +109,28 @@ func (r *RequestBuilder) WithUsernameAndPassword(username, password string) *Req
 	return r
 }
 
+// WithCustomHeaders adds the headers configured by the user, their values are redacted in the logs.
+func (r *RequestBuilder) WithCustomHeaders(headers map[string]string) *RequestBuilder {
+	for name, value := range headers {
+		r.headers.Set(name, value)
+		r.customHeaderNames = append(r.customHeaderNames, http.CanonicalHeaderKey(name))
+	}
+	return r
+}
+
+// WithClientCertificate sets the PEM encoded certificate and private key used for mutual TLS authentication.
+func (r *RequestBuilder) WithClientCertificate(certificate, privateKey string) *RequestBuilder {
+	r.clientCertificate = certificate
+	r.clientPrivateKey = privateKey
+	return r
+}
+
+// WithHostLimiter shares the concurrency limits and the pauses of the request host with the other requests using the limiter.
+func (r *RequestBuilder) WithHostLimiter(hostLimiter *HostLimiter) *RequestBuilder {
+	r.hostLimiter = hostLimiter
+	return r
+}
+
 func (r *RequestBuilder) WithProxyRotator(proxyRotator *proxyrotator.ProxyRotator) *RequestBuilder {
 	r.proxyRotator = proxyRotator
 	return r
@@ -130,6 +177,15 @@ func (r *RequestBuilder) WithoutCompression() *RequestBuilder {
 }
 
 func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, error) {
+	return r.execute(http.MethodGet, requestURL, "", nil)
+}
+
+// ExecutePostRequest sends the body to the URL with the POST method, like a form submitted to a WebSub hub.
+func (r *RequestBuilder) ExecutePostRequest(requestURL, contentType string, body io.Reader) (*http.Response, error) {
+	return r.execute(http.MethodPost, requestURL, contentType, body)
+}
+
+func (r *RequestBuilder) execute(method, requestURL, contentType string, body io.Reader) (*http.Response, error) {
 	transport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		// Setting `DialContext` disables HTTP/2, this option forces the transport to try HTTP/2 regardless.
@@ -155,6 +211,18 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 		}
 	}
 
+	if r.clientCertificate != "" {
+		clientCertificate, err := tls.X509KeyPair([]byte(r.clientCertificate), []byte(r.clientPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf(`fetcher: invalid client certificate: %w`, err)
+		}
+
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{clientCertificate}
+	}
+
 	if r.disableHTTP2 {
 		transport.ForceAttemptHTTP2 = false
 
@@ -196,12 +264,15 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 
 	client.Transport = transport
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequestWithContext(r.ctx, method, requestURL, body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header = r.headers
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	if r.disableCompression {
 		req.Header.Set("Accept-Encoding", "identity")
 	} else {
@@ -219,13 +290,43 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 	slog.Debug("Making outgoing request", slog.Group("request",
 		slog.String("method", req.Method),
 		slog.String("url", req.URL.String()),
-		slog.Any("headers", req.Header),
+		slog.Any("headers", r.redactedHeaders(req.Header)),
 		slog.Bool("without_redirects", r.withoutRedirects),
 		slog.Bool("use_app_client_proxy", r.useClientProxy),
 		slog.String("client_proxy_url", clientProxyURLRedacted),
 		slog.Bool("ignore_tls_errors", r.ignoreTLSErrors),
 		slog.Bool("disable_http2", r.disableHTTP2),
+		slog.Bool("use_client_certificate", r.clientCertificate != ""),
 	))
 
-	return client.Do(req)
+	if r.hostLimiter == nil {
+		return client.Do(req)
+	}
+
+	host := hostKey(req)
+	release, err := r.hostLimiter.acquire(host, r.clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Do(req)
+	r.hostLimiter.record(host, response, err)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	response.Body = &releaseOnClose{response.Body, release}
+	return response, nil
+}
+
+// redactedHeaders returns a copy of the headers where the credentials and the custom header values are hidden.
+func (r *RequestBuilder) redactedHeaders(headers http.Header) http.Header {
+	redactedHeaders := headers.Clone()
+	for _, name := range slices.Concat(sensitiveHeaders, r.customHeaderNames) {
+		if redactedHeaders.Get(name) != "" {
+			redactedHeaders.Set(name, redactedHeaderValue)
+		}
+	}
+	return redactedHeaders
 }
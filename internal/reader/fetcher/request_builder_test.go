@@ -4,9 +4,20 @@
 package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 )
@@ -71,6 +82,24 @@ func TestRequestBuilder_WithETag(t *testing.T) {
 	}
 }
 
+func TestRequestBuilder_WithoutConditionalHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			t.Errorf("Expected no conditional headers, got '%s' and '%s'", r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	builder := NewRequestBuilder()
+	builder.WithETag("test-etag").WithLastModified("Mon, 02 Jan 2006 15:04:05 GMT")
+	resp, err := builder.WithoutConditionalHeaders().ExecuteRequest(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+}
+
 func TestRequestBuilder_WithLastModified(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -195,6 +224,111 @@ func TestRequestBuilder_WithUsernameAndPassword(t *testing.T) {
 	}
 }
 
+func TestRequestBuilder_WithCustomHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			t.Errorf("Expected Authorization to be 'Bearer secret-token', got '%s'", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Api-Key") != "secret-key" {
+			t.Errorf("Expected X-Api-Key to be 'secret-key', got '%s'", r.Header.Get("X-Api-Key"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	builder := NewRequestBuilder()
+	resp, err := builder.WithCustomHeaders(map[string]string{
+		"Authorization": "Bearer secret-token",
+		"x-api-key":     "secret-key",
+	}).ExecuteRequest(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+}
+
+func TestRequestBuilder_RedactedHeaders(t *testing.T) {
+	builder := NewRequestBuilder()
+	builder.WithCookie("session=secret")
+	builder.WithCustomHeaders(map[string]string{"X-Api-Key": "secret-key"})
+	builder.WithUserAgent("Test", "Default")
+
+	headers := builder.redactedHeaders(builder.headers)
+	for _, name := range []string{"Cookie", "X-Api-Key"} {
+		if headers.Get(name) != redactedHeaderValue {
+			t.Errorf("Expected %s to be redacted, got '%s'", name, headers.Get(name))
+		}
+	}
+
+	if headers.Get("User-Agent") != "Test" {
+		t.Errorf("Expected User-Agent to be kept, got '%s'", headers.Get("User-Agent"))
+	}
+
+	if builder.headers.Get("X-Api-Key") != "secret-key" {
+		t.Error("The request headers should not be modified")
+	}
+}
+
+func TestRequestBuilder_WithClientCertificate(t *testing.T) {
+	certificate, privateKey := generateClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) != 1 || r.TLS.PeerCertificates[0].Subject.CommonName != "miniflux" {
+			t.Error("Expected the client certificate to be sent")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	builder := NewRequestBuilder()
+	resp, err := builder.IgnoreTLSErrors(true).WithClientCertificate(certificate, privateKey).ExecuteRequest(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+}
+
+func TestRequestBuilder_WithInvalidClientCertificate(t *testing.T) {
+	builder := NewRequestBuilder()
+	_, err := builder.WithClientCertificate("invalid", "invalid").ExecuteRequest("https://example.org/")
+	if err == nil {
+		t.Error("Expected an error for an invalid client certificate")
+	}
+}
+
+func generateClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "miniflux"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return string(certificatePEM), string(privateKeyPEM)
+}
+
 func TestRequestBuilder_DefaultAcceptHeader(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Accept") != defaultAcceptHeader {
@@ -332,6 +466,48 @@ func TestRequestBuilder_ConnectionCloseHeader(t *testing.T) {
 	defer resp.Body.Close()
 }
 
+func TestRequestBuilder_ExecutePostRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got '%s'", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("Expected Content-Type to be 'application/x-www-form-urlencoded', got '%s'", r.Header.Get("Content-Type"))
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("key") != "value" {
+			t.Errorf("Expected the form to be sent, got '%v'", r.PostForm)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	builder := NewRequestBuilder()
+	resp, err := builder.ExecutePostRequest(server.URL, "application/x-www-form-urlencoded", strings.NewReader("key=value"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status code %d, got %d", http.StatusAccepted, resp.StatusCode)
+	}
+}
+
+func TestRequestBuilder_WithCanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewRequestBuilder().WithContext(ctx).ExecuteRequest(server.URL)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a context canceled error, got %v", err)
+	}
+}
+
 func TestRequestBuilder_WithCustomApplicationProxyURL(t *testing.T) {
 	proxyURL, _ := url.Parse("http://proxy.example.com:8080")
 	builder := NewRequestBuilder()
@@ -29,6 +29,14 @@ func NewResponseHandler(httpResponse *http.Response, clientErr error) *ResponseH
 	return &ResponseHandler{httpResponse: httpResponse, clientErr: clientErr}
 }
 
+// StatusCode returns the HTTP status code of the response, or 0 if no response was received.
+func (r *ResponseHandler) StatusCode() int {
+	if r.httpResponse == nil {
+		return 0
+	}
+	return r.httpResponse.StatusCode
+}
+
 func (r *ResponseHandler) EffectiveURL() string {
 	return r.httpResponse.Request.URL.String()
 }
@@ -82,7 +90,19 @@ func (r *ResponseHandler) CacheControlMaxAge() time.Duration {
 }
 
 func (r *ResponseHandler) ParseRetryDelay() time.Duration {
-	retryAfterHeaderValue := r.httpResponse.Header.Get("Retry-After")
+	return parseRetryAfter(r.httpResponse.Header.Get("Retry-After"))
+}
+
+// HostPauseDelay returns the time left before the end of the pause of the host, when the request was not sent because of it.
+func (r *ResponseHandler) HostPauseDelay() (time.Duration, bool) {
+	var hostPausedErr *HostPausedError
+	if errors.As(r.clientErr, &hostPausedErr) {
+		return max(time.Until(hostPausedErr.Until).Truncate(time.Second), 0), true
+	}
+	return 0, false
+}
+
+func parseRetryAfter(retryAfterHeaderValue string) time.Duration {
 	if retryAfterHeaderValue != "" {
 		// First, try to parse as an integer (number of seconds)
 		if seconds, err := strconv.Atoi(retryAfterHeaderValue); err == nil {
@@ -126,6 +146,29 @@ func (r *ResponseHandler) IsRedirect() bool {
 			r.httpResponse.StatusCode == http.StatusPermanentRedirect)
 }
 
+// PermanentRedirectURL returns the final URL when the requested URL was moved permanently (301 or 308 status code).
+// It returns an empty string when there was no redirect, or when one of the redirects was temporary.
+func (r *ResponseHandler) PermanentRedirectURL() string {
+	if r.httpResponse == nil || r.httpResponse.Request == nil || r.httpResponse.Request.Response == nil {
+		return ""
+	}
+
+	for request := r.httpResponse.Request; request != nil && request.Response != nil; request = request.Response.Request {
+		switch request.Response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+		default:
+			return ""
+		}
+	}
+
+	return r.EffectiveURL()
+}
+
+// IsGone returns true if the server indicates that the resource was removed permanently (410 status code).
+func (r *ResponseHandler) IsGone() bool {
+	return r.httpResponse != nil && r.httpResponse.StatusCode == http.StatusGone
+}
+
 func (r *ResponseHandler) Close() {
 	if r.httpResponse != nil && r.httpResponse.Body != nil && r.clientErr == nil {
 		r.httpResponse.Body.Close()
@@ -176,7 +219,11 @@ func (r *ResponseHandler) ReadBody(maxBodySize int64) ([]byte, *locale.Localized
 
 func (r *ResponseHandler) LocalizedError() *locale.LocalizedErrorWrapper {
 	if r.clientErr != nil {
+		var hostPausedErr *HostPausedError
 		switch {
+		case errors.As(r.clientErr, &hostPausedErr):
+			hostPauseDelay, _ := r.HostPauseDelay()
+			return locale.NewLocalizedErrorWrapper(r.clientErr, "error.http_host_paused", hostPausedErr.Host, hostPauseDelay.String())
 		case isSSLError(r.clientErr):
 			return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: %w", r.clientErr), "error.tls_error", r.clientErr)
 		case isNetworkError(r.clientErr):
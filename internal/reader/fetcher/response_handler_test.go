@@ -4,7 +4,9 @@
 package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -69,6 +71,71 @@ func TestIsModified(t *testing.T) {
 	}
 }
 
+func TestStatusCode(t *testing.T) {
+	responseHandler := NewResponseHandler(&http.Response{StatusCode: http.StatusNotModified}, nil)
+	if statusCode := responseHandler.StatusCode(); statusCode != http.StatusNotModified {
+		t.Errorf(`Unexpected status code, got %d instead of %d`, statusCode, http.StatusNotModified)
+	}
+
+	responseHandler = NewResponseHandler(nil, errors.New("connection refused"))
+	if statusCode := responseHandler.StatusCode(); statusCode != 0 {
+		t.Errorf(`The status code should be 0 without response, got %d`, statusCode)
+	}
+}
+
+func TestPermanentRedirectURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/moved-again", http.StatusMovedPermanently)
+		case "/moved-again":
+			http.Redirect(w, r, "/feed.xml", http.StatusPermanentRedirect)
+		case "/temporary":
+			http.Redirect(w, r, "/moved", http.StatusFound)
+		default:
+			w.Write([]byte("<rss/>"))
+		}
+	}))
+	defer server.Close()
+
+	var testCases = map[string]struct {
+		Path        string
+		ExpectedURL string
+	}{
+		"No redirect":         {Path: "/feed.xml", ExpectedURL: ""},
+		"Permanent redirects": {Path: "/moved", ExpectedURL: server.URL + "/feed.xml"},
+		"Temporary redirect":  {Path: "/temporary", ExpectedURL: ""},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(tt *testing.T) {
+			responseHandler := NewResponseHandler(NewRequestBuilder().ExecuteRequest(server.URL + tc.Path))
+			defer responseHandler.Close()
+
+			if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+				tt.Fatal(localizedError.Error())
+			}
+
+			if redirectURL := responseHandler.PermanentRedirectURL(); redirectURL != tc.ExpectedURL {
+				tt.Errorf(`Unexpected redirect URL, got %q instead of %q`, redirectURL, tc.ExpectedURL)
+			}
+		})
+	}
+}
+
+func TestIsGone(t *testing.T) {
+	if !NewResponseHandler(&http.Response{StatusCode: http.StatusGone}, nil).IsGone() {
+		t.Error(`A 410 status code should be reported as gone`)
+	}
+
+	if NewResponseHandler(&http.Response{StatusCode: http.StatusNotFound}, nil).IsGone() {
+		t.Error(`A 404 status code should not be reported as gone`)
+	}
+
+	if NewResponseHandler(nil, errors.New("connection refused")).IsGone() {
+		t.Error(`A request without response should not be reported as gone`)
+	}
+}
+
 func TestRetryDelay(t *testing.T) {
 	var testCases = map[string]struct {
 		RetryAfterHeader string
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "miniflux.app/v2/internal/reader/filter"
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Action rules apply an action to new entries matching a filter rule.
+//
+// Each rule must be on a separate line and has the form "Action: Rule",
+// where Rule is either a "FieldName=RegEx" pair or a boolean expression:
+//
+//	MarkAsRead: EntryTitle =~ "(?i)sponsored"
+//	Star: EntryAuthor == "Alice"
+//	AddTag(security): EntryTag == "cve" or EntryTitle =~ "(?i)vulnerability"
+//	SendTo(wallabag): EntryReadingTime >= 10
+//
+// User action rules are evaluated before feed action rules, and all matching rules are applied.
+const (
+	ActionMarkAsRead = "MarkAsRead"
+	ActionStar       = "Star"
+	ActionAddTag     = "AddTag"
+	ActionSendTo     = "SendTo"
+)
+
+var actionRulePattern = regexp.MustCompile(`^\s*(\w+)(?:\(([^)]*)\))?\s*:(.*)$`)
+
+// EntryAction is an action to apply to an entry.
+type EntryAction struct {
+	Type     string
+	Argument string
+}
+
+type actionRule struct {
+	Action EntryAction
+	Rule   filterRule
+}
+
+type actionRules []actionRule
+
+// WithReadingSpeeds sets the reading speeds of the user, used to estimate the reading time of the entries
+// for the EntryReadingTime rules.
+func (rules actionRules) WithReadingSpeeds(defaultSpeed, cjkSpeed int) actionRules {
+	for i := range rules {
+		rules[i].Rule.speeds = readingSpeeds{defaultSpeed: defaultSpeed, cjkSpeed: cjkSpeed}
+	}
+	return rules
+}
+
+// ParseActionRules parses user and feed action rules. Invalid rules are ignored.
+func ParseActionRules(userRules, feedRules string) actionRules {
+	rules := make(actionRules, 0)
+	for _, rulesText := range []string{userRules, feedRules} {
+		for line := range strings.SplitSeq(strings.TrimSpace(rulesText), "\n") {
+			if rule, err := parseActionRule(line); err == nil {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules
+}
+
+// ParseActionRule returns the action of a rule, or an error if the rule is invalid.
+// Expression errors are reported as an *ExpressionError with a column relative to the whole line.
+// The validation of the integration name given to SendTo is left to the caller.
+func ParseActionRule(line string) (EntryAction, error) {
+	rule, err := parseActionRule(line)
+	if err != nil {
+		return EntryAction{}, err
+	}
+	return rule.Action, nil
+}
+
+func parseActionRule(line string) (actionRule, error) {
+	line = strings.TrimRight(line, "\r\n")
+
+	matches := actionRulePattern.FindStringSubmatchIndex(line)
+	if matches == nil {
+		return actionRule{}, fmt.Errorf(`expected "Action: Rule"`)
+	}
+
+	action := EntryAction{Type: line[matches[2]:matches[3]]}
+	if matches[4] >= 0 {
+		action.Argument = strings.TrimSpace(line[matches[4]:matches[5]])
+	}
+
+	switch action.Type {
+	case ActionMarkAsRead, ActionStar:
+		if action.Argument != "" {
+			return actionRule{}, fmt.Errorf("the %s action does not take an argument", action.Type)
+		}
+	case ActionAddTag, ActionSendTo:
+		if action.Argument == "" {
+			return actionRule{}, fmt.Errorf("the %s action requires an argument", action.Type)
+		}
+	default:
+		return actionRule{}, fmt.Errorf("unknown action %q (options: %s)", action.Type, strings.Join([]string{ActionMarkAsRead, ActionStar, ActionAddTag, ActionSendTo}, ", "))
+	}
+
+	condition := line[matches[6]:matches[7]]
+	if IsExpressionRule(condition) {
+		if err := ValidateExpression(condition); err != nil {
+			if expressionErr, ok := err.(*ExpressionError); ok {
+				columnOffset := utf8.RuneCountInString(line[:matches[6]])
+				return actionRule{}, &ExpressionError{Column: expressionErr.Column + columnOffset, Message: expressionErr.Message}
+			}
+			return actionRule{}, err
+		}
+	}
+
+	valid, rule := parseRule(condition)
+	if !valid {
+		return actionRule{}, fmt.Errorf("invalid rule %q", strings.TrimSpace(condition))
+	}
+
+	if rule.expression == nil {
+		if err := validateLegacyRule(condition); err != nil {
+			return actionRule{}, err
+		}
+	}
+
+	return actionRule{Action: action, Rule: rule}, nil
+}
+
+// MatchingActions returns the actions of all rules matching the entry.
+func MatchingActions(rules actionRules, feed *model.Feed, entry *model.Entry) []EntryAction {
+	var actions []EntryAction
+	for _, rule := range rules {
+		if matchesRule(rule.Rule, feed, entry) {
+			slog.Debug("Entry matches action rule",
+				slog.String("entry_url", entry.URL),
+				slog.String("entry_title", entry.Title),
+				slog.String("feed_url", feed.FeedURL),
+				slog.String("action", rule.Action.Type),
+				slog.String("action_argument", rule.Action.Argument),
+				slog.String("rule_type", rule.Rule.Type),
+				slog.String("rule_value", rule.Rule.Value),
+			)
+			actions = append(actions, rule.Action)
+		}
+	}
+	return actions
+}
+
+// ApplyActions applies the actions to the entry.
+// Integrations are only recorded on the entry, they are called once the entry is stored.
+func ApplyActions(actions []EntryAction, entry *model.Entry) {
+	for _, action := range actions {
+		switch action.Type {
+		case ActionMarkAsRead:
+			entry.Status = model.EntryStatusRead
+		case ActionStar:
+			entry.Starred = true
+		case ActionAddTag:
+			if !slices.Contains(entry.Tags, action.Argument) {
+				entry.Tags = append(entry.Tags, action.Argument)
+			}
+		case ActionSendTo:
+			if !slices.Contains(entry.SendToIntegrations, action.Argument) {
+				entry.SendToIntegrations = append(entry.SendToIntegrations, action.Argument)
+			}
+		}
+	}
+}
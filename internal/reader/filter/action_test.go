@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "miniflux.app/v2/internal/reader/filter"
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestParseActionRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected EntryAction
+		err      string
+	}{
+		{rule: "MarkAsRead: EntryTitle=sponsored", expected: EntryAction{Type: ActionMarkAsRead}},
+		{rule: `  Star : EntryAuthor == "Alice"`, expected: EntryAction{Type: ActionStar}},
+		{rule: `AddTag(security): EntryTag == "cve"`, expected: EntryAction{Type: ActionAddTag, Argument: "security"}},
+		{rule: `AddTag( with spaces ): EntryTag == "cve"`, expected: EntryAction{Type: ActionAddTag, Argument: "with spaces"}},
+		{rule: "SendTo(wallabag): EntryReadingTime >= 10\r", expected: EntryAction{Type: ActionSendTo, Argument: "wallabag"}},
+		{rule: `SendTo(wallabag): EntryURL =~ "https://example\.org/"`, expected: EntryAction{Type: ActionSendTo, Argument: "wallabag"}},
+		{rule: "EntryTitle=test", err: `expected "Action: Rule"`},
+		{rule: "Delete: EntryTitle=test", err: `unknown action "Delete"`},
+		{rule: "Star(x): EntryTitle=test", err: "does not take an argument"},
+		{rule: "AddTag: EntryTitle=test", err: "requires an argument"},
+		{rule: "SendTo(): EntryTitle=test", err: "requires an argument"},
+		{rule: "Star:", err: "invalid rule"},
+		{rule: "Star: EntryTitle", err: "expected a comparison operator"},
+		{rule: "Star: EntryTitle=~draft", expected: EntryAction{Type: ActionStar}},
+		{rule: "Star: EntryTitle=(unclosed", err: "invalid regex"},
+		{rule: "Star: NotAField=go", err: `unknown field "NotAField"`},
+		{rule: "Star: EntryTitle =go", err: "without spaces"},
+		{rule: `Star: EntryTitle=="a" and EntryAuthor=="b"`, err: "ambiguous rule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			action, err := ParseActionRule(tt.rule)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if action != tt.expected {
+				t.Errorf("Got %+v, expected %+v", action, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseActionRuleErrorColumn(t *testing.T) {
+	_, err := ParseActionRule(`AddTag(go): EntryTitle =~ "[go"`)
+
+	var expressionErr *ExpressionError
+	if !errors.As(err, &expressionErr) {
+		t.Fatalf("Expected an *ExpressionError, got %v", err)
+	}
+
+	if expressionErr.Column != 27 {
+		t.Errorf("Expected the error at column 27, got %d", expressionErr.Column)
+	}
+}
+
+func TestParseActionRules(t *testing.T) {
+	rules := ParseActionRules(
+		"MarkAsRead: EntryTitle=sponsored\ninvalid\n\nStar: EntryAuthor == \"Alice\"",
+		"AddTag(feed): EntryTag == \"go\"",
+	)
+
+	if len(rules) != 3 {
+		t.Fatalf("Expected 3 rules, got %d", len(rules))
+	}
+
+	if rules[2].Action.Type != ActionAddTag {
+		t.Errorf("Expected feed rules to be evaluated after user rules, got %+v", rules[2].Action)
+	}
+}
+
+func TestMatchingAndApplyActions(t *testing.T) {
+	feed := createTestFeed()
+	entry := createTestEntry()
+
+	rules := ParseActionRules(
+		"MarkAsRead: EntryTitle =~ \"Test\" and EntryAuthor == \"Nobody\"\nStar: EntryAuthor == \"Test Author\"\nAddTag(important): EntryTag == \"golang\"\nAddTag(golang): EntryTitle=Test",
+		"SendTo(wallabag): EntryURL=example\nSendTo(wallabag): EntryTitle=Test",
+	)
+
+	actions := MatchingActions(rules, feed, entry)
+	if len(actions) != 5 {
+		t.Fatalf("Expected 5 matching actions, got %d: %+v", len(actions), actions)
+	}
+
+	ApplyActions(actions, entry)
+
+	if entry.Status == model.EntryStatusRead {
+		t.Error("The entry should not be marked as read")
+	}
+
+	if !entry.Starred {
+		t.Error("The entry should be starred")
+	}
+
+	if !slices.Equal(entry.Tags, []string{"golang", "testing", "miniflux", "important"}) {
+		t.Errorf("Unexpected tags: %v", entry.Tags)
+	}
+
+	if !slices.Equal(entry.SendToIntegrations, []string{"wallabag"}) {
+		t.Errorf("Unexpected integrations: %v", entry.SendToIntegrations)
+	}
+}
+
+func TestApplyMarkAsReadAction(t *testing.T) {
+	entry := createTestEntry()
+	ApplyActions([]EntryAction{{Type: ActionMarkAsRead}}, entry)
+
+	if entry.Status != model.EntryStatusRead {
+		t.Errorf("Expected the entry to be marked as read, got %q", entry.Status)
+	}
+}
@@ -0,0 +1,619 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "miniflux.app/v2/internal/reader/filter"
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Expression rules combine field comparisons with boolean operators:
+//
+//	EntryTitle =~ "(?i)golang" and not EntryAuthor == "Bot"
+//	(EntryTag == "linux" or EntryContent =~ "kernel") and EntryReadingTime >= 5
+//	EntryDate < "now-30d"
+//	CategoryTitle == "News" and EnclosureMimeType =~ "^audio/"
+//
+// Supported operators:
+//
+//	Text and list fields: == != =~ !~
+//	Numeric fields: == != < <= > >=
+//	Date fields: < <= > >= (values are "YYYY-MM-DD", "now", "now-30d" or "now+1h")
+//
+// Boolean operators are "and", "or" and "not" (or "&&", "||" and "!"), parentheses can be used for grouping.
+// Values must be quoted strings, except for numeric fields which expect a number.
+
+const expressionRuleType = "Expression"
+
+// legacyRulePattern matches the historical "FieldName=RegEx" syntax.
+// A "=" followed by another "=" or "~" is an expression operator instead.
+var legacyRulePattern = regexp.MustCompile(`^\s*\w*\s*=([^=~]|$)`)
+
+// LegacyFieldNames are the field names accepted by the "FieldName=RegEx" syntax.
+var LegacyFieldNames = []string{
+	"EntryTitle", "EntryURL", "EntryCommentsURL", "EntryContent", "EntryAuthor", "EntryTag", "EntryDate",
+	"EntryLanguage", "EntryReadingTime", "FeedTitle", "FeedURL", "CategoryTitle", "EnclosureMimeType", "EnclosureURL",
+}
+
+// IsExpressionRule returns true if the rule uses the expression syntax rather than the legacy "FieldName=RegEx" syntax.
+// A rule that is a valid legacy rule is never an expression, like "EntryTitle=~draft" whose regex is "~draft".
+func IsExpressionRule(rule string) bool {
+	if strings.TrimSpace(rule) == "" || isValidLegacyRule(rule) {
+		return false
+	}
+	return !legacyRulePattern.MatchString(rule)
+}
+
+// isValidLegacyRule returns true if the rule is a field name directly followed by "=" and a valid regex.
+func isValidLegacyRule(rule string) bool {
+	fieldName, pattern, found := strings.Cut(strings.TrimLeft(rule, " \t"), "=")
+	if !found || !slices.Contains(LegacyFieldNames, fieldName) {
+		return false
+	}
+
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+// ambiguousLegacyRegexPattern matches the regex of a legacy rule that is likely an expression written without spaces,
+// like the regex `="a" and EntryAuthor=="b"` of the rule `EntryTitle=="a" and EntryAuthor=="b"`.
+var ambiguousLegacyRegexPattern = regexp.MustCompile(`==|=~|!=|!~|"\s*(&&|\|\|)|"\s+(and|or)\s`)
+
+// IsAmbiguousLegacyRule returns true if the legacy rule can also be read as an expression written without spaces.
+// Such rules are rejected because their regex silently never matches what the user meant.
+func IsAmbiguousLegacyRule(rule string) bool {
+	_, pattern, found := strings.Cut(rule, "=")
+	if !found {
+		return false
+	}
+
+	if ambiguousLegacyRegexPattern.MatchString(pattern) {
+		return true
+	}
+
+	_, err := parseExpression(rule)
+	return err == nil
+}
+
+// validateLegacyRule returns an error if the "FieldName=RegEx" rule is invalid, with the checks of the settings validator.
+func validateLegacyRule(rule string) error {
+	rule = strings.TrimSpace(rule)
+	fieldName, pattern, found := strings.Cut(rule, "=")
+	if !slices.Contains(LegacyFieldNames, strings.TrimSpace(fieldName)) {
+		return fmt.Errorf("unknown field %q (options: %s)", strings.TrimSpace(fieldName), strings.Join(LegacyFieldNames, ", "))
+	}
+
+	if !found || strings.TrimSpace(fieldName) != fieldName {
+		return fmt.Errorf(`expected "FieldName=RegEx" without spaces around "="`)
+	}
+
+	if pattern == "" {
+		return fmt.Errorf("the regex of the %s field is missing", fieldName)
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid regex %q: %v", pattern, err)
+	}
+
+	if IsAmbiguousLegacyRule(rule) {
+		return fmt.Errorf("ambiguous rule %q, add spaces around the operators to write an expression", strings.TrimSpace(rule))
+	}
+
+	return nil
+}
+
+// ExpressionError describes why an expression rule cannot be parsed.
+type ExpressionError struct {
+	Column  int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// ValidateExpression returns an *ExpressionError if the expression rule is invalid.
+func ValidateExpression(expression string) error {
+	if _, err := parseExpression(expression); err != nil {
+		return err
+	}
+	return nil
+}
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	listField
+	numberField
+	dateField
+)
+
+type expressionField struct {
+	kind   fieldKind
+	text   func(feed *model.Feed, entry *model.Entry) string
+	list   func(feed *model.Feed, entry *model.Entry) []string
+	number func(feed *model.Feed, entry *model.Entry, speeds readingSpeeds) float64
+	date   func(feed *model.Feed, entry *model.Entry) time.Time
+}
+
+var expressionFields = map[string]expressionField{
+	"EntryTitle":       {kind: textField, text: func(_ *model.Feed, entry *model.Entry) string { return entry.Title }},
+	"EntryURL":         {kind: textField, text: func(_ *model.Feed, entry *model.Entry) string { return entry.URL }},
+	"EntryCommentsURL": {kind: textField, text: func(_ *model.Feed, entry *model.Entry) string { return entry.CommentsURL }},
+	"EntryContent":     {kind: textField, text: func(_ *model.Feed, entry *model.Entry) string { return entry.Content }},
+	"EntryAuthor":      {kind: textField, text: func(_ *model.Feed, entry *model.Entry) string { return entry.Author }},
+	"EntryLanguage":    {kind: textField, text: func(_ *model.Feed, entry *model.Entry) string { return entry.Language }},
+	"EntryTag":         {kind: listField, list: func(_ *model.Feed, entry *model.Entry) []string { return entry.Tags }},
+	"EntryDate":        {kind: dateField, date: func(_ *model.Feed, entry *model.Entry) time.Time { return entry.Date }},
+	"EntryReadingTime": {kind: numberField, number: func(_ *model.Feed, entry *model.Entry, speeds readingSpeeds) float64 {
+		return entryReadingTime(entry, speeds)
+	}},
+	"FeedTitle":         {kind: textField, text: func(feed *model.Feed, _ *model.Entry) string { return feedTitle(feed) }},
+	"FeedURL":           {kind: textField, text: func(feed *model.Feed, _ *model.Entry) string { return feedURL(feed) }},
+	"CategoryTitle":     {kind: textField, text: func(feed *model.Feed, _ *model.Entry) string { return categoryTitle(feed) }},
+	"EnclosureMimeType": {kind: listField, list: func(_ *model.Feed, entry *model.Entry) []string { return enclosureMimeTypes(entry) }},
+	"EnclosureURL":      {kind: listField, list: func(_ *model.Feed, entry *model.Entry) []string { return enclosureURLs(entry) }},
+}
+
+func expressionFieldNames() []string {
+	names := make([]string, 0, len(expressionFields))
+	for name := range expressionFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+type expressionNode interface {
+	evaluate(feed *model.Feed, entry *model.Entry, speeds readingSpeeds) bool
+}
+
+type andNode struct {
+	left, right expressionNode
+}
+
+func (n *andNode) evaluate(feed *model.Feed, entry *model.Entry, speeds readingSpeeds) bool {
+	return n.left.evaluate(feed, entry, speeds) && n.right.evaluate(feed, entry, speeds)
+}
+
+type orNode struct {
+	left, right expressionNode
+}
+
+func (n *orNode) evaluate(feed *model.Feed, entry *model.Entry, speeds readingSpeeds) bool {
+	return n.left.evaluate(feed, entry, speeds) || n.right.evaluate(feed, entry, speeds)
+}
+
+type notNode struct {
+	operand expressionNode
+}
+
+func (n *notNode) evaluate(feed *model.Feed, entry *model.Entry, speeds readingSpeeds) bool {
+	return !n.operand.evaluate(feed, entry, speeds)
+}
+
+type comparisonNode struct {
+	field    expressionField
+	operator string
+	text     string
+	pattern  *regexp.Regexp
+	number   float64
+	date     dateValue
+}
+
+func (n *comparisonNode) evaluate(feed *model.Feed, entry *model.Entry, speeds readingSpeeds) bool {
+	switch n.field.kind {
+	case textField:
+		return n.matchesText(n.field.text(feed, entry))
+	case listField:
+		// Negative operators match when no item matches the positive operator.
+		negated := n.operator == "!=" || n.operator == "!~"
+		for _, item := range n.field.list(feed, entry) {
+			if n.matchesText(item) != negated {
+				return !negated
+			}
+		}
+		return negated
+	case numberField:
+		return compareOrdered(n.field.number(feed, entry, speeds), n.operator, n.number)
+	case dateField:
+		return compareOrdered(n.field.date(feed, entry).Unix(), n.operator, n.date.resolve().Unix())
+	}
+	return false
+}
+
+func (n *comparisonNode) matchesText(value string) bool {
+	switch n.operator {
+	case "==":
+		return value == n.text
+	case "!=":
+		return value != n.text
+	case "=~":
+		return n.pattern.MatchString(value)
+	case "!~":
+		return !n.pattern.MatchString(value)
+	}
+	return false
+}
+
+func compareOrdered[T int64 | float64](value T, operator string, target T) bool {
+	switch operator {
+	case "==":
+		return value == target
+	case "!=":
+		return value != target
+	case "<":
+		return value < target
+	case "<=":
+		return value <= target
+	case ">":
+		return value > target
+	case ">=":
+		return value >= target
+	}
+	return false
+}
+
+// dateValue is either an absolute date or an offset relative to the evaluation time.
+type dateValue struct {
+	absolute time.Time
+	offset   time.Duration
+	relative bool
+}
+
+func (d dateValue) resolve() time.Time {
+	if d.relative {
+		return time.Now().Add(d.offset)
+	}
+	return d.absolute
+}
+
+func parseDateValue(value string) (dateValue, error) {
+	if value == "now" {
+		return dateValue{relative: true}, nil
+	}
+
+	if offset, found := strings.CutPrefix(value, "now-"); found {
+		duration, err := parseDuration(offset)
+		if err != nil {
+			return dateValue{}, err
+		}
+		return dateValue{relative: true, offset: -duration}, nil
+	}
+
+	if offset, found := strings.CutPrefix(value, "now+"); found {
+		duration, err := parseDuration(offset)
+		if err != nil {
+			return dateValue{}, err
+		}
+		return dateValue{relative: true, offset: duration}, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return dateValue{}, err
+	}
+	return dateValue{absolute: date}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of rule"
+	case tokenString:
+		return strconv.Quote(t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+func tokenizeExpression(input string) ([]token, error) {
+	var tokens []token
+
+	position := 0
+	for position < len(input) {
+		r, size := utf8.DecodeRuneInString(input[position:])
+		column := utf8.RuneCountInString(input[:position]) + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			position += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "(", column: column})
+			position++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")", column: column})
+			position++
+		case r == '"' || r == '\'':
+			value, length, err := readQuotedString(input[position:], r)
+			if err != nil {
+				return nil, &ExpressionError{Column: column, Message: err.Error()}
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, column: column})
+			position += length
+		case r == '&' || r == '|':
+			if position+1 >= len(input) || rune(input[position+1]) != r {
+				return nil, &ExpressionError{Column: column, Message: fmt.Sprintf("unexpected character %q", r)}
+			}
+			kind := tokenAnd
+			if r == '|' {
+				kind = tokenOr
+			}
+			tokens = append(tokens, token{kind: kind, value: input[position : position+2], column: column})
+			position += 2
+		case strings.ContainsRune("=!<>", r):
+			operator := input[position : position+1]
+			if position+1 < len(input) && strings.ContainsRune("=~", rune(input[position+1])) {
+				operator = input[position : position+2]
+			}
+			switch operator {
+			case "!":
+				tokens = append(tokens, token{kind: tokenNot, value: operator, column: column})
+			case "==", "!=", "=~", "!~", "<", "<=", ">", ">=":
+				tokens = append(tokens, token{kind: tokenOperator, value: operator, column: column})
+			default:
+				return nil, &ExpressionError{Column: column, Message: fmt.Sprintf("unknown operator %q", operator)}
+			}
+			position += len(operator)
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			end := position + 1
+			for end < len(input) && (input[end] >= '0' && input[end] <= '9' || input[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: input[position:end], column: column})
+			position = end
+		case unicode.IsLetter(r) || r == '_':
+			end := position
+			for end < len(input) {
+				next, nextSize := utf8.DecodeRuneInString(input[end:])
+				if !unicode.IsLetter(next) && !unicode.IsDigit(next) && next != '_' {
+					break
+				}
+				end += nextSize
+			}
+			word := input[position:end]
+			switch strings.ToLower(word) {
+			case "and":
+				tokens = append(tokens, token{kind: tokenAnd, value: word, column: column})
+			case "or":
+				tokens = append(tokens, token{kind: tokenOr, value: word, column: column})
+			case "not":
+				tokens = append(tokens, token{kind: tokenNot, value: word, column: column})
+			default:
+				tokens = append(tokens, token{kind: tokenIdentifier, value: word, column: column})
+			}
+			position = end
+		default:
+			return nil, &ExpressionError{Column: column, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, column: utf8.RuneCountInString(input) + 1}), nil
+}
+
+// readQuotedString reads a string delimited by quote and returns its value and length in bytes.
+// Only the quote character and the backslash can be escaped, so regex escapes like \d are kept as is.
+func readQuotedString(input string, quote rune) (string, int, error) {
+	var builder strings.Builder
+	for position := 1; position < len(input); position++ {
+		switch c := input[position]; {
+		case rune(c) == quote:
+			return builder.String(), position + 1, nil
+		case c == '\\' && position+1 < len(input) && (rune(input[position+1]) == quote || input[position+1] == '\\'):
+			position++
+			builder.WriteByte(input[position])
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+type expressionParser struct {
+	tokens   []token
+	position int
+}
+
+func parseExpression(input string) (expressionNode, error) {
+	tokens, err := tokenizeExpression(input)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &expressionParser{tokens: tokens}
+	node, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := parser.peek(); next.kind != tokenEOF {
+		return nil, &ExpressionError{Column: next.column, Message: fmt.Sprintf("unexpected %s", next)}
+	}
+
+	return node, nil
+}
+
+func (p *expressionParser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *expressionParser) next() token {
+	current := p.tokens[p.position]
+	if current.kind != tokenEOF {
+		p.position++
+	}
+	return current
+}
+
+func (p *expressionParser) parseOr() (expressionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (expressionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (expressionNode, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (expressionNode, error) {
+	current := p.next()
+
+	switch current.kind {
+	case tokenLeftParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, &ExpressionError{Column: closing.column, Message: fmt.Sprintf("expected \")\" but found %s", closing)}
+		}
+		return node, nil
+	case tokenIdentifier:
+		return p.parseComparison(current)
+	}
+
+	return nil, &ExpressionError{Column: current.column, Message: fmt.Sprintf("expected a field name but found %s", current)}
+}
+
+func (p *expressionParser) parseComparison(fieldToken token) (expressionNode, error) {
+	field, found := expressionFields[fieldToken.value]
+	if !found {
+		return nil, &ExpressionError{
+			Column:  fieldToken.column,
+			Message: fmt.Sprintf("unknown field %q (options: %s)", fieldToken.value, strings.Join(expressionFieldNames(), ", ")),
+		}
+	}
+
+	operatorToken := p.next()
+	if operatorToken.kind != tokenOperator {
+		return nil, &ExpressionError{Column: operatorToken.column, Message: fmt.Sprintf("expected a comparison operator but found %s", operatorToken)}
+	}
+
+	var allowedOperators []string
+	switch field.kind {
+	case textField, listField:
+		allowedOperators = []string{"==", "!=", "=~", "!~"}
+	case numberField:
+		allowedOperators = []string{"==", "!=", "<", "<=", ">", ">="}
+	case dateField:
+		allowedOperators = []string{"<", "<=", ">", ">="}
+	}
+
+	if !slices.Contains(allowedOperators, operatorToken.value) {
+		return nil, &ExpressionError{
+			Column:  operatorToken.column,
+			Message: fmt.Sprintf("operator %q is not supported by %s (options: %s)", operatorToken.value, fieldToken.value, strings.Join(allowedOperators, " ")),
+		}
+	}
+
+	node := &comparisonNode{field: field, operator: operatorToken.value}
+	valueToken := p.next()
+
+	switch field.kind {
+	case numberField:
+		if valueToken.kind != tokenNumber {
+			return nil, &ExpressionError{Column: valueToken.column, Message: fmt.Sprintf("expected a number but found %s", valueToken)}
+		}
+		number, err := strconv.ParseFloat(valueToken.value, 64)
+		if err != nil {
+			return nil, &ExpressionError{Column: valueToken.column, Message: fmt.Sprintf("invalid number %s", valueToken)}
+		}
+		node.number = number
+	case dateField:
+		if valueToken.kind != tokenString {
+			return nil, &ExpressionError{Column: valueToken.column, Message: fmt.Sprintf("expected a quoted date but found %s", valueToken)}
+		}
+		date, err := parseDateValue(valueToken.value)
+		if err != nil {
+			return nil, &ExpressionError{Column: valueToken.column, Message: fmt.Sprintf("invalid date %s (expected YYYY-MM-DD, now, now-30d or now+1h)", valueToken)}
+		}
+		node.date = date
+	default:
+		if valueToken.kind != tokenString {
+			return nil, &ExpressionError{Column: valueToken.column, Message: fmt.Sprintf("expected a quoted string but found %s", valueToken)}
+		}
+		node.text = valueToken.value
+		if node.operator == "=~" || node.operator == "!~" {
+			pattern, err := regexp.Compile(valueToken.value)
+			if err != nil {
+				return nil, &ExpressionError{Column: valueToken.column, Message: fmt.Sprintf("invalid regex: %v", err)}
+			}
+			node.pattern = pattern
+		}
+	}
+
+	return node, nil
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "miniflux.app/v2/internal/reader/filter"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsExpressionRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected bool
+	}{
+		{"EntryTitle=test", false},
+		{"  EntryTitle  =  test  ", false},
+		{"EntryTitle=", false},
+		{"=value", false},
+		{"EntryContent=x=y", false},
+		{"", false},
+		{"   ", false},
+		{`EntryTitle == "test"`, true},
+		{`EntryTitle =~ "test"`, true},
+		{`EntryTitle=~"test"`, false},
+		{"EntryTitle=~draft", false},
+		{"EntryTitle==>", false},
+		{"EntryURL=~", false},
+		{"EntryTitle==(", true},
+		{"UnknownField==x", true},
+		{`not EntryTitle =~ "test"`, true},
+		{`(EntryTitle =~ "a")`, true},
+		{"EntryReadingTime > 5", true},
+		{"invalid_rule", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if result := IsExpressionRule(tt.rule); result != tt.expected {
+				t.Errorf("IsExpressionRule(%q) = %v, expected %v", tt.rule, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAmbiguousLegacyRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected bool
+	}{
+		{"EntryTitle=test", false},
+		{"EntryTitle=~draft", false},
+		{"EntryTitle==>", false},
+		{"EntryContent=x=y", false},
+		{`EntryTitle=(?i)"quoted" title`, false},
+		{`EntryTitle=="a"`, true},
+		{`EntryTitle=~"test"`, true},
+		{`EntryTitle=="a" and EntryAuthor=="b"`, true},
+		{`EntryTitle=~"a"||EntryTag=="b"`, true},
+		{`EntryTitle="a" or EntryAuthor="b"`, true},
+		{`EntryURL=example\.org!=`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if result := IsAmbiguousLegacyRule(tt.rule); result != tt.expected {
+				t.Errorf("IsAmbiguousLegacyRule(%q) = %v, expected %v", tt.rule, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExpressionEvaluation(t *testing.T) {
+	entry := createTestEntry()
+	entry.Date = time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	entry.ReadingTime = 7
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{`EntryTitle == "Test Entry Title"`, true},
+		{`EntryTitle == "test entry title"`, false},
+		{`EntryTitle != "Other"`, true},
+		{`EntryTitle =~ "(?i)^test"`, true},
+		{`EntryTitle !~ "Entry"`, false},
+		{`EntryURL =~ "example\.com"`, true},
+		{`EntryCommentsURL =~ "/comments$"`, true},
+		{`EntryContent =~ "test entry content"`, true},
+		{`EntryAuthor == 'Test Author'`, true},
+		{`EntryTag == "golang"`, true},
+		{`EntryTag == "python"`, false},
+		{`EntryTag != "python"`, true},
+		{`EntryTag != "golang"`, false},
+		{`EntryTag =~ "^test"`, true},
+		{`EntryTag !~ "^test"`, false},
+		{`EntryReadingTime == 7`, true},
+		{`EntryReadingTime >= 7`, true},
+		{`EntryReadingTime > 7`, false},
+		{`EntryReadingTime < 10.5`, true},
+		{`EntryReadingTime <= 6`, false},
+		{`EntryDate < "2024-06-16"`, true},
+		{`EntryDate > "2024-06-16"`, false},
+		{`EntryDate >= "2024-06-15"`, true},
+		{`EntryDate < "now"`, true},
+		{`EntryDate > "now-30d"`, false},
+		{`EntryDate < "now+1h"`, true},
+		{`EntryTitle =~ "Test" and EntryAuthor == "Test Author"`, true},
+		{`EntryTitle =~ "Test" and EntryAuthor == "Bot"`, false},
+		{`EntryTitle =~ "Test" AND NOT EntryAuthor == "Bot"`, true},
+		{`EntryTitle =~ "Nope" or EntryTag == "golang"`, true},
+		{`EntryTitle =~ "Nope" || EntryTag == "python"`, false},
+		{`EntryTitle =~ "Test" && !(EntryTag == "golang" || EntryTag == "python")`, false},
+		{`not not EntryTitle =~ "Test"`, true},
+		{`EntryTitle =~ "Nope" and EntryTag == "golang" or EntryAuthor =~ "Author"`, true},
+		{`EntryTitle =~ "Nope" and (EntryTag == "golang" or EntryAuthor =~ "Author")`, false},
+		{`EntryTitle == "Test \"Entry\" Title"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			node, err := parseExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("parseExpression(%q) returned an error: %v", tt.expression, err)
+			}
+			if result := node.evaluate(createTestFeed(), entry, readingSpeeds{}); result != tt.expected {
+				t.Errorf("%q evaluated to %v, expected %v", tt.expression, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExpressionReadingTimeIsEstimatedWhenMissing(t *testing.T) {
+	entry := createTestEntry()
+	entry.ReadingTime = 0
+	entry.Content = strings.Repeat("word ", 1000)
+
+	node, err := parseExpression("EntryReadingTime > 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !node.evaluate(createTestFeed(), entry, readingSpeeds{}) {
+		t.Error("Expected the reading time to be estimated from the content")
+	}
+}
+
+func TestExpressionStringEscapes(t *testing.T) {
+	entry := createTestEntry()
+	entry.Title = `He said "hi" \o/`
+
+	node, err := parseExpression(`EntryTitle == "He said \"hi\" \\o/"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !node.evaluate(createTestFeed(), entry, readingSpeeds{}) {
+		t.Error("Expected escaped quotes and backslashes to be unescaped")
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		column     int
+		message    string
+	}{
+		{`EntryTitle`, 11, "expected a comparison operator"},
+		{`EntryTitle ==`, 14, "expected a quoted string"},
+		{`EntryTitle == test`, 15, "expected a quoted string"},
+		{`EntryTitel == "test"`, 1, `unknown field "EntryTitel"`},
+		{`EntryTitle == "test`, 15, "unterminated string"},
+		{`EntryTitle =~ "[abc"`, 15, "invalid regex"},
+		{`EntryTitle > "test"`, 12, `operator ">" is not supported by EntryTitle`},
+		{`EntryReadingTime =~ "5"`, 18, `operator "=~" is not supported by EntryReadingTime`},
+		{`EntryReadingTime > "5"`, 20, "expected a number"},
+		{`EntryDate < "yesterday"`, 13, "invalid date"},
+		{`EntryDate == "2024-01-01"`, 11, `operator "==" is not supported by EntryDate`},
+		{`(EntryTitle == "a"`, 19, `expected ")"`},
+		{`EntryTitle == "a")`, 18, `unexpected ")"`},
+		{`EntryTitle == "a" and`, 22, "expected a field name"},
+		{`EntryTitle == "a" EntryURL == "b"`, 19, `unexpected "EntryURL"`},
+		{`EntryTitle = "a" & EntryURL == "b"`, 12, `unknown operator "="`},
+		{`EntryTitle == "a" # comment`, 19, "unexpected character"},
+		{`EntryTitle == "é" and`, 22, "expected a field name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			err := ValidateExpression(tt.expression)
+			if err == nil {
+				t.Fatalf("Expected an error for %q", tt.expression)
+			}
+
+			var expressionErr *ExpressionError
+			if !errors.As(err, &expressionErr) {
+				t.Fatalf("Expected an *ExpressionError, got %T", err)
+			}
+
+			if expressionErr.Column != tt.column {
+				t.Errorf("Expected error at column %d, got %d (%v)", tt.column, expressionErr.Column, err)
+			}
+
+			if !strings.Contains(expressionErr.Message, tt.message) {
+				t.Errorf("Expected error message to contain %q, got %q", tt.message, expressionErr.Message)
+			}
+		})
+	}
+}
+
+func TestParseRulesWithExpressions(t *testing.T) {
+	rules := ParseRules(
+		"EntryTitle=legacy\nEntryTitle =~ \"(?i)expr\" and EntryAuthor != \"Bot\"\nEntryTitle == \"unterminated",
+		"(EntryTag == \"a\" or EntryTag == \"b\")",
+	)
+
+	if len(rules) != 3 {
+		t.Fatalf("ParseRules() returned %d rules, expected 3", len(rules))
+	}
+
+	if rules[0].Type != "EntryTitle" || rules[0].expression != nil {
+		t.Errorf("Expected a legacy rule, got %+v", rules[0])
+	}
+
+	if rules[1].Type != expressionRuleType || rules[1].expression == nil {
+		t.Errorf("Expected an expression rule, got %+v", rules[1])
+	}
+
+	if rules[2].Value != `(EntryTag == "a" or EntryTag == "b")` {
+		t.Errorf("Unexpected rule value: %q", rules[2].Value)
+	}
+}
+
+func TestIsBlockedEntryWithExpressions(t *testing.T) {
+	feed := createTestFeed()
+
+	entry := createTestEntry()
+	entry.Author = "Bot"
+
+	blockRules := ParseRules(`EntryTitle =~ "Test" and EntryAuthor == "Bot"`, "")
+	if !IsBlockedEntry(blockRules, filterRules{}, feed, entry) {
+		t.Error("Expected the entry to be blocked by the expression rule")
+	}
+
+	entry.Author = "Human"
+	if IsBlockedEntry(blockRules, filterRules{}, feed, entry) {
+		t.Error("Expected the entry not to be blocked by the expression rule")
+	}
+
+	allowRules := ParseRules("", `EntryTag == "python" or EntryAuthor == "Human"`)
+	if IsBlockedEntry(filterRules{}, allowRules, feed, entry) {
+		t.Error("Expected the entry to be allowed by the expression rule")
+	}
+
+	entry.Author = "Bot"
+	if !IsBlockedEntry(filterRules{}, allowRules, feed, entry) {
+		t.Error("Expected the entry not to be allowed by the expression rule")
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "miniflux.app/v2/internal/reader/filter"
+
+import (
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/readingtime"
+)
+
+// Reading speeds used when the user has not set them, they are the defaults of the user settings.
+const (
+	defaultReadingSpeed    = 265
+	defaultCJKReadingSpeed = 500
+)
+
+// readingSpeeds are the reading speeds of the user in words per minute, used to estimate the reading time.
+type readingSpeeds struct {
+	defaultSpeed int
+	cjkSpeed     int
+}
+
+// entryReadingTime returns the reading time in minutes.
+// Filters run before the processor computes the reading time, so it is estimated from the content with the
+// reading speeds of the user when missing, like the reading time of the stored entries.
+func entryReadingTime(entry *model.Entry, speeds readingSpeeds) float64 {
+	if entry.ReadingTime > 0 {
+		return float64(entry.ReadingTime)
+	}
+
+	defaultSpeed, cjkSpeed := speeds.defaultSpeed, speeds.cjkSpeed
+	if defaultSpeed <= 0 {
+		defaultSpeed = defaultReadingSpeed
+	}
+	if cjkSpeed <= 0 {
+		cjkSpeed = defaultCJKReadingSpeed
+	}
+	return float64(readingtime.EstimateReadingTime(entry.Content, defaultSpeed, cjkSpeed))
+}
+
+func feedTitle(feed *model.Feed) string {
+	if feed == nil {
+		return ""
+	}
+	return feed.Title
+}
+
+func feedURL(feed *model.Feed) string {
+	if feed == nil {
+		return ""
+	}
+	return feed.FeedURL
+}
+
+func categoryTitle(feed *model.Feed) string {
+	if feed == nil || feed.Category == nil {
+		return ""
+	}
+	return feed.Category.Title
+}
+
+func enclosureMimeTypes(entry *model.Entry) []string {
+	mimeTypes := make([]string, 0, len(entry.Enclosures))
+	for _, enclosure := range entry.Enclosures {
+		mimeTypes = append(mimeTypes, enclosure.MimeType)
+	}
+	return mimeTypes
+}
+
+func enclosureURLs(entry *model.Entry) []string {
+	urls := make([]string, 0, len(entry.Enclosures))
+	for _, enclosure := range entry.Enclosures {
+		urls = append(urls, enclosure.URL)
+	}
+	return urls
+}
+
+// isNumberMatchingPattern compares a number with a pattern like "10", ">10", ">=10", "<5" or "<=5".
+func isNumberMatchingPattern(pattern string, value float64) bool {
+	pattern = strings.TrimSpace(pattern)
+
+	operator := "=="
+	for _, candidate := range []string{">=", "<=", "==", "!=", ">", "<"} {
+		if remaining, found := strings.CutPrefix(pattern, candidate); found {
+			operator, pattern = candidate, strings.TrimSpace(remaining)
+			break
+		}
+	}
+
+	target, err := strconv.ParseFloat(pattern, 64)
+	if err != nil {
+		return false
+	}
+
+	return compareOrdered(value, operator, target)
+}
@@ -11,15 +11,23 @@
 // Rules are processed in this order:
 //
 // 1. User block filter rules
-// 2. Feed block filter rules
-// 3. User keep filter rules
-// 4. Feed keep filter rules
+// 2. Category block filter rules
+// 3. Feed block filter rules
+// 4. User keep filter rules
+// 5. Category keep filter rules
+// 6. Feed keep filter rules
 //
 // Each rule must be on a separate line.
+// A rule is either a "FieldName=RegEx" pair or a boolean expression (see expression.go),
+// for example: EntryTitle =~ "(?i)golang" and not EntryAuthor == "Bot".
+// Rules can match entry fields (EntryTitle, EntryURL, EntryCommentsURL, EntryContent, EntryAuthor, EntryTag,
+// EntryDate, EntryLanguage, EntryReadingTime), feed fields (FeedTitle, FeedURL, CategoryTitle)
+// and enclosure fields (EnclosureMimeType, EnclosureURL).
+// EntryReadingTime expects a number of minutes, optionally prefixed by an operator: EntryReadingTime=>10.
 // Duplicate rules are allowed. For example, having multiple EntryTitle rules is possible.
 // The provided regex should use the RE2 syntax.
 // The order of the rules matters as the processor stops on the first match for both Block and Keep rules.
-// Invalid rules are ignored.
+// Invalid rules are rejected when saved and ignored during processing.
 
 package filter // import "miniflux.app/v2/internal/reader/filter"
 
@@ -38,20 +46,39 @@ import (
 type filterRule struct {
 	Type  string
 	Value string
+
+	expression expressionNode
+	speeds     readingSpeeds
+}
+
+// String returns the rule as written by the user.
+func (r filterRule) String() string {
+	if r.expression != nil {
+		return r.Value
+	}
+	return r.Type + "=" + r.Value
 }
 
 type filterRules []filterRule
 
-func ParseRules(userRules, feedRules string) filterRules {
-	rules := make(filterRules, 0)
-	for line := range strings.SplitSeq(strings.TrimSpace(userRules), "\n") {
-		if valid, filterRule := parseRule(line); valid {
-			rules = append(rules, filterRule)
-		}
+// WithReadingSpeeds sets the reading speeds of the user, used to estimate the reading time of the entries
+// for the EntryReadingTime rules.
+func (rules filterRules) WithReadingSpeeds(defaultSpeed, cjkSpeed int) filterRules {
+	for i := range rules {
+		rules[i].speeds = readingSpeeds{defaultSpeed: defaultSpeed, cjkSpeed: cjkSpeed}
 	}
-	for line := range strings.SplitSeq(strings.TrimSpace(feedRules), "\n") {
-		if valid, filterRule := parseRule(line); valid {
-			rules = append(rules, filterRule)
+	return rules
+}
+
+// ParseRules parses the rules of each level, from the most general to the most specific:
+// user rules, category rules and feed rules.
+func ParseRules(rulesByLevel ...string) filterRules {
+	rules := make(filterRules, 0)
+	for _, levelRules := range rulesByLevel {
+		for line := range strings.SplitSeq(strings.TrimSpace(levelRules), "\n") {
+			if valid, filterRule := parseRule(line); valid {
+				rules = append(rules, filterRule)
+			}
 		}
 	}
 	return rules
@@ -59,6 +86,19 @@ func ParseRules(userRules, feedRules string) filterRules {
 
 func parseRule(userDefinedRule string) (bool, filterRule) {
 	userDefinedRule = strings.TrimSpace(strings.ReplaceAll(userDefinedRule, "\r\n", ""))
+
+	if IsExpressionRule(userDefinedRule) {
+		expression, err := parseExpression(userDefinedRule)
+		if err != nil {
+			slog.Debug("Ignoring invalid filter expression",
+				slog.String("rule", userDefinedRule),
+				slog.Any("error", err),
+			)
+			return false, filterRule{}
+		}
+		return true, filterRule{Type: expressionRuleType, Value: userDefinedRule, expression: expression}
+	}
+
 	parts := strings.SplitN(userDefinedRule, "=", 2)
 	if len(parts) != 2 {
 		return false, filterRule{}
@@ -69,36 +109,63 @@ func parseRule(userDefinedRule string) (bool, filterRule) {
 	}
 }
 
+// Reasons reported by EvaluateEntry.
+const (
+	ReasonMaxAge               = "max_age"
+	ReasonBlockRule            = "block_rule"
+	ReasonBlocklistRules       = "blocklist_rules"
+	ReasonKeepRule             = "keep_rule"
+	ReasonNoKeepRuleMatch      = "no_keep_rule_match"
+	ReasonKeeplistRules        = "keeplist_rules"
+	ReasonNoKeeplistRulesMatch = "no_keeplist_rules_match"
+	ReasonNoRuleMatch          = "no_rule_match"
+)
+
+// Result describes whether an entry is blocked and why.
+// Rule is the filter rule or regex that made the decision, if any.
+type Result struct {
+	Blocked bool
+	Reason  string
+	Rule    string
+}
+
 func IsBlockedEntry(blockRules filterRules, allowRules filterRules, feed *model.Feed, entry *model.Entry) bool {
+	return EvaluateEntry(blockRules, allowRules, feed, entry).Blocked
+}
+
+// EvaluateEntry applies the filter rules to the entry and returns the decision with the rule that made it.
+func EvaluateEntry(blockRules filterRules, allowRules filterRules, feed *model.Feed, entry *model.Entry) Result {
 	if isBlockedGlobally(entry) {
-		return true
+		return Result{Blocked: true, Reason: ReasonMaxAge}
 	}
 
-	if matchesEntryFilterRules(blockRules, feed, entry) {
-		return true
+	if rule, matches := matchingEntryFilterRule(blockRules, feed, entry); matches {
+		return Result{Blocked: true, Reason: ReasonBlockRule, Rule: rule.String()}
 	}
 
 	if matches, valid := matchesEntryRegexRules(feed.BlocklistRules, feed, entry); valid && matches {
-		return true
+		return Result{Blocked: true, Reason: ReasonBlocklistRules, Rule: feed.BlocklistRules}
 	}
 
 	// If allow rules exist, only entries that match them should be retained
 	if len(allowRules) > 0 {
-		if !matchesEntryFilterRules(allowRules, feed, entry) {
-			return true // Block entry if it doesn't match any allow rules
+		if rule, matches := matchingEntryFilterRule(allowRules, feed, entry); matches {
+			return Result{Reason: ReasonKeepRule, Rule: rule.String()} // Allow entry if it matches allow rules
 		}
-		return false // Allow entry if it matches allow rules
+		return Result{Blocked: true, Reason: ReasonNoKeepRuleMatch} // Block entry if it doesn't match any allow rules
 	}
 
 	// If keeplist rules exist, only entries that match them should be retained
 	if feed.KeeplistRules != "" {
 		if matches, valid := matchesEntryRegexRules(feed.KeeplistRules, feed, entry); valid && !matches {
-			return true // Block entry if it doesn't match keeplist rules
+			return Result{Blocked: true, Reason: ReasonNoKeeplistRulesMatch, Rule: feed.KeeplistRules} // Block entry if it doesn't match keeplist rules
+		} else if valid {
+			return Result{Reason: ReasonKeeplistRules, Rule: feed.KeeplistRules} // Allow entry if it matches keeplist rules
 		}
-		return false // Allow entry if it matches keeplist rules or rule is invalid (ignored)
+		return Result{Reason: ReasonNoRuleMatch} // Invalid keeplist rules are ignored
 	}
 
-	return false
+	return Result{Reason: ReasonNoRuleMatch}
 }
 
 func isBlockedGlobally(entry *model.Entry) bool {
@@ -159,8 +226,13 @@ func matchesEntryRegexRules(regexPattern string, feed *model.Feed, entry *model.
 }
 
 func matchesEntryFilterRules(rules filterRules, feed *model.Feed, entry *model.Entry) bool {
+	_, matches := matchingEntryFilterRule(rules, feed, entry)
+	return matches
+}
+
+func matchingEntryFilterRule(rules filterRules, feed *model.Feed, entry *model.Entry) (filterRule, bool) {
 	for _, rule := range rules {
-		if matchesRule(rule, entry) {
+		if matchesRule(rule, feed, entry) {
 			slog.Debug("Entry matches filter rule",
 				slog.String("entry_url", entry.URL),
 				slog.String("entry_title", entry.Title),
@@ -169,13 +241,17 @@ func matchesEntryFilterRules(rules filterRules, feed *model.Feed, entry *model.E
 				slog.String("rule_type", rule.Type),
 				slog.String("rule_value", rule.Value),
 			)
-			return true
+			return rule, true
 		}
 	}
-	return false
+	return filterRule{}, false
 }
 
-func matchesRule(rule filterRule, entry *model.Entry) bool {
+func matchesRule(rule filterRule, feed *model.Feed, entry *model.Entry) bool {
+	if rule.expression != nil {
+		return rule.expression.evaluate(feed, entry, rule.speeds)
+	}
+
 	switch rule.Type {
 	case "EntryDate":
 		return isDateMatchingPattern(rule.Value, entry.Date)
@@ -196,6 +272,24 @@ func matchesRule(rule filterRule, entry *model.Entry) bool {
 		return match
 	case "EntryTag":
 		return containsRegexPattern(rule.Value, entry.Tags)
+	case "EntryLanguage":
+		match, _ := regexp.MatchString(rule.Value, entry.Language)
+		return match
+	case "EntryReadingTime":
+		return isNumberMatchingPattern(rule.Value, entryReadingTime(entry, rule.speeds))
+	case "FeedTitle":
+		match, _ := regexp.MatchString(rule.Value, feedTitle(feed))
+		return match
+	case "FeedURL":
+		match, _ := regexp.MatchString(rule.Value, feedURL(feed))
+		return match
+	case "CategoryTitle":
+		match, _ := regexp.MatchString(rule.Value, categoryTitle(feed))
+		return match
+	case "EnclosureMimeType":
+		return containsRegexPattern(rule.Value, enclosureMimeTypes(entry))
+	case "EnclosureURL":
+		return containsRegexPattern(rule.Value, enclosureURLs(entry))
 	}
 
 	return false
@@ -5,6 +5,7 @@ package filter // import "miniflux.app/v2/internal/reader/filter"
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -153,6 +154,18 @@ func TestParseRule(t *testing.T) {
 			valid:    true,
 			expected: filterRule{Type: "EntryContent", Value: "x=y"},
 		},
+		{
+			name:     "legacy rule with a regex starting with a tilde",
+			rule:     "EntryTitle=~draft",
+			valid:    true,
+			expected: filterRule{Type: "EntryTitle", Value: "~draft"},
+		},
+		{
+			name:     "legacy rule with a regex starting with an equals sign",
+			rule:     "EntryTitle==>",
+			valid:    true,
+			expected: filterRule{Type: "EntryTitle", Value: "=>"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -671,7 +684,7 @@ func TestMatchesRule(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := matchesRule(tt.rule, tt.entry)
+			result := matchesRule(tt.rule, createTestFeed(), tt.entry)
 			if result != tt.expected {
 				t.Errorf("matchesRule() = %v, expected %v", result, tt.expected)
 			}
@@ -1053,7 +1066,7 @@ func TestMatchesRuleWithInvalidRegex(t *testing.T) {
 
 	// Test invalid regex patterns
 	rule := filterRule{Type: "EntryTitle", Value: "["}
-	result := matchesRule(rule, entry)
+	result := matchesRule(rule, createTestFeed(), entry)
 	if result {
 		t.Errorf("matchesRule() should return false for invalid regex")
 	}
@@ -1121,6 +1134,75 @@ func TestComplexFilterScenarios(t *testing.T) {
 	}
 }
 
+func TestEvaluateEntry(t *testing.T) {
+	tests := []struct {
+		name           string
+		blockRules     string
+		keepRules      string
+		blocklistRules string
+		keeplistRules  string
+		expected       Result
+	}{
+		{
+			name:     "no rules",
+			expected: Result{Reason: ReasonNoRuleMatch},
+		},
+		{
+			name:       "second block rule matches",
+			blockRules: "EntryTitle=NonMatching\nEntryAuthor=Test",
+			expected:   Result{Blocked: true, Reason: ReasonBlockRule, Rule: "EntryAuthor=Test"},
+		},
+		{
+			name:       "block expression matches",
+			blockRules: `EntryTag == "golang"`,
+			expected:   Result{Blocked: true, Reason: ReasonBlockRule, Rule: `EntryTag == "golang"`},
+		},
+		{
+			name:           "blocklist regex matches",
+			blocklistRules: "(?i)test",
+			expected:       Result{Blocked: true, Reason: ReasonBlocklistRules, Rule: "(?i)test"},
+		},
+		{
+			name:      "keep rule matches",
+			keepRules: "EntryURL=example",
+			expected:  Result{Reason: ReasonKeepRule, Rule: "EntryURL=example"},
+		},
+		{
+			name:      "no keep rule matches",
+			keepRules: "EntryURL=nonmatching",
+			expected:  Result{Blocked: true, Reason: ReasonNoKeepRuleMatch},
+		},
+		{
+			name:          "keeplist regex matches",
+			keeplistRules: "golang",
+			expected:      Result{Reason: ReasonKeeplistRules, Rule: "golang"},
+		},
+		{
+			name:          "keeplist regex does not match",
+			keeplistRules: "python",
+			expected:      Result{Blocked: true, Reason: ReasonNoKeeplistRulesMatch, Rule: "python"},
+		},
+		{
+			name:          "invalid keeplist regex is ignored",
+			keeplistRules: "[invalid",
+			expected:      Result{Reason: ReasonNoRuleMatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := createTestFeed()
+			feed.BlocklistRules = tt.blocklistRules
+			feed.KeeplistRules = tt.keeplistRules
+
+			result := EvaluateEntry(ParseRules("", tt.blockRules), ParseRules("", tt.keepRules), feed, createTestEntry())
+			if result != tt.expected {
+				t.Errorf("EvaluateEntry() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestFilterRulesWithSpecialCharacters(t *testing.T) {
 	entry := &model.Entry{
 		Title:   "Test [Special] (Characters) & Symbols!",
@@ -1174,7 +1256,7 @@ func TestFilterRulesWithSpecialCharacters(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := matchesRule(tt.rule, entry)
+			result := matchesRule(tt.rule, createTestFeed(), entry)
 			if result != tt.expected {
 				t.Errorf("matchesRule() with special characters = %v, expected %v", result, tt.expected)
 			}
@@ -1227,7 +1309,7 @@ func TestEntryWithEmptyFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := matchesRule(tt.rule, entry)
+			result := matchesRule(tt.rule, createTestFeed(), entry)
 			if result != tt.expected {
 				t.Errorf("matchesRule() with empty fields = %v, expected %v", result, tt.expected)
 			}
@@ -1410,6 +1492,100 @@ EntryTag=tag2`
 	}
 }
 
+func TestMatchesRuleWithFeedAndEnclosureFields(t *testing.T) {
+	feed := createTestFeed()
+	feed.Title = "Example Podcast"
+	feed.Category = &model.Category{Title: "Audio"}
+
+	entry := createTestEntry()
+	entry.Language = "fr-FR"
+	entry.ReadingTime = 12
+	entry.Enclosures = model.EnclosureList{
+		{URL: "https://cdn.example.com/episode.mp3", MimeType: "audio/mpeg"},
+		{URL: "https://cdn.example.com/cover.jpg", MimeType: "image/jpeg"},
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		expected bool
+	}{
+		{"FeedTitle match", "FeedTitle=(?i)podcast", true},
+		{"FeedTitle no match", "FeedTitle=(?i)newsletter", false},
+		{"FeedURL match", `FeedURL=^https://example\.com/`, true},
+		{"CategoryTitle match", "CategoryTitle=^Audio$", true},
+		{"CategoryTitle no match", "CategoryTitle=^Video$", false},
+		{"EnclosureMimeType match", "EnclosureMimeType=^audio/", true},
+		{"EnclosureMimeType no match", "EnclosureMimeType=^video/", false},
+		{"EnclosureURL match", `EnclosureURL=\.mp3$`, true},
+		{"EnclosureURL no match", `EnclosureURL=\.ogg$`, false},
+		{"EntryLanguage match", "EntryLanguage=^fr", true},
+		{"EntryLanguage no match", "EntryLanguage=^en", false},
+		{"EntryReadingTime exact", "EntryReadingTime=12", true},
+		{"EntryReadingTime greater", "EntryReadingTime=>10", true},
+		{"EntryReadingTime greater or equal", "EntryReadingTime=>=12", true},
+		{"EntryReadingTime lower", "EntryReadingTime=<10", false},
+		{"EntryReadingTime invalid number", "EntryReadingTime=>ten", false},
+		{"expression on feed fields", `FeedTitle =~ "Podcast" and CategoryTitle == "Audio"`, true},
+		{"expression on enclosure types", `EnclosureMimeType == "image/jpeg" and not EnclosureMimeType =~ "^video/"`, true},
+		{"expression on language", `EntryLanguage =~ "^fr" and EntryReadingTime > 10`, true},
+		{"expression on category", `CategoryTitle != "Audio"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, rule := parseRule(tt.rule)
+			if !valid {
+				t.Fatalf("parseRule(%q) should be valid", tt.rule)
+			}
+			if result := matchesRule(rule, feed, entry); result != tt.expected {
+				t.Errorf("matchesRule(%q) = %v, expected %v", tt.rule, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchesRuleWithoutCategory(t *testing.T) {
+	feed := createTestFeed()
+	entry := createTestEntry()
+
+	valid, rule := parseRule("CategoryTitle=.*")
+	if !valid {
+		t.Fatal("parseRule() should be valid")
+	}
+
+	if !matchesRule(rule, feed, entry) {
+		t.Error("A feed without category should be matched as an empty category title")
+	}
+
+	if matchesRule(filterRule{Type: "EnclosureMimeType", Value: ".*"}, feed, entry) {
+		t.Error("An entry without enclosures should not match enclosure rules")
+	}
+}
+
+func TestEntryReadingTimeRulesUseReadingSpeeds(t *testing.T) {
+	feed := createTestFeed()
+	entry := createTestEntry()
+	entry.ReadingTime = 0
+	entry.Content = strings.Repeat("word ", 1000)
+
+	// 1000 words are read in 4 minutes at the default speed and in 10 minutes at 100 words per minute.
+	for _, rules := range []string{"EntryReadingTime=>=10", "EntryReadingTime >= 10"} {
+		if matchesEntryFilterRules(ParseRules(rules), feed, entry) {
+			t.Errorf(`%q should not match at the default reading speed`, rules)
+		}
+
+		if !matchesEntryFilterRules(ParseRules(rules).WithReadingSpeeds(100, 100), feed, entry) {
+			t.Errorf(`%q should match at the reading speed of the user`, rules)
+		}
+
+		actions := MatchingActions(ParseActionRules("Star: "+rules, "").WithReadingSpeeds(100, 100), feed, entry)
+		if len(actions) != 1 {
+			t.Errorf(`The action rule %q should match at the reading speed of the user`, rules)
+		}
+	}
+}
+
 func BenchmarkIsBlockedEntry(b *testing.B) {
 	entry := createTestEntry()
 	feed := createTestFeed()
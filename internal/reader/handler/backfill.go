@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+)
+
+// enqueueFeedBackfill adds a job walking the archive pages of a new feed, the walk can take a while
+// so it's done in the background by the workers.
+func enqueueFeedBackfill(store *storage.Storage, feed *model.Feed) {
+	if feed.ArchivePageURL == "" {
+		slog.Debug("Feed history not backfilled, the feed doesn't link to an archive page",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+		)
+		return
+	}
+
+	if err := store.EnqueueBackfillJob(feed.UserID, feed.ID, feed.ArchivePageURL); err != nil {
+		slog.Error("Unable to enqueue the backfill of the feed history",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// BackfillFeed stores the entries of the archive pages of a feed, starting with the given page.
+// The walk stops when the context is canceled.
+func BackfillFeed(ctx context.Context, store *storage.Storage, userID, feedID int64, archivePageURL string) *locale.LocalizedErrorWrapper {
+	feed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if feed == nil {
+		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithContext(ctx)
+	requestBuilder.WithUsernameAndPassword(feed.Username, feed.Password)
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+	requestBuilder.WithCustomHeaders(feed.CustomHeaders)
+	requestBuilder.WithClientCertificate(feed.ClientCertificate, feed.ClientPrivateKey)
+
+	backfillFeedHistory(ctx, store, feed, requestBuilder, archivePageURL)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return locale.NewLocalizedErrorWrapper(ctxErr, "error.network_operation", ctxErr)
+	}
+	return nil
+}
+
+// backfillFeedHistory walks the archive pages of a paged or archived feed (RFC 5005)
+// to store the entries that are no longer in the feed document.
+// The walk stops at the first error, or when the page or entry limit is reached.
+func backfillFeedHistory(ctx context.Context, store *storage.Storage, feed *model.Feed, requestBuilder *fetcher.RequestBuilder, archivePageURL string) {
+	maxPages := config.Opts.BackfillMaxPages()
+	maxEntries := config.Opts.BackfillMaxEntries()
+
+	// The entries of the feed are replaced by the ones of each archive page during the walk.
+	originalEntries := feed.Entries
+	defer func() {
+		feed.Entries = originalEntries
+	}()
+
+	visitedPages := map[string]bool{feed.FeedURL: true}
+	nbPages, nbEntries, nbNewEntries := 0, 0, 0
+
+	for archivePageURL != "" && !visitedPages[archivePageURL] && nbPages < maxPages && nbEntries < maxEntries {
+		visitedPages[archivePageURL] = true
+		nbPages++
+
+		archivePage, localizedError := fetchArchivePage(requestBuilder, archivePageURL)
+		if localizedError != nil {
+			slog.Warn("Unable to fetch feed archive page",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("archive_page_url", archivePageURL),
+				slog.Any("error", localizedError.Error()),
+			)
+			break
+		}
+
+		entries := archivePage.Entries
+		if len(entries) > maxEntries-nbEntries {
+			entries = entries[:maxEntries-nbEntries]
+		}
+		nbEntries += len(entries)
+
+		feed.Entries = entries
+		processor.ProcessFeedEntries(ctx, store, feed, feed.UserID, true)
+		if ctx.Err() != nil {
+			break
+		}
+
+		newEntries, storeErr := store.StorePartialFeedEntries(feed.UserID, feed.ID, feed.Entries, false)
+		if storeErr != nil {
+			slog.Error("Unable to store feed archive entries",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("archive_page_url", archivePageURL),
+				slog.Any("error", storeErr),
+			)
+			break
+		}
+		nbNewEntries += len(newEntries)
+
+		archivePageURL = archivePage.ArchivePageURL
+	}
+
+	slog.Info("Feed history backfilled",
+		slog.Int64("user_id", feed.UserID),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int("nb_pages", nbPages),
+		slog.Int("nb_entries", nbEntries),
+		slog.Int("nb_new_entries", nbNewEntries),
+	)
+}
+
+func fetchArchivePage(requestBuilder *fetcher.RequestBuilder, archivePageURL string) (*model.Feed, *locale.LocalizedErrorWrapper) {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(archivePageURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil, localizedError
+	}
+
+	archivePage, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+	if parseErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
+	}
+
+	return archivePage, nil
+}
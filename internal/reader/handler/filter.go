@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/storage"
+)
+
+const filterBatchSize = 500
+
+// ApplyFilterRules evaluates the user and feed filter rules against the existing unread entries
+// and changes the status of the blocked entries. Starred entries are left untouched.
+// All feeds of the user are processed when feedID is 0.
+// It returns the number of entries affected.
+func ApplyFilterRules(store *storage.Storage, userID, feedID int64, status string) (int, error) {
+	user, err := store.UserByID(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if user == nil {
+		return 0, nil
+	}
+
+	var feeds model.Feeds
+	if feedID > 0 {
+		feed, err := store.FeedByID(userID, feedID)
+		if err != nil {
+			return 0, err
+		}
+
+		if feed == nil {
+			return 0, ErrFeedNotFound
+		}
+
+		feeds = model.Feeds{feed}
+	} else {
+		feeds, err = store.Feeds(userID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	affected := 0
+	for _, feed := range feeds {
+		count, err := applyFeedFilterRules(store, user, feed, status)
+		affected += count
+		if err != nil {
+			return affected, err
+		}
+	}
+
+	slog.Info("Applied filter rules to existing entries",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feedID),
+		slog.String("status", status),
+		slog.Int("entries_affected", affected),
+	)
+
+	return affected, nil
+}
+
+func applyFeedFilterRules(store *storage.Storage, user *model.User, feed *model.Feed, status string) (int, error) {
+	blockRules := filter.ParseRules(user.BlockFilterEntryRules, feed.EffectiveBlockFilterEntryRules()).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
+	keepRules := filter.ParseRules(user.KeepFilterEntryRules, feed.EffectiveKeepFilterEntryRules()).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
+
+	if len(blockRules) == 0 && len(keepRules) == 0 && feed.BlocklistRules == "" && feed.KeeplistRules == "" {
+		return 0, nil
+	}
+
+	affected := 0
+	var lastEntryID int64
+	for {
+		builder := store.NewEntryQueryBuilder(user.ID)
+		builder.WithFeedID(feed.ID)
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.WithStarred(false)
+		builder.AfterEntryID(lastEntryID)
+		builder.WithSorting("e.id", "asc")
+		builder.WithLimit(filterBatchSize)
+		builder.WithEnclosures()
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return affected, err
+		}
+
+		if len(entries) == 0 {
+			return affected, nil
+		}
+
+		var blockedEntryIDs []int64
+		for _, entry := range entries {
+			result := filter.EvaluateEntry(blockRules, keepRules, feed, entry)
+
+			// The global maximum age only applies to new entries, it is not a user rule.
+			if result.Blocked && result.Reason != filter.ReasonMaxAge {
+				blockedEntryIDs = append(blockedEntryIDs, entry.ID)
+			}
+		}
+
+		if len(blockedEntryIDs) > 0 {
+			if err := store.SetEntriesStatus(user.ID, blockedEntryIDs, status); err != nil {
+				return affected, err
+			}
+			affected += len(blockedEntryIDs)
+		}
+
+		if len(entries) < filterBatchSize {
+			return affected, nil
+		}
+
+		lastEntryID = entries[len(entries)-1].ID
+	}
+}
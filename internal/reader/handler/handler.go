@@ -5,6 +5,7 @@ package handler // import "miniflux.app/v2/internal/reader/handler"
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"log/slog"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"miniflux.app/v2/internal/reader/icon"
 	"miniflux.app/v2/internal/reader/parser"
 	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/webpage"
 	"miniflux.app/v2/internal/storage"
 )
 
@@ -25,6 +27,7 @@ var (
 	ErrCategoryNotFound = errors.New("fetcher: category not found")
 	ErrFeedNotFound     = errors.New("fetcher: feed not found")
 	ErrDuplicatedFeed   = errors.New("fetcher: duplicated feed")
+	ErrFeedGone         = errors.New("fetcher: feed gone (410 status code)")
 )
 
 func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, feedCreationRequest *model.FeedCreationRequestFromSubscriptionDiscovery) (*model.Feed, *locale.LocalizedErrorWrapper) {
@@ -34,7 +37,13 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		slog.String("proxy_url", feedCreationRequest.ProxyURL),
 	)
 
-	if !store.CategoryIDExists(userID, feedCreationRequest.CategoryID) {
+	// The category is loaded to apply its rules to the entries of the new feed.
+	category, storeErr := store.Category(userID, feedCreationRequest.CategoryID)
+	if storeErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if category == nil {
 		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
 	}
 
@@ -42,7 +51,22 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(feedCreationRequest.FeedURL, feedCreationRequest.Content)
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
+	requestBuilder.WithUserAgent(feedCreationRequest.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feedCreationRequest.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
+	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feedCreationRequest.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feedCreationRequest.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feedCreationRequest.DisableHTTP2)
+	requestBuilder.WithCustomHeaders(feedCreationRequest.CustomHeaders)
+	requestBuilder.WithClientCertificate(feedCreationRequest.ClientCertificate, feedCreationRequest.ClientPrivateKey)
+
+	subscription, parseErr := parseFeed(requestBuilder, feedCreationRequest.FeedURL, feedCreationRequest.Content)
 	if parseErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
@@ -52,7 +76,8 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 	subscription.Cookie = feedCreationRequest.Cookie
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
-	subscription.Crawler = feedCreationRequest.Crawler
+	// The sitemaps only list the pages, their content is fetched by the crawler.
+	subscription.Crawler = feedCreationRequest.Crawler || isSitemap(feedCreationRequest.Content)
 	subscription.Disabled = feedCreationRequest.Disabled
 	subscription.IgnoreHTTPCache = feedCreationRequest.IgnoreHTTPCache
 	subscription.AllowSelfSignedCertificates = feedCreationRequest.AllowSelfSignedCertificates
@@ -64,15 +89,20 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 	subscription.UrlRewriteRules = feedCreationRequest.UrlRewriteRules
 	subscription.BlockFilterEntryRules = feedCreationRequest.BlockFilterEntryRules
 	subscription.KeepFilterEntryRules = feedCreationRequest.KeepFilterEntryRules
+	subscription.EntryActionRules = feedCreationRequest.EntryActionRules
 	subscription.EtagHeader = feedCreationRequest.ETag
 	subscription.LastModifiedHeader = feedCreationRequest.LastModified
 	subscription.FeedURL = feedCreationRequest.FeedURL
 	subscription.DisableHTTP2 = feedCreationRequest.DisableHTTP2
-	subscription.WithCategoryID(feedCreationRequest.CategoryID)
+	subscription.Category = category
 	subscription.ProxyURL = feedCreationRequest.ProxyURL
+	subscription.IgnoreSkipHints = feedCreationRequest.IgnoreSkipHints
+	subscription.CustomHeaders = feedCreationRequest.CustomHeaders
+	subscription.ClientCertificate = feedCreationRequest.ClientCertificate
+	subscription.ClientPrivateKey = feedCreationRequest.ClientPrivateKey
 	subscription.CheckedNow()
 
-	processor.ProcessFeedEntries(store, subscription, userID, true)
+	processor.ProcessFeedEntries(context.Background(), store, subscription, userID, true)
 
 	if storeErr := store.CreateFeed(subscription); storeErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
@@ -84,20 +114,12 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		slog.String("feed_url", subscription.FeedURL),
 	)
 
-	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
-	requestBuilder.WithUserAgent(feedCreationRequest.UserAgent, config.Opts.HTTPClientUserAgent())
-	requestBuilder.WithCookie(feedCreationRequest.Cookie)
-	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
-	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
-	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(feedCreationRequest.FetchViaProxy)
-	requestBuilder.IgnoreTLSErrors(feedCreationRequest.AllowSelfSignedCertificates)
-	requestBuilder.DisableHTTP2(feedCreationRequest.DisableHTTP2)
-
 	icon.NewIconChecker(store, subscription).UpdateOrCreateFeedIcon()
 
+	if feedCreationRequest.BackfillHistory && !subscription.IsWebPageFeed() {
+		enqueueFeedBackfill(store, subscription)
+	}
+
 	return subscription, nil
 }
 
@@ -109,7 +131,13 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		slog.String("proxy_url", feedCreationRequest.ProxyURL),
 	)
 
-	if !store.CategoryIDExists(userID, feedCreationRequest.CategoryID) {
+	// The category is loaded to apply its rules to the entries of the new feed.
+	category, storeErr := store.Category(userID, feedCreationRequest.CategoryID)
+	if storeErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if category == nil {
 		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
 	}
 
@@ -119,11 +147,14 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	requestBuilder.WithCookie(feedCreationRequest.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
 	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
 	requestBuilder.UseCustomApplicationProxyURL(feedCreationRequest.FetchViaProxy)
 	requestBuilder.IgnoreTLSErrors(feedCreationRequest.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(feedCreationRequest.DisableHTTP2)
+	requestBuilder.WithCustomHeaders(feedCreationRequest.CustomHeaders)
+	requestBuilder.WithClientCertificate(feedCreationRequest.ClientCertificate, feedCreationRequest.ClientPrivateKey)
 
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(feedCreationRequest.FeedURL))
 	defer responseHandler.Close()
@@ -143,7 +174,19 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+	var subscription *model.Feed
+	var parseErr error
+	if feedCreationRequest.WebPageItemSelector != "" {
+		subscription, parseErr = webpage.Parse(responseHandler.EffectiveURL(), responseHandler.ContentType(), bytes.NewReader(responseBody), &webpage.Selectors{
+			Item:    feedCreationRequest.WebPageItemSelector,
+			Title:   feedCreationRequest.WebPageTitleSelector,
+			Link:    feedCreationRequest.WebPageLinkSelector,
+			Date:    feedCreationRequest.WebPageDateSelector,
+			Content: feedCreationRequest.WebPageContentSelector,
+		})
+	} else {
+		subscription, parseErr = parseFeed(requestBuilder, responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+	}
 	if parseErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
@@ -153,7 +196,8 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.Cookie = feedCreationRequest.Cookie
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
-	subscription.Crawler = feedCreationRequest.Crawler
+	// The sitemaps only list the pages, their content is fetched by the crawler.
+	subscription.Crawler = feedCreationRequest.Crawler || isSitemap(bytes.NewReader(responseBody))
 	subscription.Disabled = feedCreationRequest.Disabled
 	subscription.IgnoreHTTPCache = feedCreationRequest.IgnoreHTTPCache
 	subscription.AllowSelfSignedCertificates = feedCreationRequest.AllowSelfSignedCertificates
@@ -166,15 +210,25 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.KeeplistRules = feedCreationRequest.KeeplistRules
 	subscription.BlockFilterEntryRules = feedCreationRequest.BlockFilterEntryRules
 	subscription.KeepFilterEntryRules = feedCreationRequest.KeepFilterEntryRules
+	subscription.EntryActionRules = feedCreationRequest.EntryActionRules
 	subscription.HideGlobally = feedCreationRequest.HideGlobally
+	subscription.IgnoreSkipHints = feedCreationRequest.IgnoreSkipHints
+	subscription.CustomHeaders = feedCreationRequest.CustomHeaders
+	subscription.ClientCertificate = feedCreationRequest.ClientCertificate
+	subscription.ClientPrivateKey = feedCreationRequest.ClientPrivateKey
 	subscription.EtagHeader = responseHandler.ETag()
 	subscription.LastModifiedHeader = responseHandler.LastModified()
 	subscription.FeedURL = responseHandler.EffectiveURL()
 	subscription.ProxyURL = feedCreationRequest.ProxyURL
-	subscription.WithCategoryID(feedCreationRequest.CategoryID)
+	subscription.WebPageItemSelector = feedCreationRequest.WebPageItemSelector
+	subscription.WebPageTitleSelector = feedCreationRequest.WebPageTitleSelector
+	subscription.WebPageLinkSelector = feedCreationRequest.WebPageLinkSelector
+	subscription.WebPageDateSelector = feedCreationRequest.WebPageDateSelector
+	subscription.WebPageContentSelector = feedCreationRequest.WebPageContentSelector
+	subscription.Category = category
 	subscription.CheckedNow()
 
-	processor.ProcessFeedEntries(store, subscription, userID, true)
+	processor.ProcessFeedEntries(context.Background(), store, subscription, userID, true)
 
 	if storeErr := store.CreateFeed(subscription); storeErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
@@ -188,11 +242,16 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 
 	icon.NewIconChecker(store, subscription).UpdateOrCreateFeedIcon()
 
+	if feedCreationRequest.BackfillHistory && !subscription.IsWebPageFeed() {
+		enqueueFeedBackfill(store, subscription)
+	}
+
 	return subscription, nil
 }
 
 // RefreshFeed refreshes a feed.
-func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
+// The entries are not stored when the context is canceled during the refresh, the feed is refreshed again later.
+func RefreshFeed(ctx context.Context, store *storage.Storage, userID, feedID int64, forceRefresh bool) (refreshErr *locale.LocalizedErrorWrapper) {
 	slog.Debug("Begin feed refresh process",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", feedID),
@@ -208,9 +267,18 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
 	}
 
+	if originalFeed.IsNewsletter() {
+		slog.Debug("Ignoring refresh of a newsletter feed, its entries are received by email",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedID),
+		)
+		return nil
+	}
+
 	weeklyEntryCount := 0
 	var refreshDelay time.Duration
-	if config.Opts.PollingScheduler() == model.SchedulerEntryFrequency {
+	switch config.Opts.PollingScheduler() {
+	case model.SchedulerEntryFrequency, model.SchedulerPublicationTime:
 		var weeklyCountErr error
 		weeklyEntryCount, weeklyCountErr = store.WeeklyFeedEntryCount(userID, feedID)
 		if weeklyCountErr != nil {
@@ -218,20 +286,40 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		}
 	}
 
+	if config.Opts.PollingScheduler() == model.SchedulerPublicationTime {
+		histogram, histogramErr := store.FeedPublicationHistogram(userID, feedID)
+		if histogramErr != nil {
+			return locale.NewLocalizedErrorWrapper(histogramErr, "error.database_error", histogramErr)
+		}
+		originalFeed.PublicationHistogram = histogram
+	}
+
+	if config.Opts.WebSub() {
+		webSubSubscription, webSubErr := store.WebSubSubscription(feedID)
+		if webSubErr != nil {
+			return locale.NewLocalizedErrorWrapper(webSubErr, "error.database_error", webSubErr)
+		}
+		originalFeed.WebSubSubscription = webSubSubscription
+	}
+
 	originalFeed.CheckedNow()
 	originalFeed.ScheduleNextCheck(weeklyEntryCount, refreshDelay)
 
 	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithContext(ctx)
 	requestBuilder.WithUsernameAndPassword(originalFeed.Username, originalFeed.Password)
 	requestBuilder.WithUserAgent(originalFeed.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(originalFeed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
 	requestBuilder.WithCustomFeedProxyURL(originalFeed.ProxyURL)
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
 	requestBuilder.UseCustomApplicationProxyURL(originalFeed.FetchViaProxy)
 	requestBuilder.IgnoreTLSErrors(originalFeed.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(originalFeed.DisableHTTP2)
+	requestBuilder.WithCustomHeaders(originalFeed.CustomHeaders)
+	requestBuilder.WithClientCertificate(originalFeed.ClientCertificate, originalFeed.ClientPrivateKey)
 
 	ignoreHTTPCache := originalFeed.IgnoreHTTPCache || forceRefresh
 	if !ignoreHTTPCache {
@@ -239,9 +327,25 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		requestBuilder.WithLastModified(originalFeed.LastModifiedHeader)
 	}
 
+	fetchStartTime := time.Now()
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(originalFeed.FeedURL))
 	defer responseHandler.Close()
 
+	// The interrupted request is not an error of the feed.
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return locale.NewLocalizedErrorWrapper(ctxErr, "error.network_operation", ctxErr)
+	}
+
+	feedFetch := &model.FeedFetch{
+		FeedID:         feedID,
+		FetchedAt:      fetchStartTime,
+		StatusCode:     responseHandler.StatusCode(),
+		ResponseTimeMs: time.Since(fetchStartTime).Milliseconds(),
+	}
+	defer func() {
+		recordFeedFetch(store, userID, feedFetch, refreshErr)
+	}()
+
 	if responseHandler.IsRateLimited() {
 		retryDelay := responseHandler.ParseRetryDelay()
 		calculatedNextCheckInterval := originalFeed.ScheduleNextCheck(weeklyEntryCount, retryDelay)
@@ -255,6 +359,25 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		)
 	}
 
+	// The error counter of the feed is not incremented when its host is paused, the feed is checked again after the pause.
+	if hostPauseDelay, isHostPaused := responseHandler.HostPauseDelay(); isHostPaused {
+		calculatedNextCheckInterval := originalFeed.ScheduleNextCheck(weeklyEntryCount, hostPauseDelay)
+
+		slog.Info("Feed refresh postponed because its host is paused",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedID),
+			slog.String("feed_url", originalFeed.FeedURL),
+			slog.Int("host_pause_delay_in_seconds", int(hostPauseDelay.Seconds())),
+			slog.Int("calculated_next_check_interval_in_minutes", int(calculatedNextCheckInterval.Minutes())),
+			slog.Time("new_next_check_at", originalFeed.NextCheckAt),
+		)
+
+		if storeErr := store.UpdateFeedError(originalFeed); storeErr != nil {
+			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+		return responseHandler.LocalizedError()
+	}
+
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
 		slog.Warn("Unable to fetch feed",
 			slog.Int64("user_id", userID),
@@ -266,8 +389,18 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		if storeErr != nil {
 			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 		}
+
+		isGone := responseHandler.IsGone()
+		if isGone {
+			localizedError = locale.NewLocalizedErrorWrapper(ErrFeedGone, "error.feed_gone")
+		}
+
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
-		store.UpdateFeedError(originalFeed)
+		if handleFailingFeed(store, originalFeed, feedFetch, isGone) {
+			store.UpdateFeed(originalFeed)
+		} else {
+			store.UpdateFeedError(originalFeed)
+		}
 		return localizedError
 	}
 
@@ -296,7 +429,16 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return localizedError
 		}
 
-		updatedFeed, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+		feedFetch.ResponseTimeMs = time.Since(fetchStartTime).Milliseconds()
+		feedFetch.ResponseSize = int64(len(responseBody))
+
+		var updatedFeed *model.Feed
+		var parseErr error
+		if originalFeed.IsWebPageFeed() {
+			updatedFeed, parseErr = webpage.Parse(responseHandler.EffectiveURL(), responseHandler.ContentType(), bytes.NewReader(responseBody), webpage.NewSelectors(originalFeed))
+		} else {
+			updatedFeed, parseErr = parseFeed(requestBuilder, responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+		}
 		if parseErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 
@@ -309,10 +451,22 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			}
 
 			originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
-			store.UpdateFeedError(originalFeed)
+			if handleFailingFeed(store, originalFeed, feedFetch, false) {
+				store.UpdateFeed(originalFeed)
+			} else {
+				store.UpdateFeedError(originalFeed)
+			}
 			return localizedError
 		}
 
+		// Keep the publisher hints up to date, they are used by the scheduler even when the next refresh fails.
+		originalFeed.SkipHours = updatedFeed.SkipHours
+		originalFeed.SkipDays = updatedFeed.SkipDays
+
+		if config.Opts.WebSub() && !originalFeed.IsWebPageFeed() {
+			subscribeToWebSubHub(store, originalFeed, updatedFeed.HubURL, updatedFeed.FeedURL)
+		}
+
 		// Use the RSS TTL value, or the Cache-Control or Expires HTTP headers if available.
 		// Otherwise, we use the default value from the configuration (min interval parameter).
 		feedTTLValue := updatedFeed.TTL
@@ -336,10 +490,15 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		)
 
 		originalFeed.Entries = updatedFeed.Entries
-		processor.ProcessFeedEntries(store, originalFeed, userID, forceRefresh)
+		processor.ProcessFeedEntries(ctx, store, originalFeed, userID, forceRefresh)
+
+		// The web pages of some entries may be missing, the entries are stored by the next refresh.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return locale.NewLocalizedErrorWrapper(ctxErr, "error.network_operation", ctxErr)
+		}
 
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries). Unless it is forced to refresh
-		updateExistingEntries := forceRefresh || !originalFeed.Crawler
+		updateExistingEntries := forceRefresh || !originalFeed.IsCrawlerEnabled()
 		newEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
 		if storeErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
@@ -352,16 +511,8 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return localizedError
 		}
 
-		userIntegrations, intErr := store.Integration(userID)
-		if intErr != nil {
-			slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
-				slog.Int64("user_id", userID),
-				slog.Int64("feed_id", feedID),
-				slog.Any("error", intErr),
-			)
-		} else if userIntegrations != nil && len(newEntries) > 0 {
-			go integration.PushEntries(originalFeed, newEntries, userIntegrations)
-		}
+		feedFetch.NewEntries = len(newEntries)
+		sendEntriesToIntegrations(store, originalFeed, newEntries)
 
 		originalFeed.EtagHeader = responseHandler.ETag()
 		originalFeed.LastModifiedHeader = responseHandler.LastModified()
@@ -379,6 +530,8 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			slog.Int64("feed_id", feedID),
 		)
 
+		feedFetch.NotModified = true
+
 		// Last-Modified may be updated even if ETag is not. In this case, per
 		// RFC9111 sections 3.2 and 4.3.4, the stored response must be updated.
 		if responseHandler.LastModified() != "" {
@@ -386,6 +539,19 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		}
 	}
 
+	// The feed moved permanently, the new URL is used for the next refreshes.
+	if redirectURL := responseHandler.PermanentRedirectURL(); redirectURL != "" && redirectURL != originalFeed.FeedURL {
+		slog.Info("Feed URL updated after a permanent redirect",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedID),
+			slog.String("previous_feed_url", originalFeed.FeedURL),
+			slog.String("new_feed_url", redirectURL),
+		)
+		feedFetch.PreviousFeedURL = originalFeed.FeedURL
+		feedFetch.NewFeedURL = redirectURL
+		originalFeed.WithFeedURL(redirectURL)
+	}
+
 	originalFeed.ResetErrorCounter()
 
 	if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
@@ -401,3 +567,40 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 	return nil
 }
+
+func sendEntriesToIntegrations(store *storage.Storage, feed *model.Feed, newEntries model.Entries) {
+	userIntegrations, intErr := store.Integration(feed.UserID)
+	if intErr != nil {
+		slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", intErr),
+		)
+	} else if userIntegrations != nil && len(newEntries) > 0 {
+		go integration.PushEntries(feed, newEntries, userIntegrations)
+
+		for _, entry := range newEntries {
+			if len(entry.SendToIntegrations) > 0 {
+				go integration.SendEntryToIntegrations(entry, userIntegrations, entry.SendToIntegrations)
+			}
+		}
+	}
+}
+
+// recordFeedFetch adds the refresh attempt to the fetch history of the feed, the error is translated in the user language.
+func recordFeedFetch(store *storage.Storage, userID int64, feedFetch *model.FeedFetch, refreshErr *locale.LocalizedErrorWrapper) {
+	if refreshErr != nil {
+		feedFetch.ErrorMsg = refreshErr.Error().Error()
+		if user, storeErr := store.UserByID(userID); storeErr == nil && user != nil {
+			feedFetch.ErrorMsg = refreshErr.Translate(user.Language)
+		}
+	}
+
+	if err := store.CreateFeedFetch(feedFetch); err != nil {
+		slog.Error("Unable to record feed fetch",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedFetch.FeedID),
+			slog.Any("error", err),
+		)
+	}
+}
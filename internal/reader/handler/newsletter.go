@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"context"
+	"log/slog"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ProcessNewsletterEntry stores the entry converted from a newsletter received by email, with its attachments.
+// The entry and its attachments are stored in the same transaction, a failed delivery leaves nothing behind.
+// The entry goes through the same processing as the ones fetched when refreshing a feed.
+// A message delivered twice is stored once, the entry hash is derived from its Message-ID.
+func ProcessNewsletterEntry(store *storage.Storage, feed *model.Feed, entry *model.Entry, attachments []*model.NewsletterAttachment) *locale.LocalizedErrorWrapper {
+	if feed.Disabled {
+		slog.Debug("Ignoring newsletter delivered to a disabled feed",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+		)
+		return nil
+	}
+
+	feed.Entries = model.Entries{entry}
+	processor.ProcessFeedEntries(context.Background(), store, feed, feed.UserID, false)
+
+	// The entry is dropped by the processor when a block or keep rule filters it out.
+	var newEntries model.Entries
+	for _, processedEntry := range feed.Entries {
+		created, storeErr := store.StoreNewsletterEntry(feed.UserID, feed.ID, processedEntry, attachments)
+		if storeErr != nil {
+			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+
+		if created {
+			newEntries = append(newEntries, processedEntry)
+		}
+	}
+
+	sendEntriesToIntegrations(store, feed, newEntries)
+
+	slog.Debug("Newsletter processed",
+		slog.Int64("user_id", feed.UserID),
+		slog.Int64("feed_id", feed.ID),
+		slog.String("entry_hash", entry.Hash),
+		slog.Int("nb_new_entries", len(newEntries)),
+		slog.Int("nb_attachments", len(attachments)),
+	)
+
+	return nil
+}
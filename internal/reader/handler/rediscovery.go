@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"log/slog"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/subscription"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// handleFailingFeed looks for a replacement feed URL on the website of a feed that is gone or fails repeatedly.
+// The replacement URL is applied or proposed to the user depending on the configuration.
+// A feed that is gone is disabled unless its URL is replaced.
+// It returns true when more than the error of the feed was modified.
+func handleFailingFeed(store *storage.Storage, feed *model.Feed, feedFetch *model.FeedFetch, isGone bool) bool {
+	errorLimit := config.Opts.PollingRediscoveryErrorLimit()
+	if !isGone && (errorLimit == 0 || feed.ParsingErrorCount != errorLimit) {
+		return false
+	}
+
+	replacementURL := findReplacementFeedURL(store, feed)
+	switch {
+	case replacementURL != "" && config.Opts.PollingRediscoveryAutoApply():
+		slog.Info("Feed URL replaced by the one found on the website",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("previous_feed_url", feed.FeedURL),
+			slog.String("new_feed_url", replacementURL),
+		)
+		replaceFeedURL(feed, feedFetch, replacementURL)
+
+		// The feed is fetched again with its new URL, the errors of the previous URL are not relevant anymore.
+		feed.ResetErrorCounter()
+		return true
+	case replacementURL != "":
+		slog.Info("Replacement feed URL proposed to the user",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.String("proposed_feed_url", replacementURL),
+		)
+		feed.ProposedFeedURL = replacementURL
+	}
+
+	if isGone {
+		slog.Info("Feed disabled because it is gone",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+		)
+		feed.Disabled = true
+	}
+
+	return isGone || replacementURL != ""
+}
+
+// findReplacementFeedURL runs the subscription discovery on the website of the feed.
+// It returns the first feed found that is not the current feed URL nor another feed of the user.
+func findReplacementFeedURL(store *storage.Storage, feed *model.Feed) string {
+	if feed.SiteURL == "" || feed.SiteURL == feed.FeedURL || feed.IsWebPageFeed() {
+		return ""
+	}
+
+	// The custom headers and the client certificate of the feed are not sent, the website may be on another host.
+	// The credentials and the cookie are sent only when the website is on the same host as the feed.
+	requestBuilder := fetcher.NewRequestBuilder()
+	if strings.EqualFold(urllib.Domain(feed.SiteURL), urllib.Domain(feed.FeedURL)) {
+		requestBuilder.WithUsernameAndPassword(feed.Username, feed.Password)
+		requestBuilder.WithCookie(feed.Cookie)
+	}
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	subscriptions, localizedError := subscription.NewSubscriptionFinder(requestBuilder).FindSubscriptions(feed.SiteURL, "", "")
+	if localizedError != nil {
+		slog.Debug("Unable to discover the feeds of the website",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("site_url", feed.SiteURL),
+			slog.Any("error", localizedError.Error()),
+		)
+		return ""
+	}
+
+	for _, discoveredSubscription := range subscriptions {
+		if discoveredSubscription.URL != feed.FeedURL && !store.AnotherFeedURLExists(feed.UserID, feed.ID, discoveredSubscription.URL) {
+			return discoveredSubscription.URL
+		}
+	}
+
+	return ""
+}
+
+// replaceFeedURL changes the URL of the feed and records the change in its fetch history.
+func replaceFeedURL(feed *model.Feed, feedFetch *model.FeedFetch, newFeedURL string) {
+	feedFetch.PreviousFeedURL = feed.FeedURL
+	feedFetch.NewFeedURL = newFeedURL
+
+	feed.WithFeedURL(newFeedURL)
+
+	// The caching headers belong to the previous URL.
+	feed.EtagHeader = ""
+	feed.LastModifiedHeader = ""
+}
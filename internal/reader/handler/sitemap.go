@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/sitemap"
+)
+
+// A sitemap index can list hundreds of sitemaps, only the most recently modified ones are fetched.
+const maxSitemapIndexSitemaps = 3
+
+// parseFeed returns a normalized feed object from the document.
+// The sitemaps listed in a sitemap index are fetched with the request builder and merged into a single feed.
+func parseFeed(requestBuilder *fetcher.RequestBuilder, feedURL string, data io.ReadSeeker) (*model.Feed, error) {
+	feed, parseErr := parser.ParseFeed(feedURL, data)
+	if !errors.Is(parseErr, sitemap.ErrSitemapIndex) {
+		return feed, parseErr
+	}
+
+	data.Seek(0, io.SeekStart)
+	sitemapURLs, parseErr := sitemap.ParseIndex(feedURL, data)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	// The cache validators of the index don't apply to its sitemaps.
+	requestBuilder.WithoutConditionalHeaders()
+
+	entryHashes := make(map[string]bool)
+	var fetchErr error
+	for _, sitemapURL := range sitemapURLs[:min(len(sitemapURLs), maxSitemapIndexSitemaps)] {
+		sitemapFeed, err := fetchSitemap(requestBuilder, sitemapURL)
+		if err != nil {
+			slog.Warn("Unable to fetch sitemap from sitemap index",
+				slog.String("feed_url", feedURL),
+				slog.String("sitemap_url", sitemapURL),
+				slog.Any("error", err),
+			)
+			fetchErr = err
+			continue
+		}
+
+		if feed == nil {
+			feed = sitemapFeed
+			feed.FeedURL = feedURL
+			feed.Entries = nil
+		}
+
+		// A page can be listed in several sitemaps.
+		for _, entry := range sitemapFeed.Entries {
+			if !entryHashes[entry.Hash] {
+				entryHashes[entry.Hash] = true
+				feed.Entries = append(feed.Entries, entry)
+			}
+		}
+	}
+
+	if feed == nil {
+		if fetchErr == nil {
+			fetchErr = errors.New("the sitemap index is empty")
+		}
+		return nil, fmt.Errorf("handler: unable to fetch the sitemaps of %s: %w", feedURL, fetchErr)
+	}
+
+	return feed, nil
+}
+
+func fetchSitemap(requestBuilder *fetcher.RequestBuilder, sitemapURL string) (*model.Feed, error) {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(sitemapURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	// The sitemaps of an index can't be other indexes.
+	return sitemap.Parse(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+}
+
+// isSitemap returns true if the document is a sitemap or a sitemap index.
+func isSitemap(data io.ReadSeeker) bool {
+	format, _ := parser.DetectFeedFormat(data)
+	return format == parser.FormatSitemap
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/websub"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ProcessWebSubContent stores the entries of the feed content delivered by a WebSub hub.
+// The entries go through the same processing as the ones fetched when refreshing the feed.
+func ProcessWebSubContent(store *storage.Storage, userID, feedID int64, content []byte) *locale.LocalizedErrorWrapper {
+	originalFeed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if originalFeed == nil {
+		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+	}
+
+	if originalFeed.Disabled {
+		slog.Debug("Ignoring WebSub content delivered for a disabled feed",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedID),
+		)
+		return nil
+	}
+
+	updatedFeed, parseErr := parser.ParseFeed(originalFeed.FeedURL, bytes.NewReader(content))
+	if parseErr != nil {
+		return locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
+	}
+
+	originalFeed.Entries = updatedFeed.Entries
+	processor.ProcessFeedEntries(context.Background(), store, originalFeed, userID, false)
+
+	newEntries, storeErr := store.StorePartialFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.IsCrawlerEnabled())
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	sendEntriesToIntegrations(store, originalFeed, newEntries)
+
+	slog.Debug("WebSub content processed",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feedID),
+		slog.Int("nb_entries", len(originalFeed.Entries)),
+		slog.Int("nb_new_entries", len(newEntries)),
+	)
+
+	return nil
+}
+
+// subscribeToWebSubHub subscribes the feed to the hub advertised by the publisher,
+// or renews the subscription before the end of the lease.
+func subscribeToWebSubHub(store *storage.Storage, feed *model.Feed, hubURL, topicURL string) {
+	subscription := feed.WebSubSubscription
+
+	if hubURL == "" {
+		// The publisher doesn't advertise a hub anymore, the feed is polled again.
+		if subscription != nil {
+			if err := store.RemoveWebSubSubscription(feed.ID); err != nil {
+				slog.Error("Unable to remove WebSub subscription",
+					slog.Int64("user_id", feed.UserID),
+					slog.Int64("feed_id", feed.ID),
+					slog.Any("error", err),
+				)
+				return
+			}
+			feed.WebSubSubscription = nil
+		}
+		return
+	}
+
+	if subscription != nil && !subscription.NeedsRenewal(hubURL, topicURL) {
+		return
+	}
+
+	switch {
+	case subscription == nil || subscription.HubURL != hubURL || subscription.TopicURL != topicURL:
+		// A new callback is used for a new hub or topic, the previous subscription expires on its own.
+		subscription = &model.WebSubSubscription{
+			FeedID:     feed.ID,
+			UserID:     feed.UserID,
+			HubURL:     hubURL,
+			TopicURL:   topicURL,
+			CallbackID: crypto.GenerateRandomStringHex(20),
+			Secret:     crypto.GenerateRandomStringHex(32),
+			State:      model.WebSubStatePending,
+		}
+	case subscription.State != model.WebSubStateActive:
+		subscription.State = model.WebSubStatePending
+	}
+
+	// The subscription must be saved before sending the request because the hub may verify the intent immediately.
+	if err := store.SaveWebSubSubscription(subscription); err != nil {
+		slog.Error("Unable to save WebSub subscription",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	feed.WebSubSubscription = subscription
+
+	// The hub is reached with the network settings of the feed, the credentials of the feed are not sent to the hub.
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	callbackURL := websub.CallbackURL(subscription.CallbackID)
+	if err := websub.Subscribe(requestBuilder, hubURL, topicURL, callbackURL, subscription.Secret, config.Opts.WebSubLeaseDuration()); err != nil {
+		slog.Warn("Unable to subscribe to WebSub hub",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("hub_url", hubURL),
+			slog.String("topic_url", topicURL),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	slog.Debug("WebSub subscription requested",
+		slog.Int64("user_id", feed.UserID),
+		slog.Int64("feed_id", feed.ID),
+		slog.String("hub_url", hubURL),
+		slog.String("topic_url", topicURL),
+		slog.String("callback_url", callbackURL),
+	)
+}
@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hfeed builds a feed from the h-feed and h-entry microformats of an HTML page.
+//
+// Only the properties useful for a feed reader are parsed, see https://microformats.org/wiki/h-feed
+// and https://microformats.org/wiki/h-entry. The properties of a nested microformat, like the
+// name of an h-card, belong to this microformat and not to the enclosing entry.
+package hfeed // import "miniflux.app/v2/internal/reader/hfeed"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/encoding"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// ErrNoEntryFound is returned when the page doesn't contain any h-entry.
+var ErrNoEntryFound = errors.New("hfeed: no h-entry found")
+
+// The root class names are like "h-entry", the utility classes of CSS frameworks like "h-100" are not microformats.
+var rootClassName = regexp.MustCompile(`^h(-[a-z]+)+$`)
+
+// IsHFeed returns true if the HTML document contains an h-feed or h-entry microformat.
+func IsHFeed(r io.Reader) bool {
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return false
+		case html.StartTagToken, html.SelfClosingTagToken:
+			for {
+				key, value, moreAttr := tokenizer.TagAttr()
+				if string(key) == "class" {
+					for _, className := range strings.Fields(string(value)) {
+						if className == "h-feed" || className == "h-entry" {
+							return true
+						}
+					}
+				}
+				if !moreAttr {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Parse returns a normalized feed object built from the h-feed of the page.
+// The h-entry elements outside of any h-feed are used when the page has no h-feed.
+func Parse(pageURL string, r io.Reader) (*model.Feed, error) {
+	htmlDocumentReader, err := encoding.NewCharsetReader(r, "text/html")
+	if err != nil {
+		return nil, fmt.Errorf("hfeed: unable to read HTML document: %w", err)
+	}
+
+	document, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("hfeed: unable to parse HTML document: %w", err)
+	}
+
+	baseURL := pageURL
+	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
+		if absoluteBaseURL, err := urllib.AbsoluteURL(pageURL, strings.TrimSpace(hrefValue)); err == nil {
+			baseURL = absoluteBaseURL
+		}
+	}
+
+	feed := &model.Feed{
+		FeedURL: pageURL,
+		SiteURL: pageURL,
+	}
+
+	root := document.Find(".h-feed").First()
+	if root.Length() > 0 {
+		feed.Title = findText(root, "p-name")
+		if siteURL := findURL(baseURL, root, "u-url"); siteURL != "" {
+			feed.SiteURL = siteURL
+		}
+		feed.Description = findText(root, "p-summary")
+		feed.IconURL = findURL(baseURL, root, "u-photo")
+	} else {
+		root = document.Selection
+	}
+
+	if feed.Title == "" {
+		feed.Title = normalizeText(document.FindMatcher(goquery.Single("head title")).Text())
+	}
+	if feed.Title == "" {
+		feed.Title = feed.SiteURL
+	}
+
+	// The author of the feed is the default author of its entries.
+	feedAuthor := findAuthor(root)
+
+	items := findEntries(root)
+	if len(items) == 0 {
+		return nil, ErrNoEntryFound
+	}
+
+	for _, item := range items {
+		entry := buildEntry(baseURL, item)
+		if entry.Author == "" {
+			entry.Author = feedAuthor
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed, nil
+}
+
+func buildEntry(baseURL string, item *goquery.Selection) *model.Entry {
+	entry := model.NewEntry()
+	entry.URL = findURL(baseURL, item, "u-url")
+	entry.Title = findText(item, "p-name")
+	entry.Author = findAuthor(item)
+	entry.Date = findDate(item, "dt-published")
+	if entry.Date.IsZero() {
+		entry.Date = findDate(item, "dt-updated")
+	}
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	if properties := findProperties(item, "e-content"); len(properties) > 0 {
+		if content, err := properties[0].Html(); err == nil {
+			entry.Content = strings.TrimSpace(content)
+		}
+
+		// A note doesn't have a title, some pages use its whole text as name.
+		if properties[0].HasClass("p-name") {
+			entry.Title = ""
+		}
+	} else {
+		entry.Content = findText(item, "p-summary")
+	}
+
+	for _, property := range findProperties(item, "p-category") {
+		if category := propertyText(property); category != "" {
+			entry.Tags = append(entry.Tags, category)
+		}
+	}
+
+	if entry.Title == "" {
+		entry.Title = sanitizer.TruncateHTML(entry.Content, 100)
+	}
+
+	if entry.URL != "" {
+		entry.Hash = crypto.SHA256(entry.URL)
+	} else {
+		entry.URL = baseURL
+		entry.Hash = crypto.SHA256(entry.Title + entry.Content)
+	}
+
+	if entry.Title == "" {
+		entry.Title = entry.URL
+	}
+
+	return entry
+}
+
+// findEntries returns the h-entry elements of the root, without the ones nested in another microformat like a reply context.
+func findEntries(root *goquery.Selection) []*goquery.Selection {
+	var entries []*goquery.Selection
+	root.Children().Each(func(_ int, child *goquery.Selection) {
+		switch {
+		case child.HasClass("h-entry"):
+			entries = append(entries, child)
+		case !isMicroformat(child):
+			entries = append(entries, findEntries(child)...)
+		}
+	})
+	return entries
+}
+
+// findProperties returns the elements having the property class name that belong to the microformat.
+func findProperties(root *goquery.Selection, className string) []*goquery.Selection {
+	var properties []*goquery.Selection
+	root.Children().Each(func(_ int, child *goquery.Selection) {
+		if child.HasClass(className) {
+			properties = append(properties, child)
+		}
+		if !isMicroformat(child) {
+			properties = append(properties, findProperties(child, className)...)
+		}
+	})
+	return properties
+}
+
+func isMicroformat(selection *goquery.Selection) bool {
+	for _, className := range strings.Fields(selection.AttrOr("class", "")) {
+		if rootClassName.MatchString(className) {
+			return true
+		}
+	}
+	return false
+}
+
+func findText(root *goquery.Selection, className string) string {
+	if properties := findProperties(root, className); len(properties) > 0 {
+		return propertyText(properties[0])
+	}
+	return ""
+}
+
+// findAuthor returns the name of the author, the author is often an h-card with its own name property.
+func findAuthor(root *goquery.Selection) string {
+	properties := findProperties(root, "p-author")
+	if len(properties) == 0 {
+		return ""
+	}
+
+	if properties[0].HasClass("h-card") {
+		if name := findText(properties[0], "p-name"); name != "" {
+			return name
+		}
+	}
+	return propertyText(properties[0])
+}
+
+// propertyText returns the value of a "p-*" property, as defined by the microformats2 parsing rules.
+func propertyText(property *goquery.Selection) string {
+	switch {
+	case property.Is("abbr[title], link[title]"):
+		return normalizeText(property.AttrOr("title", ""))
+	case property.Is("data[value], input[value]"):
+		return normalizeText(property.AttrOr("value", ""))
+	case property.Is("img[alt], area[alt]"):
+		return normalizeText(property.AttrOr("alt", ""))
+	default:
+		return normalizeText(property.Text())
+	}
+}
+
+// findURL returns the absolute value of the first "u-*" property.
+func findURL(baseURL string, root *goquery.Selection, className string) string {
+	for _, property := range findProperties(root, className) {
+		var value string
+		switch {
+		case property.Is("a[href], area[href], link[href]"):
+			value = property.AttrOr("href", "")
+		case property.Is("img[src], audio[src], video[src], source[src], iframe[src]"):
+			value = property.AttrOr("src", "")
+		case property.Is("video[poster]"):
+			value = property.AttrOr("poster", "")
+		case property.Is("object[data]"):
+			value = property.AttrOr("data", "")
+		default:
+			value = propertyText(property)
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, value); err == nil {
+			return absoluteURL
+		}
+	}
+	return ""
+}
+
+// findDate returns the value of the first "dt-*" property that is a valid date.
+func findDate(root *goquery.Selection, className string) time.Time {
+	for _, property := range findProperties(root, className) {
+		var value string
+		switch {
+		case property.Is("time[datetime], ins[datetime], del[datetime]"):
+			value = property.AttrOr("datetime", "")
+		case property.Is("abbr[title]"):
+			value = property.AttrOr("title", "")
+		case property.Is("data[value], input[value]"):
+			value = property.AttrOr("value", "")
+		default:
+			value = property.Text()
+		}
+
+		value = normalizeText(value)
+		if value == "" {
+			continue
+		}
+
+		parsedDate, err := date.Parse(value)
+		if err == nil {
+			return parsedDate
+		}
+
+		slog.Debug("Unable to parse date from h-entry",
+			slog.String("date", value),
+			slog.Any("error", err),
+		)
+	}
+	return time.Time{}
+}
+
+func normalizeText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
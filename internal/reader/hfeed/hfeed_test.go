@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hfeed // import "miniflux.app/v2/internal/reader/hfeed"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+func TestIsHFeed(t *testing.T) {
+	scenarios := map[string]bool{
+		`<html><body><div class="h-feed"></div></body></html>`:               true,
+		`<html><body><article class="post h-entry"></article></body></html>`: true,
+		`<html><body><div class="h-card"></div></body></html>`:               false,
+		`<html><body><div class="h-100 h-feeds">h-feed</div></body></html>`:  false,
+		`<?xml version="1.0"?><rss version="2.0"><channel></channel></rss>`:  false,
+		`{"version": "https://jsonfeed.org/version/1", "title": "h-feed"}`:   false,
+	}
+
+	for input, expected := range scenarios {
+		if result := IsHFeed(strings.NewReader(input)); result != expected {
+			t.Errorf(`Unexpected result for %q: got %v instead of %v`, input, result, expected)
+		}
+	}
+}
+
+func TestParseHFeed(t *testing.T) {
+	data := `<!DOCTYPE html>
+	<html>
+	<head><title>Page title</title></head>
+	<body>
+		<div class="h-feed">
+			<h1 class="p-name">Jane's Blog</h1>
+			<a class="u-url" href="/">Home</a>
+			<p class="p-author h-card"><img class="u-photo" src="/jane.jpg" alt=""><span class="p-name">Jane Doe</span></p>
+
+			<article class="h-entry">
+				<h2><a class="p-name u-url" href="/posts/first">First post</a></h2>
+				<time class="dt-published" datetime="2024-05-01T10:00:00Z">May 1st</time>
+				<a class="p-author h-card" href="https://john.example.org/">John Smith</a>
+				<div class="e-content"><p>Hello <strong>world</strong></p></div>
+				<a class="p-category" href="/tags/go">Go</a>
+				<a class="p-category" href="/tags/web">Web</a>
+			</article>
+
+			<article class="h-entry">
+				<div class="p-name e-content">A short note without title</div>
+				<a class="u-url" href="https://example.org/notes/2"><time class="dt-published" datetime="2024-05-02">2024-05-02</time></a>
+			</article>
+		</div>
+	</body>
+	</html>`
+
+	feed, err := Parse("https://example.org/blog/", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Jane's Blog" {
+		t.Errorf(`Unexpected feed title: %q`, feed.Title)
+	}
+	if feed.SiteURL != "https://example.org/" {
+		t.Errorf(`Unexpected site URL: %q`, feed.SiteURL)
+	}
+	if feed.FeedURL != "https://example.org/blog/" {
+		t.Errorf(`Unexpected feed URL: %q`, feed.FeedURL)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "First post" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+	if entry.URL != "https://example.org/posts/first" {
+		t.Errorf(`Unexpected URL: %q`, entry.URL)
+	}
+	if entry.Hash != crypto.SHA256("https://example.org/posts/first") {
+		t.Errorf(`Unexpected hash: %q`, entry.Hash)
+	}
+	if !entry.Date.Equal(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+	if entry.Author != "John Smith" {
+		t.Errorf(`Unexpected author: %q`, entry.Author)
+	}
+	if entry.Content != "<p>Hello <strong>world</strong></p>" {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+	if len(entry.Tags) != 2 || entry.Tags[0] != "Go" || entry.Tags[1] != "Web" {
+		t.Errorf(`Unexpected categories: %v`, entry.Tags)
+	}
+
+	note := feed.Entries[1]
+	if note.Title != "A short note without title" {
+		t.Errorf(`Unexpected title: %q`, note.Title)
+	}
+	if note.URL != "https://example.org/notes/2" {
+		t.Errorf(`Unexpected URL: %q`, note.URL)
+	}
+	if note.Author != "Jane Doe" {
+		t.Errorf(`The author of the feed should be the default author: %q`, note.Author)
+	}
+	if note.Date.Format(time.DateOnly) != "2024-05-02" {
+		t.Errorf(`Unexpected date: %v`, note.Date)
+	}
+}
+
+func TestParseEntriesWithoutHFeed(t *testing.T) {
+	data := `<html>
+	<head><title>Notes</title><base href="https://cdn.example.org/"></head>
+	<body>
+		<div class="h-entry">
+			<p class="p-summary">Summary of the entry</p>
+			<a class="u-url" href="entry.html">Permalink</a>
+			<div class="h-cite u-in-reply-to">
+				<a class="p-name u-url" href="https://other.example.org/post">Quoted post</a>
+				<span class="p-category">quoted</span>
+			</div>
+			<div class="h-entry"><a class="u-url" href="nested.html">Nested</a></div>
+		</div>
+	</body>
+	</html>`
+
+	feed, err := Parse("https://example.org/notes", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Notes" {
+		t.Errorf(`The title of the page should be used: %q`, feed.Title)
+	}
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`The nested entries should be ignored, got %d entries`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://cdn.example.org/entry.html" {
+		t.Errorf(`Unexpected URL: %q`, entry.URL)
+	}
+	if entry.Title != "Summary of the entry" || entry.Content != "Summary of the entry" {
+		t.Errorf(`Unexpected title or content: %q / %q`, entry.Title, entry.Content)
+	}
+	if len(entry.Tags) != 0 {
+		t.Errorf(`The categories of the reply context should be ignored: %v`, entry.Tags)
+	}
+}
+
+func TestParseEntryWithoutURL(t *testing.T) {
+	data := `<html><body><div class="h-entry"><p class="p-name">Title</p><abbr class="dt-published" title="2024-01-02T03:04:05Z">Jan 2</abbr></div></body></html>`
+
+	feed, err := Parse("https://example.org/", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://example.org/" {
+		t.Errorf(`The page URL should be used: %q`, entry.URL)
+	}
+	if entry.Hash != crypto.SHA256("Title") {
+		t.Errorf(`The hash should be computed from the title and the content: %q`, entry.Hash)
+	}
+	if entry.Date.Year() != 2024 {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+}
+
+func TestParsePageWithoutEntry(t *testing.T) {
+	_, err := Parse("https://example.org/", strings.NewReader(`<html><body><div class="h-feed"><p class="p-name">Empty</p></div></body></html>`))
+	if !errors.Is(err, ErrNoEntryFound) {
+		t.Errorf(`Unexpected error: %v`, err)
+	}
+}
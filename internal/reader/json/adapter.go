@@ -54,6 +54,17 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		feed.Title = feed.SiteURL
 	}
 
+	// Populate the WebSub hub URL if present.
+	for _, hub := range j.jsonFeed.Hubs {
+		hubURL := strings.TrimSpace(hub.URL)
+		if hubURL != "" && strings.EqualFold(hub.Type, "WebSub") {
+			if hubURL, err := urllib.AbsoluteURL(feed.FeedURL, hubURL); err == nil {
+				feed.HubURL = hubURL
+				break
+			}
+		}
+	}
+
 	// Populate the icon URL if present.
 	for _, iconURL := range []string{j.jsonFeed.FaviconURL, j.jsonFeed.IconURL} {
 		iconURL = strings.TrimSpace(iconURL)
@@ -118,6 +129,14 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 			entry.Date = time.Now()
 		}
 
+		// Populate the entry language.
+		for _, language := range []string{item.Language, j.jsonFeed.Language} {
+			if language = strings.TrimSpace(language); language != "" {
+				entry.Language = strings.ToLower(language)
+				break
+			}
+		}
+
 		// Populate the entry author.
 		itemAuthors := j.jsonFeed.Authors
 		itemAuthors = append(itemAuthors, item.Authors...)
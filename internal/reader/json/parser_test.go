@@ -114,6 +114,33 @@ func TestParseFeedWithDescription(t *testing.T) {
 	}
 }
 
+func TestParseEntryLanguage(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"language": "en",
+		"items": [
+			{"id": "1", "url": "https://example.org/1", "content_text": "Hello"},
+			{"id": "2", "url": "https://example.org/2", "content_text": "Bonjour", "language": "fr-CA"}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "en" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "fr-ca" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[1].Language)
+	}
+}
+
 func TestParsePodcast(t *testing.T) {
 	data := `{
 		"version": "https://jsonfeed.org/version/1",
@@ -220,6 +247,29 @@ func TestParseFeedWithFeedURLWithTrailingSpace(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithWebSubHub(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"hubs": [
+			{"type": "rssCloud", "url": "https://cloud.example.org/"},
+			{"type": "WebSub", "url": "https://hub.example.org/"}
+		],
+		"items": []
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
 func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	data := `{
 		"version": "https://jsonfeed.org/version/1",
@@ -8,6 +8,7 @@ import (
 	"io"
 	"unicode"
 
+	"miniflux.app/v2/internal/reader/hfeed"
 	rxml "miniflux.app/v2/internal/reader/xml"
 )
 
@@ -17,6 +18,8 @@ const (
 	FormatRSS     = "rss"
 	FormatAtom    = "atom"
 	FormatJSON    = "json"
+	FormatHFeed   = "h-feed"
+	FormatSitemap = "sitemap"
 	FormatUnknown = "unknown"
 )
 
@@ -51,10 +54,18 @@ func DetectFeedFormat(r io.ReadSeeker) (string, string) {
 				return FormatAtom, "1.0"
 			case "RDF":
 				return FormatRDF, ""
+			case "urlset", "sitemapindex":
+				return FormatSitemap, ""
 			}
 		}
 	}
 
+	// An HTML page is a feed when it is marked up with the h-feed or h-entry microformats.
+	r.Seek(0, io.SeekStart)
+	if hfeed.IsHFeed(r) {
+		return FormatHFeed, ""
+	}
+
 	return FormatUnknown, ""
 }
 
@@ -78,6 +78,35 @@ func TestDetectUnknown(t *testing.T) {
 	}
 }
 
+func TestDetectHFeed(t *testing.T) {
+	data := `
+	<!DOCTYPE html> <html><body><div class="h-feed"><article class="h-entry"></article></div></body></html>
+	`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatHFeed {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatHFeed)
+	}
+}
+
+func TestDetectSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatSitemap {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatSitemap)
+	}
+}
+
+func TestDetectSitemapIndex(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></sitemapindex>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatSitemap {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatSitemap)
+	}
+}
+
 func TestDetectJSONWithLargeLeadingWhitespace(t *testing.T) {
 	leadingWhitespace := strings.Repeat(" ", 10000)
 	data := leadingWhitespace + `{
@@ -9,9 +9,11 @@ import (
 
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/atom"
+	"miniflux.app/v2/internal/reader/hfeed"
 	"miniflux.app/v2/internal/reader/json"
 	"miniflux.app/v2/internal/reader/rdf"
 	"miniflux.app/v2/internal/reader/rss"
+	"miniflux.app/v2/internal/reader/sitemap"
 )
 
 var ErrFeedFormatNotDetected = errors.New("parser: unable to detect feed format")
@@ -28,6 +30,10 @@ func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 		return json.Parse(baseURL, r)
 	case FormatRDF:
 		return rdf.Parse(baseURL, r)
+	case FormatHFeed:
+		return hfeed.Parse(baseURL, r)
+	case FormatSitemap:
+		return sitemap.Parse(baseURL, r)
 	default:
 		return nil, ErrFeedFormatNotDetected
 	}
@@ -378,6 +378,50 @@ func TestParseJsonFeedWithRelativeURL(t *testing.T) {
 	}
 }
 
+func TestParseHFeed(t *testing.T) {
+	data := `<!DOCTYPE html>
+		<html>
+			<body class="h-feed">
+				<h1 class="p-name">My Example Feed</h1>
+				<article class="h-entry">
+					<a class="p-name u-url" href="/first-post">First post</a>
+				</article>
+			</body>
+		</html>`
+
+	feed, err := ParseFeed("https://example.org/", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "My Example Feed" {
+		t.Errorf("Incorrect title, got: %s", feed.Title)
+	}
+
+	if len(feed.Entries) != 1 || feed.Entries[0].URL != "https://example.org/first-post" {
+		t.Errorf("Incorrect entries, got: %v", feed.Entries)
+	}
+}
+
+func TestParseSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+			<url>
+				<loc>https://example.org/first-post</loc>
+				<lastmod>2024-05-01</lastmod>
+			</url>
+		</urlset>`
+
+	feed, err := ParseFeed("https://example.org/sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 || feed.Entries[0].URL != "https://example.org/first-post" {
+		t.Errorf("Incorrect entries, got: %v", feed.Entries)
+	}
+}
+
 func TestParseUnknownFeed(t *testing.T) {
 	data := `
 		<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Specs: https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/examples/chapters/jsonChapters.md
+type jsonChapters struct {
+	Version  string        `json:"version"`
+	Chapters []jsonChapter `json:"chapters"`
+}
+
+type jsonChapter struct {
+	StartTime float64 `json:"startTime"`
+	Title     string  `json:"title"`
+	URL       string  `json:"url"`
+
+	// TOC is false for the chapters that should not be displayed in the table of contents.
+	TOC *bool `json:"toc"`
+}
+
+// IsJSONChaptersType returns true when the chapters file has the JSON format, the only one supported.
+// The type is optional in the feed, the JSON format is assumed when it is missing.
+func IsJSONChaptersType(mimeType string) bool {
+	switch strings.ToLower(strings.TrimSpace(mimeType)) {
+	case "", "application/json+chapters", "application/json":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseChapters reads a JSON chapters file and returns the chapters sorted by start time.
+func ParseChapters(data io.Reader) ([]*model.PodcastChapter, error) {
+	var document jsonChapters
+	if err := json.NewDecoder(data).Decode(&document); err != nil {
+		return nil, fmt.Errorf("podcast: unable to parse chapters: %w", err)
+	}
+
+	var chapters []*model.PodcastChapter
+	for _, chapter := range document.Chapters {
+		if chapter.TOC != nil && !*chapter.TOC {
+			continue
+		}
+
+		title := strings.TrimSpace(chapter.Title)
+		if title == "" || chapter.StartTime < 0 {
+			continue
+		}
+
+		chapterURL := strings.TrimSpace(chapter.URL)
+		if !strings.HasPrefix(chapterURL, "https://") && !strings.HasPrefix(chapterURL, "http://") {
+			chapterURL = ""
+		}
+
+		chapters = append(chapters, &model.PodcastChapter{
+			StartTime: chapter.StartTime,
+			Title:     title,
+			URL:       chapterURL,
+		})
+	}
+
+	slices.SortStableFunc(chapters, func(a, b *model.PodcastChapter) int {
+		return cmp.Compare(a.StartTime, b.StartTime)
+	})
+
+	return chapters, nil
+}
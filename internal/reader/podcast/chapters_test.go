@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseChapters(t *testing.T) {
+	data := `{
+		"version": "1.2.0",
+		"chapters": [
+			{"startTime": 120.5, "title": "Second chapter", "url": "https://example.org/chapter-2"},
+			{"startTime": 0, "title": "Introduction", "url": "javascript:alert(1)"},
+			{"startTime": 60, "title": "Hidden chapter", "toc": false},
+			{"startTime": 90, "title": " "}
+		]
+	}`
+
+	chapters, err := ParseChapters(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chapters) != 2 {
+		t.Fatalf(`Incorrect number of chapters, got: %d`, len(chapters))
+	}
+
+	if chapters[0].StartTime != 0 || chapters[0].Title != "Introduction" || chapters[0].URL != "" {
+		t.Errorf(`Unexpected first chapter: %+v`, chapters[0])
+	}
+
+	if chapters[1].StartTime != 120.5 || chapters[1].Title != "Second chapter" || chapters[1].URL != "https://example.org/chapter-2" {
+		t.Errorf(`Unexpected second chapter: %+v`, chapters[1])
+	}
+}
+
+func TestParseInvalidChapters(t *testing.T) {
+	if _, err := ParseChapters(strings.NewReader(`<chapters/>`)); err == nil {
+		t.Error(`Parsing an invalid chapters file should return an error`)
+	}
+}
+
+func TestIsJSONChaptersType(t *testing.T) {
+	scenarios := map[string]bool{
+		"":                          true,
+		"application/json+chapters": true,
+		"application/json":          true,
+		"text/vtt":                  false,
+	}
+
+	for mimeType, expected := range scenarios {
+		if result := IsJSONChaptersType(mimeType); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, mimeType, result, expected)
+		}
+	}
+}
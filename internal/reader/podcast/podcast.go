@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Specs: https://podcastindex.org/namespace/1.0
+type PodcastChannelElement struct {
+	PodcastFunding []PodcastFundingElement `xml:"https://podcastindex.org/namespace/1.0 funding"`
+	PodcastPersons []PodcastPersonElement  `xml:"https://podcastindex.org/namespace/1.0 person"`
+}
+
+type PodcastItemElement struct {
+	PodcastChapters    PodcastChaptersElement     `xml:"https://podcastindex.org/namespace/1.0 chapters"`
+	PodcastEpisode     PodcastEpisodeElement      `xml:"https://podcastindex.org/namespace/1.0 episode"`
+	PodcastPersons     []PodcastPersonElement     `xml:"https://podcastindex.org/namespace/1.0 person"`
+	PodcastSeason      PodcastSeasonElement       `xml:"https://podcastindex.org/namespace/1.0 season"`
+	PodcastSoundbites  []PodcastSoundbiteElement  `xml:"https://podcastindex.org/namespace/1.0 soundbite"`
+	PodcastTranscripts []PodcastTranscriptElement `xml:"https://podcastindex.org/namespace/1.0 transcript"`
+}
+
+type PodcastChaptersElement struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type PodcastEpisodeElement struct {
+	Display string `xml:"display,attr"`
+	Number  string `xml:",chardata"`
+}
+
+// EpisodeNumber returns the number of the episode, or zero when it is missing or invalid.
+func (e *PodcastEpisodeElement) EpisodeNumber() float64 {
+	number, err := strconv.ParseFloat(strings.TrimSpace(e.Number), 64)
+	if err != nil || number < 0 {
+		return 0
+	}
+	return number
+}
+
+type PodcastFundingElement struct {
+	URL   string `xml:"url,attr"`
+	Title string `xml:",chardata"`
+}
+
+type PodcastPersonElement struct {
+	Role  string `xml:"role,attr"`
+	Group string `xml:"group,attr"`
+	Image string `xml:"img,attr"`
+	Href  string `xml:"href,attr"`
+	Name  string `xml:",chardata"`
+}
+
+type PodcastSeasonElement struct {
+	Name   string `xml:"name,attr"`
+	Number string `xml:",chardata"`
+}
+
+// SeasonNumber returns the number of the season, or zero when it is missing or invalid.
+func (s *PodcastSeasonElement) SeasonNumber() int {
+	number, err := strconv.Atoi(strings.TrimSpace(s.Number))
+	if err != nil || number < 0 {
+		return 0
+	}
+	return number
+}
+
+type PodcastSoundbiteElement struct {
+	StartTime string `xml:"startTime,attr"`
+	Duration  string `xml:"duration,attr"`
+	Title     string `xml:",chardata"`
+}
+
+type PodcastTranscriptElement struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Language string `xml:"language,attr"`
+	Rel      string `xml:"rel,attr"`
+}
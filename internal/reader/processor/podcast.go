@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"context"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/podcast"
+	"miniflux.app/v2/internal/storage"
+)
+
+// updateEntryPodcastChapters downloads the chapters file of a podcast episode.
+// The chapters already stored are reused when the entry is not new and its chapters URL did not change.
+func updateEntryPodcastChapters(ctx context.Context, store *storage.Storage, feed *model.Feed, entry *model.Entry, entryIsNew, forceRefresh bool) {
+	if entry.Podcast == nil || entry.Podcast.ChaptersURL == "" || !podcast.IsJSONChaptersType(entry.Podcast.ChaptersType) {
+		return
+	}
+
+	if !entryIsNew && !forceRefresh {
+		if storedPodcastEpisode := store.GetPodcastEpisode(feed.ID, entry.Hash); storedPodcastEpisode != nil && storedPodcastEpisode.ChaptersURL == entry.Podcast.ChaptersURL {
+			entry.Podcast.Chapters = storedPodcastEpisode.Chapters
+			return
+		}
+	}
+
+	chapters, err := fetchPodcastChapters(ctx, feed, entry.Podcast.ChaptersURL)
+	if err != nil {
+		slog.Warn("Unable to fetch podcast chapters",
+			slog.Int64("user_id", feed.UserID),
+			slog.String("entry_url", entry.URL),
+			slog.String("chapters_url", entry.Podcast.ChaptersURL),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	entry.Podcast.Chapters = chapters
+}
+
+// fetchPodcastChapters downloads the chapters file with the network settings of the feed,
+// the chapters are usually hosted next to the episodes and behind the same proxy or authentication.
+func fetchPodcastChapters(ctx context.Context, feed *model.Feed, chaptersURL string) ([]*model.PodcastChapter, error) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithContext(ctx)
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+	requestBuilder.WithCustomHeaders(feed.CustomHeaders)
+	requestBuilder.WithClientCertificate(feed.ClientCertificate, feed.ClientPrivateKey)
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(chaptersURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	return podcast.ParseChapters(responseHandler.Body(config.Opts.HTTPClientMaxBodySize()))
+}
@@ -4,6 +4,7 @@
 package processor // import "miniflux.app/v2/internal/reader/processor"
 
 import (
+	"context"
 	"log/slog"
 	"net/url"
 	"slices"
@@ -13,6 +14,7 @@ import (
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/dedup"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/filter"
 	"miniflux.app/v2/internal/reader/readingtime"
@@ -24,7 +26,8 @@ import (
 )
 
 // ProcessFeedEntries downloads original web page for entries and apply filters.
-func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64, forceRefresh bool) {
+// The web pages are not downloaded anymore once the context is canceled.
+func ProcessFeedEntries(ctx context.Context, store *storage.Storage, feed *model.Feed, userID int64, forceRefresh bool) {
 	var filteredEntries model.Entries
 
 	user, storeErr := store.UserByID(userID)
@@ -37,24 +40,28 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 	parsedFeedURL, _ := url.Parse(feed.FeedURL)
 	parsedSiteURL, _ := url.Parse(feed.SiteURL)
 
-	blockRules := filter.ParseRules(user.BlockFilterEntryRules, feed.BlockFilterEntryRules)
-	allowRules := filter.ParseRules(user.KeepFilterEntryRules, feed.KeepFilterEntryRules)
+	blockRules := filter.ParseRules(user.BlockFilterEntryRules, feed.EffectiveBlockFilterEntryRules()).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
+	allowRules := filter.ParseRules(user.KeepFilterEntryRules, feed.EffectiveKeepFilterEntryRules()).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
 	slog.Debug("Filter rules",
 		slog.String("user_block_filter_rules", user.BlockFilterEntryRules),
-		slog.String("feed_block_filter_rules", feed.BlockFilterEntryRules),
+		slog.String("feed_block_filter_rules", feed.EffectiveBlockFilterEntryRules()),
 		slog.String("user_keep_filter_rules", user.KeepFilterEntryRules),
-		slog.String("feed_keep_filter_rules", feed.KeepFilterEntryRules),
+		slog.String("feed_keep_filter_rules", feed.EffectiveKeepFilterEntryRules()),
 		slog.Any("block_rules", blockRules),
 		slog.Any("allow_rules", allowRules),
 		slog.Int64("user_id", user.ID),
 		slog.Int64("feed_id", feed.ID),
 	)
 
+	actionRules := filter.ParseActionRules(user.EntryActionRules, feed.EntryActionRules).WithReadingSpeeds(user.DefaultReadingSpeed, user.CJKReadingSpeed)
+
 	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithContext(ctx)
 	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
 	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
 	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
@@ -92,7 +99,7 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 		webpageBaseURL := ""
 		entry.URL = rewrite.RewriteEntryURL(feed, entry)
 		entryIsNew := store.IsNewEntry(feed.ID, entry.Hash)
-		if feed.Crawler && (entryIsNew || forceRefresh) {
+		if feed.IsCrawlerEnabled() && (entryIsNew || forceRefresh) && ctx.Err() == nil {
 			slog.Debug("Scraping entry",
 				slog.Int64("user_id", user.ID),
 				slog.String("entry_url", entry.URL),
@@ -109,7 +116,7 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			scrapedPageBaseURL, extractedContent, scraperErr := scraper.ScrapeWebsite(
 				requestBuilder,
 				entry.URL,
-				feed.ScraperRules,
+				feed.EffectiveScraperRules(),
 			)
 
 			if scrapedPageBaseURL != "" {
@@ -138,7 +145,7 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			}
 		}
 
-		rewrite.ApplyContentRewriteRules(entry, feed.RewriteRules)
+		rewrite.ApplyContentRewriteRules(entry, feed.EffectiveRewriteRules())
 
 		if webpageBaseURL == "" {
 			webpageBaseURL = entry.URL
@@ -148,6 +155,16 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 		entry.Content = sanitizer.SanitizeHTML(webpageBaseURL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
 
 		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
+		updateEntryPodcastChapters(ctx, store, feed, entry, entryIsNew, forceRefresh)
+
+		entry.URLFingerprint = entryURLFingerprint(feed, entry)
+		entry.ContentFingerprint = dedup.ContentFingerprint(entry.Title, entry.Content)
+		if entryIsNew && user.DuplicateEntriesPolicy != model.DuplicateEntriesPolicyNone {
+			applyDuplicateEntriesPolicy(store, feed, entry, user)
+		}
+
+		// Status and starred changes are only persisted for new entries, see storage.createEntry.
+		filter.ApplyActions(filter.MatchingActions(actionRules, feed, entry), entry)
 
 		filteredEntries = append(filteredEntries, entry)
 	}
@@ -159,6 +176,51 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 	feed.Entries = filteredEntries
 }
 
+func applyDuplicateEntriesPolicy(store *storage.Storage, feed *model.Feed, entry *model.Entry, user *model.User) {
+	duplicateOfEntryID, err := store.DuplicateEntryID(user.ID, feed.ID, entry.URLFingerprint, entry.ContentFingerprint)
+	if err != nil {
+		slog.Error("Unable to check for duplicate entries",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("entry_url", entry.URL),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if duplicateOfEntryID == 0 {
+		return
+	}
+
+	slog.Debug("Entry is a duplicate of another feed entry",
+		slog.Int64("user_id", user.ID),
+		slog.String("entry_url", entry.URL),
+		slog.String("entry_hash", entry.Hash),
+		slog.Int64("duplicate_of_entry_id", duplicateOfEntryID),
+		slog.Int64("feed_id", feed.ID),
+		slog.String("duplicate_entries_policy", user.DuplicateEntriesPolicy),
+	)
+
+	switch user.DuplicateEntriesPolicy {
+	case model.DuplicateEntriesPolicyMarkAsRead:
+		entry.Status = model.EntryStatusRead
+	case model.DuplicateEntriesPolicyHide, model.DuplicateEntriesPolicyGroup:
+		// The duplicates are kept out of the entry lists, the group policy lists them under the first occurrence.
+		entry.Status = model.EntryStatusRead
+		entry.DuplicateOfEntryID = duplicateOfEntryID
+	}
+}
+
+// entryURLFingerprint returns the fingerprint of the entry URL, or an empty string when the URL is
+// the one of the website or of the feed, used as a fallback for the entries without link.
+func entryURLFingerprint(feed *model.Feed, entry *model.Entry) string {
+	fingerprint := dedup.URLFingerprint(entry.URL)
+	if fingerprint == "" || fingerprint == dedup.URLFingerprint(feed.SiteURL) || fingerprint == dedup.URLFingerprint(feed.FeedURL) {
+		return ""
+	}
+	return fingerprint
+}
+
 // ProcessEntryWebPage downloads the entry web page and apply rewrite rules.
 func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User) error {
 	startTime := time.Now()
@@ -169,6 +231,7 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	requestBuilder.WithCookie(feed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithHostLimiter(fetcher.HostLimiterInstance)
 	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
 	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
@@ -178,7 +241,7 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	webpageBaseURL, extractedContent, scraperErr := scraper.ScrapeWebsite(
 		requestBuilder,
 		entry.URL,
-		feed.ScraperRules,
+		feed.EffectiveScraperRules(),
 	)
 
 	if config.Opts.HasMetricsCollector() {
@@ -200,7 +263,7 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 		}
 	}
 
-	rewrite.ApplyContentRewriteRules(entry, entry.Feed.RewriteRules)
+	rewrite.ApplyContentRewriteRules(entry, feed.EffectiveRewriteRules())
 	entry.Content = sanitizer.SanitizeHTML(webpageBaseURL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
 
 	return nil
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestEntryURLFingerprint(t *testing.T) {
+	feed := &model.Feed{SiteURL: "https://example.org/blog/", FeedURL: "https://example.org/blog/feed.xml"}
+
+	scenarios := map[string]bool{
+		"https://example.org/blog/articles/123": true,
+		"https://example.org/blog":              false,
+		"https://www.example.org/blog/":         false,
+		"https://example.org/blog/feed.xml":     false,
+		"https://example.org/":                  false,
+		"":                                      false,
+	}
+
+	for entryURL, expected := range scenarios {
+		fingerprint := entryURLFingerprint(feed, &model.Entry{URL: entryURL})
+		if (fingerprint != "") != expected {
+			t.Errorf(`Unexpected fingerprint %q for the entry URL %q`, fingerprint, entryURL)
+		}
+	}
+}
@@ -70,6 +70,14 @@ func (r *rdfAdapter) buildFeed(baseURL string) *model.Feed {
 			entry.Content = item.Description
 		}
 
+		// Populate the entry language.
+		for _, language := range []string{item.DublinCoreLanguage, r.rdf.Channel.DublinCoreLanguage} {
+			if language = strings.TrimSpace(language); language != "" {
+				entry.Language = strings.ToLower(language)
+				break
+			}
+		}
+
 		// Generate the entry hash.
 		hashValue := itemLink
 		if hashValue == "" {
@@ -13,18 +13,19 @@ import (
 var customReplaceRuleRegex = regexp.MustCompile(`^rewrite\("([^"]+)"\|"([^"]+)"\)$`)
 
 func RewriteEntryURL(feed *model.Feed, entry *model.Entry) string {
-	if feed.UrlRewriteRules == "" {
+	urlRewriteRules := feed.EffectiveUrlRewriteRules()
+	if urlRewriteRules == "" {
 		return entry.URL
 	}
 
 	var rewrittenURL = entry.URL
-	parts := customReplaceRuleRegex.FindStringSubmatch(feed.UrlRewriteRules)
+	parts := customReplaceRuleRegex.FindStringSubmatch(urlRewriteRules)
 
 	if len(parts) == 3 {
 		re, err := regexp.Compile(parts[1])
 		if err != nil {
 			slog.Error("Failed on regexp compilation",
-				slog.String("url_rewrite_rules", feed.UrlRewriteRules),
+				slog.String("url_rewrite_rules", urlRewriteRules),
 				slog.Any("error", err),
 			)
 			return rewrittenURL
@@ -42,7 +43,7 @@ func RewriteEntryURL(feed *model.Feed, entry *model.Entry) string {
 			slog.String("rewritten_entry_url", rewrittenURL),
 			slog.Int64("feed_id", feed.ID),
 			slog.String("feed_url", feed.FeedURL),
-			slog.String("url_rewrite_rules", feed.UrlRewriteRules),
+			slog.String("url_rewrite_rules", urlRewriteRules),
 		)
 	}
 
@@ -6,6 +6,7 @@ package rss // import "miniflux.app/v2/internal/reader/rss"
 import (
 	"html"
 	"log/slog"
+	"net/url"
 	"path"
 	"slices"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/atom"
 	"miniflux.app/v2/internal/reader/date"
 	"miniflux.app/v2/internal/reader/sanitizer"
 	"miniflux.app/v2/internal/urllib"
@@ -47,6 +49,20 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Find the WebSub hub advertised in the Atom links.
+	for _, atomLink := range r.rss.Channel.Links {
+		atomLinkHref := strings.TrimSpace(atomLink.Href)
+		if atomLinkHref != "" && strings.EqualFold(atomLink.Rel, "hub") {
+			if absoluteHubURL, err := urllib.AbsoluteURL(feed.FeedURL, atomLinkHref); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
+	// Find the page with older entries of an archived or paged feed (RFC 5005).
+	feed.ArchivePageURL = findArchivePageURL(baseURL, r.rss.Channel.Links)
+
 	// Fallback to the site URL if the title is empty.
 	if feed.Title == "" {
 		feed.Title = feed.SiteURL
@@ -59,6 +75,10 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Get the hours and days the aggregators can skip.
+	feed.SkipHours = findSkipHours(r.rss.Channel.SkipHours)
+	feed.SkipDays = findSkipDays(r.rss.Channel.SkipDays)
+
 	// Get the feed icon URL if defined.
 	if r.rss.Channel.Image != nil {
 		if absoluteIconURL, err := urllib.AbsoluteURL(feed.SiteURL, r.rss.Channel.Image.URL); err == nil {
@@ -99,6 +119,14 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 			}
 		}
 
+		// Populate the entry language.
+		for _, language := range []string{item.DublinCoreLanguage, r.rss.Channel.Language} {
+			if language = strings.TrimSpace(language); language != "" {
+				entry.Language = strings.ToLower(language)
+				break
+			}
+		}
+
 		entry.Author = findEntryAuthor(&item)
 		if entry.Author == "" {
 			entry.Author = findFeedAuthor(&r.rss.Channel)
@@ -126,6 +154,9 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 			}
 		}
 
+		// Populate the Podcasting 2.0 metadata.
+		entry.Podcast = findEntryPodcastEpisode(&item, &r.rss.Channel, feed.SiteURL)
+
 		// Populate entry categories.
 		entry.Tags = findEntryTags(&item)
 		if len(entry.Tags) == 0 {
@@ -406,3 +437,143 @@ func findEntryEnclosures(rssItem *rssItem, siteURL string) model.EnclosureList {
 
 	return enclosures
 }
+
+// findEntryPodcastEpisode returns the Podcasting 2.0 metadata of the item, or nil when there is none.
+// The persons and the funding links of the channel are used when the item does not define its own.
+func findEntryPodcastEpisode(rssItem *rssItem, rssChannel *rssChannel, siteURL string) *model.PodcastEpisode {
+	podcastEpisode := &model.PodcastEpisode{
+		Season:      rssItem.PodcastSeason.SeasonNumber(),
+		SeasonName:  strings.TrimSpace(rssItem.PodcastSeason.Name),
+		Episode:     rssItem.PodcastEpisode.EpisodeNumber(),
+		EpisodeName: strings.TrimSpace(rssItem.PodcastEpisode.Display),
+	}
+
+	for _, transcript := range rssItem.PodcastTranscripts {
+		if transcriptURL := absolutePodcastURL(siteURL, transcript.URL); transcriptURL != "" {
+			podcastEpisode.Transcripts = append(podcastEpisode.Transcripts, &model.PodcastTranscript{
+				URL:      transcriptURL,
+				MimeType: strings.TrimSpace(transcript.Type),
+				Language: strings.TrimSpace(transcript.Language),
+				Rel:      strings.TrimSpace(transcript.Rel),
+			})
+		}
+	}
+
+	if chaptersURL := absolutePodcastURL(siteURL, rssItem.PodcastChapters.URL); chaptersURL != "" {
+		podcastEpisode.ChaptersURL = chaptersURL
+		podcastEpisode.ChaptersType = strings.TrimSpace(rssItem.PodcastChapters.Type)
+	}
+
+	persons := rssItem.PodcastPersons
+	if len(persons) == 0 {
+		persons = rssChannel.PodcastPersons
+	}
+	for _, person := range persons {
+		if name := strings.TrimSpace(person.Name); name != "" {
+			podcastEpisode.Persons = append(podcastEpisode.Persons, &model.PodcastPerson{
+				Name:     name,
+				Role:     strings.ToLower(strings.TrimSpace(person.Role)),
+				Group:    strings.ToLower(strings.TrimSpace(person.Group)),
+				ImageURL: absolutePodcastURL(siteURL, person.Image),
+				URL:      absolutePodcastURL(siteURL, person.Href),
+			})
+		}
+	}
+
+	for _, funding := range rssChannel.PodcastFunding {
+		if fundingURL := absolutePodcastURL(siteURL, funding.URL); fundingURL != "" {
+			podcastEpisode.Funding = append(podcastEpisode.Funding, &model.PodcastFunding{
+				URL:   fundingURL,
+				Title: strings.TrimSpace(funding.Title),
+			})
+		}
+	}
+
+	for _, soundbite := range rssItem.PodcastSoundbites {
+		startTime, startTimeErr := strconv.ParseFloat(strings.TrimSpace(soundbite.StartTime), 64)
+		duration, durationErr := strconv.ParseFloat(strings.TrimSpace(soundbite.Duration), 64)
+		if startTimeErr != nil || durationErr != nil || startTime < 0 || duration <= 0 {
+			continue
+		}
+		podcastEpisode.Soundbites = append(podcastEpisode.Soundbites, &model.PodcastSoundbite{
+			StartTime: startTime,
+			Duration:  duration,
+			Title:     strings.TrimSpace(soundbite.Title),
+		})
+	}
+
+	if podcastEpisode.IsEmpty() {
+		return nil
+	}
+
+	return podcastEpisode
+}
+
+// absolutePodcastURL returns the absolute URL of a link from the podcast namespace.
+// An empty string is returned for the links that are not web pages or files.
+func absolutePodcastURL(siteURL, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+
+	absoluteURL, err := urllib.AbsoluteURL(siteURL, link)
+	if err != nil {
+		return ""
+	}
+
+	parsedURL, err := url.Parse(absoluteURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return ""
+	}
+
+	return absoluteURL
+}
+
+// findArchivePageURL returns the link to the older entries of an archived feed, or to the next page of a paged feed.
+func findArchivePageURL(baseURL string, links []*atom.AtomLink) string {
+	for _, relation := range []string{"prev-archive", "next"} {
+		for _, link := range links {
+			href := strings.TrimSpace(link.Href)
+			if href != "" && strings.EqualFold(link.Rel, relation) {
+				if absoluteURL, err := urllib.AbsoluteURL(baseURL, href); err == nil {
+					return absoluteURL
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func findSkipHours(values []string) []int64 {
+	var skipHours []int64
+	for _, value := range values {
+		hour, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || hour < 0 || hour > 24 {
+			continue
+		}
+
+		// Some publishers use 24 for midnight.
+		hour = hour % 24
+		if !slices.Contains(skipHours, hour) {
+			skipHours = append(skipHours, hour)
+		}
+	}
+
+	slices.Sort(skipHours)
+	return skipHours
+}
+
+func findSkipDays(values []string) []string {
+	var skipDays []string
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		for _, value := range values {
+			if strings.EqualFold(strings.TrimSpace(value), weekday.String()) {
+				skipDays = append(skipDays, weekday.String())
+				break
+			}
+		}
+	}
+
+	return skipDays
+}
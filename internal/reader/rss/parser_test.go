@@ -5,6 +5,7 @@ package rss // import "miniflux.app/v2/internal/reader/rss"
 
 import (
 	"bytes"
+	"slices"
 	"testing"
 	"time"
 )
@@ -137,6 +138,39 @@ func TestParseFeedWithFeedURLWithTrailingSpace(t *testing.T) {
 	}
 }
 
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0"?>
+		<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<language>en-us</language>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item1</link>
+			</item>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item2</link>
+				<dc:language> de </dc:language>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "en-us" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "de" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[1].Language)
+	}
+}
+
 func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	data := `<?xml version="1.0"?>
 		<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
@@ -2179,3 +2213,222 @@ func TestParseFeedWithIncorrectTTLValue(t *testing.T) {
 		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
 	}
 }
+
+func TestParseFeedWithSkipHoursAndSkipDays(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<skipHours>
+				<hour>23</hour>
+				<hour> 1 </hour>
+				<hour>24</hour>
+				<hour>1</hour>
+				<hour>invalid</hour>
+				<hour>42</hour>
+			</skipHours>
+			<skipDays>
+				<day>Sunday</day>
+				<day>saturday</day>
+				<day>Someday</day>
+			</skipDays>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHours := []int64{0, 1, 23}
+	if !slices.Equal(feed.SkipHours, expectedHours) {
+		t.Errorf("Incorrect skip hours, got: %v", feed.SkipHours)
+	}
+
+	expectedDays := []string{"Sunday", "Saturday"}
+	if !slices.Equal(feed.SkipDays, expectedDays) {
+		t.Errorf("Incorrect skip days, got: %v", feed.SkipDays)
+	}
+}
+
+func TestParseFeedWithWebSubHub(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link rel="hub" href="https://hub.example.org/"/>
+			<atom:link rel="self" type="application/rss+xml" href="https://example.org/rss.xml"/>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/feed", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+
+	if feed.FeedURL != "https://example.org/rss.xml" {
+		t.Errorf("Incorrect feed URL, got: %s", feed.FeedURL)
+	}
+}
+
+func TestParseFeedWithoutWebSubHub(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/feed", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "" {
+		t.Errorf("The hub URL should be empty, got: %s", feed.HubURL)
+	}
+}
+
+func TestParseFeedWithArchivePage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link rel="next" href="/rss.xml?page=2"/>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/rss.xml", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.ArchivePageURL != "https://example.org/rss.xml?page=2" {
+		t.Errorf("Incorrect archive page URL, got: %s", feed.ArchivePageURL)
+	}
+}
+
+func TestParsePodcastNamespace(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+		<channel>
+			<title>Podcast Example</title>
+			<link>https://example.org/</link>
+			<podcast:funding url="https://example.org/donate">Support the show!</podcast:funding>
+			<podcast:funding url="javascript:alert(1)">Invalid link</podcast:funding>
+			<podcast:person role="host" href="https://example.org/alice">Alice</podcast:person>
+			<item>
+				<title>Episode 1</title>
+				<guid>https://example.org/episode-1.mp3</guid>
+				<enclosure url="https://example.org/episode-1.mp3" type="audio/mpeg" length="1000"/>
+				<podcast:season name="Origins">2</podcast:season>
+				<podcast:episode>3.5</podcast:episode>
+				<podcast:transcript url="/episode-1.vtt" type="text/vtt" language="en" rel="captions"/>
+				<podcast:transcript url="https://example.org/episode-1.html" type="text/html"/>
+				<podcast:chapters url="https://example.org/episode-1.json" type="application/json+chapters"/>
+				<podcast:person role="Guest" img="https://example.org/bob.jpg">Bob</podcast:person>
+				<podcast:soundbite startTime="73.5" duration="60">The best part</podcast:soundbite>
+				<podcast:soundbite startTime="invalid" duration="60">Invalid soundbite</podcast:soundbite>
+			</item>
+			<item>
+				<title>Episode 2</title>
+				<guid>https://example.org/episode-2.mp3</guid>
+				<podcast:episode display="Trailer">4</podcast:episode>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcastEpisode := feed.Entries[0].Podcast
+	if podcastEpisode == nil {
+		t.Fatal(`The podcast metadata should be parsed`)
+	}
+
+	if podcastEpisode.Season != 2 || podcastEpisode.SeasonName != "Origins" || podcastEpisode.Episode != 3.5 || podcastEpisode.EpisodeName != "" {
+		t.Errorf(`Unexpected season or episode: %+v`, podcastEpisode)
+	}
+
+	if len(podcastEpisode.Transcripts) != 2 {
+		t.Fatalf(`Incorrect number of transcripts, got: %d`, len(podcastEpisode.Transcripts))
+	}
+
+	if transcript := podcastEpisode.Transcripts[0]; transcript.URL != "https://example.org/episode-1.vtt" || transcript.MimeType != "text/vtt" || transcript.Language != "en" || transcript.Rel != "captions" {
+		t.Errorf(`Unexpected transcript: %+v`, transcript)
+	}
+
+	if podcastEpisode.ChaptersURL != "https://example.org/episode-1.json" || podcastEpisode.ChaptersType != "application/json+chapters" {
+		t.Errorf(`Unexpected chapters: %s (%s)`, podcastEpisode.ChaptersURL, podcastEpisode.ChaptersType)
+	}
+
+	if len(podcastEpisode.Persons) != 1 || podcastEpisode.Persons[0].Name != "Bob" || podcastEpisode.Persons[0].Role != "guest" || podcastEpisode.Persons[0].ImageURL != "https://example.org/bob.jpg" {
+		t.Errorf(`The persons of the item should be used: %+v`, podcastEpisode.Persons)
+	}
+
+	if len(podcastEpisode.Funding) != 1 || podcastEpisode.Funding[0].URL != "https://example.org/donate" || podcastEpisode.Funding[0].Title != "Support the show!" {
+		t.Errorf(`Unexpected funding: %+v`, podcastEpisode.Funding)
+	}
+
+	if len(podcastEpisode.Soundbites) != 1 || podcastEpisode.Soundbites[0].StartTime != 73.5 || podcastEpisode.Soundbites[0].Duration != 60 || podcastEpisode.Soundbites[0].Title != "The best part" {
+		t.Errorf(`Unexpected soundbites: %+v`, podcastEpisode.Soundbites)
+	}
+
+	podcastEpisode = feed.Entries[1].Podcast
+	if podcastEpisode == nil {
+		t.Fatal(`The podcast metadata of the second entry should be parsed`)
+	}
+
+	if podcastEpisode.Episode != 4 || podcastEpisode.EpisodeName != "Trailer" {
+		t.Errorf(`Unexpected episode: %+v`, podcastEpisode)
+	}
+
+	if len(podcastEpisode.Persons) != 1 || podcastEpisode.Persons[0].Name != "Alice" || podcastEpisode.Persons[0].URL != "https://example.org/alice" {
+		t.Errorf(`The persons of the channel should be used: %+v`, podcastEpisode.Persons)
+	}
+}
+
+func TestParseEntryWithoutPodcastNamespace(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Podcast != nil {
+		t.Errorf(`The entry should not have podcast metadata, got: %+v`, feed.Entries[0].Podcast)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"miniflux.app/v2/internal/reader/googleplay"
 	"miniflux.app/v2/internal/reader/itunes"
 	"miniflux.app/v2/internal/reader/media"
+	"miniflux.app/v2/internal/reader/podcast"
 )
 
 // Specs: https://www.rssboard.org/rss-specification
@@ -88,6 +89,7 @@ type rssChannel struct {
 	atomLinks
 	itunes.ItunesChannelElement
 	googleplay.GooglePlayChannelElement
+	podcast.PodcastChannelElement
 }
 
 type rssCloud struct {
@@ -167,6 +169,7 @@ type rssItem struct {
 	atomLinks
 	itunes.ItunesItemElement
 	googleplay.GooglePlayItemElement
+	podcast.PodcastItemElement
 }
 
 type rssAuthor struct {
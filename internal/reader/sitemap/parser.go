@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/xml"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// A sitemap can list up to 50,000 pages, only the most recent ones are turned into entries.
+const maxEntries = 100
+
+// ErrSitemapIndex is returned by Parse when the document lists sitemaps instead of pages, see ParseIndex.
+var ErrSitemapIndex = errors.New("sitemap: the document is a sitemap index")
+
+// Parse returns a normalized feed struct from a sitemap, the most recent pages first.
+// The sitemaps don't have the content of the pages, the crawler fetches it.
+func Parse(baseURL string, data io.ReadSeeker) (*model.Feed, error) {
+	sitemapDocument, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if sitemapDocument.XMLName.Local == "sitemapindex" {
+		return nil, ErrSitemapIndex
+	}
+
+	feed := &model.Feed{
+		FeedURL: baseURL,
+		SiteURL: urllib.RootURL(baseURL),
+	}
+
+	for _, sitemapURL := range sitemapDocument.URLs {
+		entry := buildEntry(baseURL, &sitemapURL)
+		if entry == nil {
+			continue
+		}
+
+		if feed.Title == "" && sitemapURL.News != nil {
+			feed.Title = strings.TrimSpace(sitemapURL.News.PublicationName)
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	if feed.Title == "" {
+		feed.Title = urllib.Domain(feed.SiteURL)
+	}
+
+	// The pages without date are kept after the dated ones, in the order of the sitemap.
+	slices.SortStableFunc(feed.Entries, func(a, b *model.Entry) int {
+		return b.Date.Compare(a.Date)
+	})
+
+	if len(feed.Entries) > maxEntries {
+		feed.Entries = feed.Entries[:maxEntries]
+	}
+
+	for _, entry := range feed.Entries {
+		if entry.Date.IsZero() {
+			entry.Date = time.Now()
+		}
+	}
+
+	return feed, nil
+}
+
+// ParseIndex returns the absolute URLs of the sitemaps listed in a sitemap index, the most recently modified first.
+func ParseIndex(baseURL string, data io.ReadSeeker) ([]string, error) {
+	sitemapDocument, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if sitemapDocument.XMLName.Local != "sitemapindex" {
+		return nil, errors.New("sitemap: the document is not a sitemap index")
+	}
+
+	sitemaps := slices.Clone(sitemapDocument.Sitemaps)
+	slices.SortStableFunc(sitemaps, func(a, b indexSitemap) int {
+		return parseDate(b.LastModified).Compare(parseDate(a.LastModified))
+	})
+
+	var sitemapURLs []string
+	for _, sitemap := range sitemaps {
+		location := strings.TrimSpace(sitemap.Location)
+		if location == "" {
+			continue
+		}
+
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, location); err == nil && !slices.Contains(sitemapURLs, absoluteURL) {
+			sitemapURLs = append(sitemapURLs, absoluteURL)
+		}
+	}
+
+	return sitemapURLs, nil
+}
+
+func decode(data io.ReadSeeker) (*document, error) {
+	sitemapDocument := new(document)
+	if err := xml.NewXMLDecoder(data).Decode(sitemapDocument); err != nil {
+		return nil, fmt.Errorf("sitemap: unable to parse document: %w", err)
+	}
+	return sitemapDocument, nil
+}
+
+func buildEntry(baseURL string, sitemapURL *sitemapURL) *model.Entry {
+	location := strings.TrimSpace(sitemapURL.Location)
+	if location == "" {
+		return nil
+	}
+
+	entryURL, err := urllib.AbsoluteURL(baseURL, location)
+	if err != nil {
+		return nil
+	}
+
+	entry := model.NewEntry()
+	entry.URL = entryURL
+	entry.Hash = crypto.SHA256(entryURL)
+	entry.Date = parseDate(sitemapURL.LastModified)
+
+	if news := sitemapURL.News; news != nil {
+		entry.Title = strings.TrimSpace(news.Title)
+		if publicationDate := parseDate(news.PublicationDate); !publicationDate.IsZero() {
+			entry.Date = publicationDate
+		}
+
+		for keyword := range strings.SplitSeq(news.Keywords, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				entry.Tags = append(entry.Tags, keyword)
+			}
+		}
+	}
+
+	if entry.Title == "" {
+		entry.Title = titleFromURL(entryURL)
+	}
+
+	for _, image := range sitemapURL.Images {
+		imageURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(image.Location))
+		if err != nil || image.Location == "" {
+			continue
+		}
+
+		entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
+			URL:      imageURL,
+			MimeType: "image/*",
+		})
+	}
+
+	return entry
+}
+
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	parsedDate, err := date.Parse(value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsedDate
+}
+
+// titleFromURL returns a title made from the last segment of the URL path, like "My article" for "/2024/05/my-article.html".
+// The URL is the title when the segment is not made of words.
+func titleFromURL(pageURL string) string {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+
+	segment := path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
+	segment = strings.TrimSuffix(segment, path.Ext(segment))
+	if unescapedSegment, err := url.PathUnescape(segment); err == nil {
+		segment = unescapedSegment
+	}
+
+	words := strings.FieldsFunc(segment, func(r rune) bool {
+		return r == '-' || r == '_' || r == '+' || unicode.IsSpace(r)
+	})
+
+	hasLetter := slices.ContainsFunc(words, func(word string) bool {
+		return strings.IndexFunc(word, unicode.IsLetter) >= 0
+	})
+	if !hasLetter {
+		return pageURL
+	}
+
+	title := strings.Join(words, " ")
+	firstRune, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(firstRune)) + title[size:]
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+func TestParseSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+		<url>
+			<loc>https://example.org/2024/05/older-article.html</loc>
+			<lastmod>2024-05-01</lastmod>
+		</url>
+		<url>
+			<loc>/2024/05/newer_article/</loc>
+			<lastmod>2024-05-02T10:00:00+00:00</lastmod>
+			<image:image>
+				<image:loc>https://cdn.example.org/photo.jpg</image:loc>
+			</image:image>
+		</url>
+		<url>
+			<loc>https://example.org/</loc>
+		</url>
+		<url>
+			<loc></loc>
+		</url>
+	</urlset>`
+
+	feed, err := Parse("https://example.org/sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "example.org" {
+		t.Errorf(`Unexpected feed title: %q`, feed.Title)
+	}
+	if feed.SiteURL != "https://example.org/" {
+		t.Errorf(`Unexpected site URL: %q`, feed.SiteURL)
+	}
+	if feed.FeedURL != "https://example.org/sitemap.xml" {
+		t.Errorf(`Unexpected feed URL: %q`, feed.FeedURL)
+	}
+	if len(feed.Entries) != 3 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://example.org/2024/05/newer_article/" {
+		t.Errorf(`The most recent page should be the first entry: %q`, entry.URL)
+	}
+	if entry.Title != "Newer article" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+	if entry.Hash != crypto.SHA256(entry.URL) {
+		t.Errorf(`Unexpected hash: %q`, entry.Hash)
+	}
+	if !entry.Date.Equal(time.Date(2024, time.May, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].URL != "https://cdn.example.org/photo.jpg" || entry.Enclosures[0].MimeType != "image/*" {
+		t.Errorf(`Unexpected enclosures: %v`, entry.Enclosures)
+	}
+
+	if feed.Entries[1].Title != "Older article" {
+		t.Errorf(`Unexpected title: %q`, feed.Entries[1].Title)
+	}
+
+	undatedEntry := feed.Entries[2]
+	if undatedEntry.Title != "https://example.org/" {
+		t.Errorf(`The URL should be the title of the home page: %q`, undatedEntry.Title)
+	}
+	if undatedEntry.Date.IsZero() {
+		t.Error(`The date of the undated pages should be the current time`)
+	}
+}
+
+func TestParseNewsSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+		<url>
+			<loc>https://news.example.org/business/article55.html</loc>
+			<lastmod>2024-01-01</lastmod>
+			<news:news>
+				<news:publication>
+					<news:name>The Example Times</news:name>
+					<news:language>en</news:language>
+				</news:publication>
+				<news:publication_date>2024-06-01T08:30:00Z</news:publication_date>
+				<news:title>Companies A, B in Merger Talks</news:title>
+				<news:keywords>business, merger, , acquisition</news:keywords>
+			</news:news>
+		</url>
+	</urlset>`
+
+	feed, err := Parse("https://news.example.org/news-sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "The Example Times" {
+		t.Errorf(`The publication name should be the feed title: %q`, feed.Title)
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Companies A, B in Merger Talks" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+	if !entry.Date.Equal(time.Date(2024, time.June, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf(`The publication date should be preferred to the modification date: %v`, entry.Date)
+	}
+	if strings.Join(entry.Tags, "|") != "business|merger|acquisition" {
+		t.Errorf(`Unexpected tags: %v`, entry.Tags)
+	}
+}
+
+func TestParseSitemapKeepsMostRecentPages(t *testing.T) {
+	var builder strings.Builder
+	builder.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	for i := range maxEntries + 20 {
+		fmt.Fprintf(&builder, `<url><loc>https://example.org/page-%d</loc><lastmod>%s</lastmod></url>`, i, time.Date(2024, time.January, 1, 0, i, 0, 0, time.UTC).Format(time.RFC3339))
+	}
+	builder.WriteString(`</urlset>`)
+
+	feed, err := Parse("https://example.org/sitemap.xml", strings.NewReader(builder.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != maxEntries {
+		t.Fatalf(`Unexpected number of entries: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != fmt.Sprintf("https://example.org/page-%d", maxEntries+19) || feed.Entries[maxEntries-1].URL != "https://example.org/page-20" {
+		t.Errorf(`The oldest pages should be dropped: %q ... %q`, feed.Entries[0].URL, feed.Entries[maxEntries-1].URL)
+	}
+}
+
+func TestParseSitemapIndex(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+		<sitemap>
+			<loc>https://example.org/sitemap-2024-04.xml</loc>
+			<lastmod>2024-04-30</lastmod>
+		</sitemap>
+		<sitemap>
+			<loc>/sitemap-pages.xml</loc>
+		</sitemap>
+		<sitemap>
+			<loc>https://example.org/sitemap-2024-05.xml</loc>
+			<lastmod>2024-05-31</lastmod>
+		</sitemap>
+		<sitemap>
+			<loc>https://example.org/sitemap-2024-05.xml</loc>
+		</sitemap>
+	</sitemapindex>`
+
+	if _, err := Parse("https://example.org/sitemap.xml", strings.NewReader(data)); !errors.Is(err, ErrSitemapIndex) {
+		t.Errorf(`Parse should return ErrSitemapIndex, got %v`, err)
+	}
+
+	sitemapURLs, err := ParseIndex("https://example.org/sitemap.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "https://example.org/sitemap-2024-05.xml https://example.org/sitemap-2024-04.xml https://example.org/sitemap-pages.xml"
+	if result := strings.Join(sitemapURLs, " "); result != expected {
+		t.Errorf(`Unexpected sitemaps: got %q instead of %q`, result, expected)
+	}
+}
+
+func TestParseIndexWithSitemap(t *testing.T) {
+	data := `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.org/</loc></url></urlset>`
+	if _, err := ParseIndex("https://example.org/sitemap.xml", strings.NewReader(data)); err == nil {
+		t.Error(`ParseIndex should return an error for a sitemap`)
+	}
+}
+
+func TestTitleFromURL(t *testing.T) {
+	scenarios := map[string]string{
+		"https://example.org/2024/05/my-first-article.html": "My first article",
+		"https://example.org/blog/%C3%A9t%C3%A9_2024/":      "Été 2024",
+		"https://example.org/articles/123456":               "https://example.org/articles/123456",
+		"https://example.org":                               "https://example.org",
+	}
+
+	for input, expected := range scenarios {
+		if result := titleFromURL(input); result != expected {
+			t.Errorf(`Unexpected title for %q: got %q instead of %q`, input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sitemap builds a feed from the pages listed in a sitemap.
+//
+// See https://www.sitemaps.org/protocol.html for the protocol, and the Google News and image extensions:
+// https://developers.google.com/search/docs/crawling-indexing/sitemaps/news-sitemap and
+// https://developers.google.com/search/docs/crawling-indexing/sitemaps/image-sitemaps.
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"encoding/xml"
+)
+
+// document represents a sitemap ("urlset" element) or a sitemap index ("sitemapindex" element).
+type document struct {
+	XMLName  xml.Name
+	URLs     []sitemapURL   `xml:"url"`
+	Sitemaps []indexSitemap `xml:"sitemap"`
+}
+
+// sitemapURL represents a page listed in a sitemap.
+type sitemapURL struct {
+	Location     string         `xml:"loc"`
+	LastModified string         `xml:"lastmod"`
+	News         *newsArticle   `xml:"news"`
+	Images       []sitemapImage `xml:"image"`
+}
+
+// newsArticle represents the "news:news" element of the Google News extension.
+type newsArticle struct {
+	PublicationName string `xml:"publication>name"`
+	PublicationDate string `xml:"publication_date"`
+	Title           string `xml:"title"`
+	Keywords        string `xml:"keywords"`
+}
+
+// sitemapImage represents the "image:image" element of the image extension.
+type sitemapImage struct {
+	Location string `xml:"loc"`
+}
+
+// indexSitemap represents a sitemap listed in a sitemap index.
+type indexSitemap struct {
+	Location     string `xml:"loc"`
+	LastModified string `xml:"lastmod"`
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"net/url"
+	"slices"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
@@ -64,7 +65,9 @@ func (f *subscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string,
 	}
 
 	// Step 1) Check if the website URL is already a feed.
-	if feedFormat, _ := parser.DetectFeedFormat(f.feedResponseInfo.Content); feedFormat != parser.FormatUnknown {
+	// A web page with an h-feed is also a feed, but the feeds advertised in its meta tags are preferred.
+	feedFormat, _ := parser.DetectFeedFormat(f.feedResponseInfo.Content)
+	if feedFormat != parser.FormatUnknown && feedFormat != parser.FormatHFeed {
 		f.feedDownloaded = true
 		return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), feedFormat)}, nil
 	}
@@ -83,9 +86,17 @@ func (f *subscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string,
 		slog.String("website_url", websiteURL),
 		slog.String("content_type", responseHandler.ContentType()),
 	)
-	if subscriptions, localizedError := f.findSubscriptionsFromWebPage(websiteURL, responseHandler.ContentType(), bytes.NewReader(responseBody)); localizedError != nil {
+	subscriptions, localizedError := f.findSubscriptionsFromWebPage(websiteURL, responseHandler.ContentType(), bytes.NewReader(responseBody))
+	if localizedError != nil {
 		return nil, localizedError
-	} else if len(subscriptions) > 0 {
+	}
+
+	if feedFormat == parser.FormatHFeed {
+		subscriptions = append(subscriptions, NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), parser.FormatHFeed))
+		f.feedDownloaded = len(subscriptions) == 1
+	}
+
+	if len(subscriptions) > 0 {
 		slog.Debug("Subscriptions found from web page", slog.String("website_url", websiteURL), slog.Any("subscriptions", subscriptions))
 		return subscriptions, nil
 	}
@@ -182,6 +193,7 @@ func (f *subscriptionFinder) findSubscriptionsFromWellKnownURLs(websiteURL strin
 		"rss.xml":      parser.FormatRSS,
 		"rss/":         parser.FormatRSS,
 		"rss/feed.xml": parser.FormatRSS,
+		"sitemap.xml":  parser.FormatSitemap,
 	}
 
 	websiteURLRoot := urllib.RootURL(websiteURL)
@@ -235,9 +247,54 @@ func (f *subscriptionFinder) findSubscriptionsFromWellKnownURLs(websiteURL strin
 		}
 	}
 
+	// The news sitemaps usually have their own URL, declared in the robots.txt file.
+	for _, sitemapURL := range f.findSitemapsFromRobotsTxt(websiteURLRoot) {
+		if !slices.ContainsFunc(subscriptions, func(s *subscription) bool { return s.URL == sitemapURL }) {
+			subscriptions = append(subscriptions, NewSubscription(sitemapURL, sitemapURL, parser.FormatSitemap))
+		}
+	}
+
 	return subscriptions, nil
 }
 
+// findSitemapsFromRobotsTxt returns the URLs of the "Sitemap:" lines of the robots.txt file.
+func (f *subscriptionFinder) findSitemapsFromRobotsTxt(websiteURLRoot string) []string {
+	robotsTxtURL, err := urllib.AbsoluteURL(websiteURLRoot, "/robots.txt")
+	if err != nil {
+		return nil
+	}
+
+	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(robotsTxtURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		slog.Debug("Ignore missing robots.txt during feed discovery",
+			slog.String("robots_txt_url", robotsTxtURL),
+			slog.Any("error", localizedError.Error()),
+		)
+		return nil
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil
+	}
+
+	var sitemapURLs []string
+	for line := range strings.Lines(string(responseBody)) {
+		directive, value, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(directive), "sitemap") {
+			continue
+		}
+
+		if sitemapURL := strings.TrimSpace(value); urllib.IsAbsoluteURL(sitemapURL) && !slices.Contains(sitemapURLs, sitemapURL) {
+			sitemapURLs = append(sitemapURLs, sitemapURL)
+		}
+	}
+
+	return sitemapURLs
+}
+
 func (f *subscriptionFinder) findSubscriptionsFromRSSBridge(websiteURL, rssBridgeURL string, rssBridgeToken string) (Subscriptions, *locale.LocalizedErrorWrapper) {
 	slog.Debug("Trying to detect feeds using RSS-Bridge",
 		slog.String("website_url", websiteURL),
@@ -4,8 +4,14 @@
 package subscription
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
 )
 
 func TestFindYoutubeFeed(t *testing.T) {
@@ -397,3 +403,101 @@ func TestParseWebPageWithNoHref(t *testing.T) {
 		t.Fatal(`Incorrect number of subscriptions returned`)
 	}
 }
+
+func TestFindSubscriptionsWithHFeed(t *testing.T) {
+	config.Opts = config.NewConfigOptions()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><body><div class="h-feed"><article class="h-entry"><a class="u-url" href="/post">Post</a></article></div></body></html>`))
+	}))
+	defer ts.Close()
+
+	finder := NewSubscriptionFinder(fetcher.NewRequestBuilder())
+	subscriptions, localizedError := finder.FindSubscriptions(ts.URL+"/", "", "")
+	if localizedError != nil {
+		t.Fatalf(`Unexpected error: %v`, localizedError.Error())
+	}
+
+	if len(subscriptions) != 1 {
+		t.Fatalf(`Incorrect number of subscriptions returned: %d`, len(subscriptions))
+	}
+
+	if subscriptions[0].URL != ts.URL+"/" || subscriptions[0].Type != parser.FormatHFeed {
+		t.Errorf(`Incorrect subscription: %v`, subscriptions[0])
+	}
+
+	if !finder.IsFeedAlreadyDownloaded() {
+		t.Error(`The page should be reused to create the feed`)
+	}
+}
+
+func TestFindSubscriptionsWithHFeedAndRssFeed(t *testing.T) {
+	config.Opts = config.NewConfigOptions()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html>
+			<head><link href="/rss" rel="alternate" type="application/rss+xml" title="RSS"></head>
+			<body><article class="h-entry"><a class="u-url" href="/post">Post</a></article></body>
+		</html>`))
+	}))
+	defer ts.Close()
+
+	finder := NewSubscriptionFinder(fetcher.NewRequestBuilder())
+	subscriptions, localizedError := finder.FindSubscriptions(ts.URL+"/", "", "")
+	if localizedError != nil {
+		t.Fatalf(`Unexpected error: %v`, localizedError.Error())
+	}
+
+	if len(subscriptions) != 2 {
+		t.Fatalf(`Incorrect number of subscriptions returned: %d`, len(subscriptions))
+	}
+
+	if subscriptions[0].Type != parser.FormatRSS || subscriptions[1].Type != parser.FormatHFeed {
+		t.Errorf(`The advertised feeds should be listed before the page: %v`, subscriptions)
+	}
+
+	if finder.IsFeedAlreadyDownloaded() {
+		t.Error(`The page should not be used as feed when there is a choice`)
+	}
+}
+
+func TestFindSubscriptionsWithSitemaps(t *testing.T) {
+	config.Opts = config.NewConfigOptions()
+
+	var serverURL string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><body>No feed</body></html>`))
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /admin\n\nSitemap: " + serverURL + "/sitemap.xml\nsitemap: " + serverURL + "/news-sitemap.xml\nSitemap: /relative.xml\n"))
+		case "/sitemap.xml", "/news-sitemap.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write([]byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	serverURL = ts.URL
+
+	subscriptions, localizedError := NewSubscriptionFinder(fetcher.NewRequestBuilder()).FindSubscriptions(ts.URL+"/", "", "")
+	if localizedError != nil {
+		t.Fatalf(`Unexpected error: %v`, localizedError.Error())
+	}
+
+	if len(subscriptions) != 2 {
+		t.Fatalf(`Incorrect number of subscriptions returned: %v`, subscriptions)
+	}
+
+	if subscriptions[0].URL != ts.URL+"/sitemap.xml" || subscriptions[0].Type != parser.FormatSitemap {
+		t.Errorf(`Incorrect subscription: %v`, subscriptions[0])
+	}
+
+	if subscriptions[1].URL != ts.URL+"/news-sitemap.xml" || subscriptions[1].Type != parser.FormatSitemap {
+		t.Errorf(`Incorrect subscription: %v`, subscriptions[1])
+	}
+}
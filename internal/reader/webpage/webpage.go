@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webpage builds a feed from a regular web page by using CSS selectors.
+package webpage // import "miniflux.app/v2/internal/reader/webpage"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/encoding"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// ErrNoItemFound is returned when the item selector doesn't match any element of the page.
+var ErrNoItemFound = errors.New("webpage: no item found with the item selector")
+
+// Selectors describes how to find the entries in the page.
+// The item selector is applied to the whole document, the other selectors are relative to each item.
+// Only the item selector is mandatory:
+//   - The link defaults to the first link of the item, or the item itself when it's a link.
+//   - The title defaults to the text of the link.
+//   - The content defaults to the HTML of the whole item.
+//   - The date defaults to the time of the refresh.
+type Selectors struct {
+	Item    string
+	Title   string
+	Link    string
+	Date    string
+	Content string
+}
+
+// NewSelectors returns the selectors configured for the feed.
+func NewSelectors(feed *model.Feed) *Selectors {
+	return &Selectors{
+		Item:    feed.WebPageItemSelector,
+		Title:   feed.WebPageTitleSelector,
+		Link:    feed.WebPageLinkSelector,
+		Date:    feed.WebPageDateSelector,
+		Content: feed.WebPageContentSelector,
+	}
+}
+
+// ValidateSelector returns an error if the CSS selector is invalid.
+func ValidateSelector(selector string) error {
+	if _, err := cascadia.ParseGroup(selector); err != nil {
+		return fmt.Errorf("webpage: invalid selector %q: %w", selector, err)
+	}
+	return nil
+}
+
+// Parse returns a normalized feed object built from the items of the HTML page.
+// The content type of the response is used to decode the pages that are not encoded in UTF-8.
+func Parse(pageURL, contentType string, r io.Reader, selectors *Selectors) (*model.Feed, error) {
+	htmlDocumentReader, err := encoding.NewCharsetReader(r, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("webpage: unable to read HTML document: %w", err)
+	}
+
+	document, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("webpage: unable to parse HTML document: %w", err)
+	}
+
+	baseURL := pageURL
+	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
+		if absoluteBaseURL, err := urllib.AbsoluteURL(pageURL, strings.TrimSpace(hrefValue)); err == nil {
+			baseURL = absoluteBaseURL
+		}
+	}
+
+	feed := &model.Feed{
+		Title:   normalizeText(document.FindMatcher(goquery.Single("head title")).Text()),
+		FeedURL: pageURL,
+		SiteURL: pageURL,
+	}
+
+	if description, exists := document.FindMatcher(goquery.Single(`head meta[name="description"]`)).Attr("content"); exists {
+		feed.Description = strings.TrimSpace(description)
+	}
+
+	if feed.Title == "" {
+		feed.Title = feed.SiteURL
+	}
+
+	items := document.Find(selectors.Item)
+	if items.Length() == 0 {
+		return nil, ErrNoItemFound
+	}
+
+	items.Each(func(_ int, item *goquery.Selection) {
+		if entry := buildEntry(baseURL, item, selectors); entry != nil {
+			feed.Entries = append(feed.Entries, entry)
+		}
+	})
+
+	return feed, nil
+}
+
+func buildEntry(baseURL string, item *goquery.Selection, selectors *Selectors) *model.Entry {
+	entry := model.NewEntry()
+
+	link := findLink(item, selectors.Link)
+	if href, exists := link.Attr("href"); exists && strings.TrimSpace(href) != "" {
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(href)); err == nil {
+			entry.URL = absoluteURL
+		}
+	}
+
+	if selectors.Title != "" {
+		entry.Title = normalizeText(item.Find(selectors.Title).First().Text())
+	} else {
+		entry.Title = normalizeText(link.Text())
+	}
+
+	if selectors.Content != "" {
+		item.Find(selectors.Content).Each(func(_ int, s *goquery.Selection) {
+			if content, err := goquery.OuterHtml(s); err == nil {
+				entry.Content += content
+			}
+		})
+	} else if content, err := item.Html(); err == nil {
+		entry.Content = strings.TrimSpace(content)
+	}
+
+	if entry.Title == "" {
+		entry.Title = sanitizer.TruncateHTML(entry.Content, 100)
+	}
+
+	if entry.URL == "" && entry.Title == "" {
+		return nil
+	}
+
+	if selectors.Date != "" {
+		entry.Date = findDate(item.Find(selectors.Date).First())
+	}
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	if entry.URL != "" {
+		entry.Hash = crypto.SHA256(entry.URL)
+	} else {
+		entry.URL = baseURL
+		entry.Hash = crypto.SHA256(entry.Title + entry.Content)
+	}
+
+	if entry.Title == "" {
+		entry.Title = entry.URL
+	}
+
+	return entry
+}
+
+func findLink(item *goquery.Selection, linkSelector string) *goquery.Selection {
+	candidates := item
+	if linkSelector != "" {
+		candidates = item.Find(linkSelector).First()
+	}
+
+	if candidates.Is("a[href]") {
+		return candidates.First()
+	}
+
+	return candidates.Find("a[href]").First()
+}
+
+// findDate reads the date from the "datetime" or "content" attributes, as used by <time> and <meta> elements, or from the text.
+func findDate(selection *goquery.Selection) time.Time {
+	if selection.Length() == 0 {
+		return time.Time{}
+	}
+
+	values := []string{selection.AttrOr("datetime", ""), selection.AttrOr("content", ""), selection.Text()}
+	for _, value := range values {
+		value = normalizeText(value)
+		if value == "" {
+			continue
+		}
+
+		parsedDate, err := date.Parse(value)
+		if err == nil {
+			return parsedDate
+		}
+
+		slog.Debug("Unable to parse date from web page",
+			slog.String("date", value),
+			slog.Any("error", err),
+		)
+	}
+
+	return time.Time{}
+}
+
+func normalizeText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
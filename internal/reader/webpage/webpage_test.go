@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package webpage // import "miniflux.app/v2/internal/reader/webpage"
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readTestPage(t *testing.T, filename string) string {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + filename)
+	if err != nil {
+		t.Fatalf(`Unable to read file %q: %v`, filename, err)
+	}
+
+	return string(data)
+}
+
+func TestParseWithAllSelectors(t *testing.T) {
+	data := readTestPage(t, "blog.html")
+
+	feed, err := Parse("https://example.org/blog/index.html", "text/html; charset=utf-8", strings.NewReader(data), &Selectors{
+		Item:    "article.post",
+		Title:   "h2",
+		Link:    "h2 a",
+		Date:    "time, .date",
+		Content: ".summary",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Example Blog" {
+		t.Errorf(`Incorrect feed title, got: %q`, feed.Title)
+	}
+
+	if feed.Description != "News from the example blog." {
+		t.Errorf(`Incorrect feed description, got: %q`, feed.Description)
+	}
+
+	if feed.SiteURL != "https://example.org/blog/index.html" {
+		t.Errorf(`Incorrect site URL, got: %q`, feed.SiteURL)
+	}
+
+	if len(feed.Entries) != 3 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].Title != "First post" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[0].Title)
+	}
+
+	if feed.Entries[0].URL != "https://example.org/blog/first-post.html" {
+		t.Errorf(`Incorrect entry URL, got: %q`, feed.Entries[0].URL)
+	}
+
+	expectedDate := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	if !feed.Entries[0].Date.Equal(expectedDate) {
+		t.Errorf(`Incorrect entry date, got: %v`, feed.Entries[0].Date)
+	}
+
+	if feed.Entries[0].Content != `<div class="summary"><p>Summary of the first post.</p></div>` {
+		t.Errorf(`Incorrect entry content, got: %q`, feed.Entries[0].Content)
+	}
+
+	if feed.Entries[1].URL != "https://example.com/external" {
+		t.Errorf(`Incorrect entry URL, got: %q`, feed.Entries[1].URL)
+	}
+
+	if feed.Entries[1].Date.Year() != 2024 || feed.Entries[1].Date.Month() != time.March || feed.Entries[1].Date.Day() != 4 {
+		t.Errorf(`Incorrect entry date, got: %v`, feed.Entries[1].Date)
+	}
+
+	if feed.Entries[2].Title != "Announcement without link" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[2].Title)
+	}
+
+	if feed.Entries[2].URL != "https://example.org/blog/" {
+		t.Errorf(`The entry without link should use the base URL, got: %q`, feed.Entries[2].URL)
+	}
+
+	if feed.Entries[0].Hash == feed.Entries[2].Hash || feed.Entries[2].Hash == "" {
+		t.Errorf(`The entries should have distinct hashes`)
+	}
+}
+
+func TestParseWithItemSelectorOnly(t *testing.T) {
+	data := readTestPage(t, "blog.html")
+
+	feed, err := Parse("https://example.org/blog/", "text/html; charset=utf-8", strings.NewReader(data), &Selectors{Item: "article.post h2 a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].Title != "First post" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[0].Title)
+	}
+
+	if feed.Entries[0].URL != "https://example.org/blog/first-post.html" {
+		t.Errorf(`Incorrect entry URL, got: %q`, feed.Entries[0].URL)
+	}
+
+	if feed.Entries[0].Date.IsZero() {
+		t.Errorf(`The entry date should default to the current time`)
+	}
+}
+
+func TestParseWithDefaultLinkFromItem(t *testing.T) {
+	data := `<html><body><ul><li><a href="/a">A</a> <a href="/b">B</a></li></ul></body></html>`
+
+	feed, err := Parse("https://example.org/", "text/html; charset=utf-8", strings.NewReader(data), &Selectors{Item: "li"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != "https://example.org/a" {
+		t.Errorf(`The first link of the item should be used, got: %q`, feed.Entries[0].URL)
+	}
+
+	if feed.Entries[0].Title != "A" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[0].Title)
+	}
+
+	if feed.Title != "https://example.org/" {
+		t.Errorf(`The feed title should default to the page URL, got: %q`, feed.Title)
+	}
+}
+
+func TestParseWithoutMatchingItems(t *testing.T) {
+	data := readTestPage(t, "blog.html")
+
+	_, err := Parse("https://example.org/blog/", "text/html; charset=utf-8", strings.NewReader(data), &Selectors{Item: "div.missing"})
+	if !errors.Is(err, ErrNoItemFound) {
+		t.Errorf(`Expected ErrNoItemFound, got: %v`, err)
+	}
+}
+
+func TestParseWithCharsetFromContentType(t *testing.T) {
+	// "Новости" encoded in windows-1251.
+	data := "<html><head><title>\xcd\xee\xe2\xee\xf1\xf2\xe8</title></head><body><ul><li><a href=\"/a\">\xcd\xee\xe2\xee\xf1\xf2\xe8</a></li></ul></body></html>"
+
+	feed, err := Parse("https://example.org/", "text/html; charset=windows-1251", strings.NewReader(data), &Selectors{Item: "li"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Новости" {
+		t.Errorf(`Incorrect feed title, got: %q`, feed.Title)
+	}
+
+	if feed.Entries[0].Title != "Новости" {
+		t.Errorf(`Incorrect entry title, got: %q`, feed.Entries[0].Title)
+	}
+}
+
+func TestValidateSelector(t *testing.T) {
+	scenarios := map[string]bool{
+		"article.post":      true,
+		"ul > li a[href]":   true,
+		"h2, h3":            true,
+		"time[datetime]":    true,
+		"article[":          false,
+		"h2 >":              false,
+		"div:unknown-thing": false,
+	}
+
+	for selector, valid := range scenarios {
+		err := ValidateSelector(selector)
+		if valid && err != nil {
+			t.Errorf(`Unexpected error for %q: %v`, selector, err)
+		}
+		if !valid && err == nil {
+			t.Errorf(`Expected an error for %q`, selector)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package websub sends subscription requests to WebSub hubs.
+//
+// Specs: https://www.w3.org/TR/websub/
+package websub // import "miniflux.app/v2/internal/reader/websub"
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+// maxErrorBodySize limits the part of the hub response body included in errors.
+const maxErrorBodySize = 512
+
+// CallbackURL returns the absolute URL where the hub verifies the subscription and delivers the content.
+func CallbackURL(callbackID string) string {
+	return config.Opts.BaseURL() + "/websub/" + callbackID
+}
+
+// Subscribe asks the hub to deliver the updates of the topic to the callback URL.
+// The hub accepts the request and verifies the intent of the subscriber later, by calling the callback URL.
+// The secret is used by the hub to sign the content it delivers.
+// The request builder carries the network settings of the feed, like its proxy.
+func Subscribe(requestBuilder *fetcher.RequestBuilder, hubURL, topicURL, callbackURL, secret string, lease time.Duration) error {
+	values := url.Values{}
+	values.Set("hub.mode", "subscribe")
+	values.Set("hub.topic", topicURL)
+	values.Set("hub.callback", callbackURL)
+	values.Set("hub.secret", secret)
+	values.Set("hub.lease_seconds", strconv.Itoa(int(lease.Seconds())))
+
+	response, err := requestBuilder.ExecutePostRequest(hubURL, "application/x-www-form-urlencoded", strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("websub: unable to send subscription request to %s: %v", hubURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		return fmt.Errorf("websub: hub %s refused the subscription request with status code %d: %s", hubURL, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/reader/websub"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+func parseTestConfig(t *testing.T) {
+	t.Helper()
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func TestCallbackURL(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://reader.example.org/miniflux")
+	parseTestConfig(t)
+
+	expected := "https://reader.example.org/miniflux/websub/abc123"
+	if actual := CallbackURL("abc123"); actual != expected {
+		t.Errorf(`Unexpected callback URL, got %q instead of %q`, actual, expected)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	os.Clearenv()
+	parseTestConfig(t)
+
+	var form url.Values
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf(`Unexpected method %q`, r.Method)
+		}
+
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+			t.Errorf(`Unexpected content type %q`, contentType)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf(`Unable to parse form: %v`, err)
+		}
+		form = r.PostForm
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer hub.Close()
+
+	err := Subscribe(fetcher.NewRequestBuilder(), hub.URL, "https://example.org/feed.xml", "https://reader.example.org/websub/abc123", "secret", 48*time.Hour)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := map[string]string{
+		"hub.mode":          "subscribe",
+		"hub.topic":         "https://example.org/feed.xml",
+		"hub.callback":      "https://reader.example.org/websub/abc123",
+		"hub.secret":        "secret",
+		"hub.lease_seconds": "172800",
+	}
+
+	for key, value := range expected {
+		if actual := form.Get(key); actual != value {
+			t.Errorf(`Unexpected value for %s, got %q instead of %q`, key, actual, value)
+		}
+	}
+}
+
+func TestSubscribeRefusedByHub(t *testing.T) {
+	os.Clearenv()
+	parseTestConfig(t)
+
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unknown topic", http.StatusBadRequest)
+	}))
+	defer hub.Close()
+
+	err := Subscribe(fetcher.NewRequestBuilder(), hub.URL, "https://example.org/feed.xml", "https://reader.example.org/websub/abc123", "secret", time.Hour)
+	if err == nil {
+		t.Fatal(`An error should be returned when the hub refuses the request`)
+	}
+
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "unknown topic") {
+		t.Errorf(`The error should contain the status code and the hub response, got %q`, err)
+	}
+}
+
+func TestSubscribeWithUnreachableHub(t *testing.T) {
+	os.Clearenv()
+	parseTestConfig(t)
+
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	hubURL := hub.URL
+	hub.Close()
+
+	if err := Subscribe(fetcher.NewRequestBuilder(), hubURL, "https://example.org/feed.xml", "https://reader.example.org/websub/abc123", "secret", time.Hour); err == nil {
+		t.Fatal(`An error should be returned when the hub is unreachable`)
+	}
+}
+
+func TestSubscribeThroughFeedProxy(t *testing.T) {
+	os.Clearenv()
+	parseTestConfig(t)
+
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer proxy.Close()
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithCustomFeedProxyURL(proxy.URL)
+
+	err := Subscribe(requestBuilder, "http://hub.example.org/", "https://example.org/feed.xml", "https://reader.example.org/websub/abc123", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if proxiedURL != "http://hub.example.org/" {
+		t.Errorf(`The subscription request should be sent through the proxy of the feed, got %q`, proxiedURL)
+	}
+}
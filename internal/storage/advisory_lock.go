@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// CleanupAdvisoryLockID identifies the lock held by the instance in charge of the cleanup tasks.
+const CleanupAdvisoryLockID int64 = 0x6d696e69666c7801
+
+// AdvisoryLock is a PostgreSQL session-level advisory lock held by a dedicated database connection.
+// The lock is released by PostgreSQL when the connection is closed, for example when the process stops.
+type AdvisoryLock struct {
+	id   int64
+	conn *sql.Conn
+}
+
+// TryAdvisoryLock acquires the advisory lock without waiting.
+// It returns nil if the lock is already held by another session.
+func (s *Storage) TryAdvisoryLock(id int64) (*AdvisoryLock, error) {
+	ctx := context.Background()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to get a database connection: %v`, err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, id).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf(`store: unable to acquire advisory lock %d: %v`, id, err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, nil
+	}
+
+	return &AdvisoryLock{id: id, conn: conn}, nil
+}
+
+// IsHeld returns false when the connection holding the lock has been lost.
+func (l *AdvisoryLock) IsHeld() bool {
+	return l.conn.PingContext(context.Background()) == nil
+}
+
+// Release releases the lock and the connection.
+func (l *AdvisoryLock) Release() error {
+	_, unlockErr := l.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, l.id)
+	if err := errors.Join(unlockErr, l.conn.Close()); err != nil {
+		return fmt.Errorf(`store: unable to release advisory lock %d: %v`, l.id, err)
+	}
+	return nil
+}
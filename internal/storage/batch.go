@@ -10,8 +10,11 @@ import (
 	"strconv"
 	"strings"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/urllib"
+
+	"github.com/lib/pq"
 )
 
 type BatchBuilder struct {
@@ -25,6 +28,8 @@ type BatchBuilder struct {
 func (s *Storage) NewBatchBuilder() *BatchBuilder {
 	return &BatchBuilder{
 		db: s.db,
+		// The entries of the newsletter feeds are received by email, there is nothing to fetch.
+		conditions: []string{"newsletter_token IS NULL"},
 	}
 }
 
@@ -72,6 +77,10 @@ func (b *BatchBuilder) WithLimitPerHost(limit int) *BatchBuilder {
 
 // FetchJobs retrieves a batch of jobs based on the conditions set in the builder.
 // When limitPerHost is set, it limits the number of jobs per feed hostname to prevent overwhelming a single host.
+//
+// The feeds of the batch are claimed: their next check is postponed by the job queue lock timeout,
+// and the feeds locked by a concurrent batch are skipped. This way, several instances sharing the same database
+// don't schedule the same feeds twice.
 func (b *BatchBuilder) FetchJobs() (model.JobList, error) {
 	query := `SELECT id, user_id, feed_url FROM feeds`
 
@@ -85,7 +94,48 @@ func (b *BatchBuilder) FetchJobs() (model.JobList, error) {
 		query += " LIMIT " + strconv.Itoa(b.batchSize)
 	}
 
-	rows, err := b.db.Query(query, b.args...)
+	query += " FOR UPDATE SKIP LOCKED"
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+	defer tx.Rollback()
+
+	jobs, err := b.fetchJobs(tx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jobs) > 0 {
+		feedIDs := make([]int64, len(jobs))
+		for i, job := range jobs {
+			feedIDs[i] = job.FeedID
+		}
+
+		claimQuery := `
+			UPDATE
+				feeds
+			SET
+				next_check_at = GREATEST(next_check_at, now() + $1::interval)
+			WHERE
+				id = ANY($2)
+		`
+		claimDuration := fmt.Sprintf("%d seconds", int(config.Opts.JobQueueLockTimeout().Seconds()))
+		if _, err := tx.Exec(claimQuery, claimDuration, pq.Array(feedIDs)); err != nil {
+			return nil, fmt.Errorf(`store: unable to claim batch of jobs: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf(`store: unable to commit batch of jobs: %v`, err)
+	}
+
+	return jobs, nil
+}
+
+func (b *BatchBuilder) fetchJobs(tx *sql.Tx, query string) (model.JobList, error) {
+	rows, err := tx.Query(query, b.args...)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch batch of jobs: %v`, err)
 	}
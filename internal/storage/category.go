@@ -40,8 +40,8 @@ func (s *Storage) CategoryIDExists(userID, categoryID int64) bool {
 func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND id=$2`
-	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, scraper_rules, rewrite_rules, urlrewrite_rules, block_filter_entry_rules, keep_filter_entry_rules, crawler FROM categories WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ScraperRules, &category.RewriteRules, &category.UrlRewriteRules, &category.BlockFilterEntryRules, &category.KeepFilterEntryRules, &category.Crawler)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -55,10 +55,10 @@ func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 
 // FirstCategory returns the first category for the given user.
 func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
+	query := `SELECT id, user_id, title, hide_globally, scraper_rules, rewrite_rules, urlrewrite_rules, block_filter_entry_rules, keep_filter_entry_rules, crawler FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
 
 	var category model.Category
-	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ScraperRules, &category.RewriteRules, &category.UrlRewriteRules, &category.BlockFilterEntryRules, &category.KeepFilterEntryRules, &category.Crawler)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -74,8 +74,8 @@ func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
 func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND title=$2`
-	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, scraper_rules, rewrite_rules, urlrewrite_rules, block_filter_entry_rules, keep_filter_entry_rules, crawler FROM categories WHERE user_id=$1 AND title=$2`
+	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ScraperRules, &category.RewriteRules, &category.UrlRewriteRules, &category.BlockFilterEntryRules, &category.KeepFilterEntryRules, &category.Crawler)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -89,7 +89,7 @@ func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category,
 
 // Categories returns all categories that belongs to the given user.
 func (s *Storage) Categories(userID int64) (model.Categories, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC`
+	query := `SELECT id, user_id, title, hide_globally, scraper_rules, rewrite_rules, urlrewrite_rules, block_filter_entry_rules, keep_filter_entry_rules, crawler FROM categories WHERE user_id=$1 ORDER BY title ASC`
 	rows, err := s.db.Query(query, userID)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch categories: %v`, err)
@@ -99,7 +99,7 @@ func (s *Storage) Categories(userID int64) (model.Categories, error) {
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally); err != nil {
+		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ScraperRules, &category.RewriteRules, &category.UrlRewriteRules, &category.BlockFilterEntryRules, &category.KeepFilterEntryRules, &category.Crawler); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -122,6 +122,12 @@ func (s *Storage) CategoriesWithFeedCount(userID int64) (model.Categories, error
 			c.user_id,
 			c.title,
 			c.hide_globally,
+			c.scraper_rules,
+			c.rewrite_rules,
+			c.urlrewrite_rules,
+			c.block_filter_entry_rules,
+			c.keep_filter_entry_rules,
+			c.crawler,
 			(SELECT count(*) FROM feeds WHERE feeds.category_id=c.id) AS count,
 			(SELECT count(*)
 			   FROM feeds
@@ -154,7 +160,7 @@ func (s *Storage) CategoriesWithFeedCount(userID int64) (model.Categories, error
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.FeedCount, &category.TotalUnread); err != nil {
+		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ScraperRules, &category.RewriteRules, &category.UrlRewriteRules, &category.BlockFilterEntryRules, &category.KeepFilterEntryRules, &category.Crawler, &category.FeedCount, &category.TotalUnread); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -170,25 +176,53 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryCreationRe
 
 	query := `
 		INSERT INTO categories
-			(user_id, title, hide_globally)
+			(
+				user_id,
+				title,
+				hide_globally,
+				scraper_rules,
+				rewrite_rules,
+				urlrewrite_rules,
+				block_filter_entry_rules,
+				keep_filter_entry_rules,
+				crawler
+			)
 		VALUES
-			($1, $2, $3)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING
 			id,
 			user_id,
 			title,
-			hide_globally
+			hide_globally,
+			scraper_rules,
+			rewrite_rules,
+			urlrewrite_rules,
+			block_filter_entry_rules,
+			keep_filter_entry_rules,
+			crawler
 	`
 	err := s.db.QueryRow(
 		query,
 		userID,
 		request.Title,
 		request.HideGlobally,
+		request.ScraperRules,
+		request.RewriteRules,
+		request.UrlRewriteRules,
+		request.BlockFilterEntryRules,
+		request.KeepFilterEntryRules,
+		request.Crawler,
 	).Scan(
 		&category.ID,
 		&category.UserID,
 		&category.Title,
 		&category.HideGlobally,
+		&category.ScraperRules,
+		&category.RewriteRules,
+		&category.UrlRewriteRules,
+		&category.BlockFilterEntryRules,
+		&category.KeepFilterEntryRules,
+		&category.Crawler,
 	)
 
 	if err != nil {
@@ -200,11 +234,31 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryCreationRe
 
 // UpdateCategory updates an existing category.
 func (s *Storage) UpdateCategory(category *model.Category) error {
-	query := `UPDATE categories SET title=$1, hide_globally=$2 WHERE id=$3 AND user_id=$4`
+	query := `
+		UPDATE
+			categories
+		SET
+			title=$1,
+			hide_globally=$2,
+			scraper_rules=$3,
+			rewrite_rules=$4,
+			urlrewrite_rules=$5,
+			block_filter_entry_rules=$6,
+			keep_filter_entry_rules=$7,
+			crawler=$8
+		WHERE
+			id=$9 AND user_id=$10
+	`
 	_, err := s.db.Exec(
 		query,
 		category.Title,
 		category.HideGlobally,
+		category.ScraperRules,
+		category.RewriteRules,
+		category.UrlRewriteRules,
+		category.BlockFilterEntryRules,
+		category.KeepFilterEntryRules,
+		category.Crawler,
 		category.ID,
 		category.UserID,
 	)
@@ -100,6 +100,13 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 // createEntry add a new entry.
 func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 	truncatedTitle, truncatedContent := truncateTitleAndContentForTSVectorField(entry.Title, entry.Content)
+
+	// Action rules may have already changed the status of the new entry.
+	status := entry.Status
+	if status == "" {
+		status = model.EntryStatusUnread
+	}
+
 	query := `
 		INSERT INTO entries
 			(
@@ -115,7 +122,14 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				reading_time,
 				changed_at,
 				document_vectors,
-				tags
+				tags,
+				status,
+				starred,
+				url_fingerprint,
+				content_fingerprint,
+				duplicate_of_entry_id,
+				language,
+				podcast
 			)
 		VALUES
 			(
@@ -131,7 +145,14 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$10,
 				now(),
 				setweight(to_tsvector($11), 'A') || setweight(to_tsvector($12), 'B'),
-				$13
+				$13,
+				$14,
+				$15,
+				$16,
+				$17,
+				$18,
+				$19,
+				$20
 			)
 		RETURNING
 			id, status, created_at, changed_at
@@ -151,6 +172,13 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		truncatedTitle,
 		truncatedContent,
 		pq.Array(entry.Tags),
+		status,
+		entry.Starred,
+		entry.URLFingerprint,
+		entry.ContentFingerprint,
+		sql.NullInt64{Int64: entry.DuplicateOfEntryID, Valid: entry.DuplicateOfEntryID > 0},
+		entry.Language,
+		entry.Podcast,
 	).Scan(
 		&entry.ID,
 		&entry.Status,
@@ -189,7 +217,11 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			author=$5,
 			reading_time=$6,
 			document_vectors = setweight(to_tsvector($7), 'A') || setweight(to_tsvector($8), 'B'),
-			tags=$12
+			tags=$12,
+			url_fingerprint=$13,
+			content_fingerprint=$14,
+			language=$15,
+			podcast=$16
 		WHERE
 			user_id=$9 AND feed_id=$10 AND hash=$11
 		RETURNING
@@ -209,6 +241,10 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(entry.Tags),
+		entry.URLFingerprint,
+		entry.ContentFingerprint,
+		entry.Language,
+		entry.Podcast,
 	).Scan(&entry.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
@@ -236,6 +272,88 @@ func (s *Storage) entryExists(tx *sql.Tx, entry *model.Entry) (bool, error) {
 	return result, nil
 }
 
+// DuplicateEntryID returns the ID of the first occurrence of an entry already published by another feed of the user.
+// Entries are matched by URL or content fingerprint, zero is returned when there is no duplicate.
+func (s *Storage) DuplicateEntryID(userID, feedID int64, urlFingerprint, contentFingerprint string) (int64, error) {
+	if urlFingerprint == "" && contentFingerprint == "" {
+		return 0, nil
+	}
+
+	query := `
+		SELECT
+			coalesce(duplicate_of_entry_id, id)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND
+			feed_id <> $2 AND
+			((url_fingerprint <> '' AND url_fingerprint=$3) OR (content_fingerprint <> '' AND content_fingerprint=$4))
+		ORDER BY
+			id ASC
+		LIMIT 1
+	`
+
+	var entryID int64
+	err := s.db.QueryRow(query, userID, feedID, urlFingerprint, contentFingerprint).Scan(&entryID)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to find duplicate entry: %v`, err)
+	}
+
+	return entryID, nil
+}
+
+// GetDuplicateEntries returns the duplicates of the given entries, grouped by the ID of their first occurrence.
+// Only the fields needed to link to the duplicates are fetched.
+func (s *Storage) GetDuplicateEntries(entryIDs []int64) (map[int64]model.Entries, error) {
+	query := `
+		SELECT
+			e.id,
+			e.user_id,
+			e.feed_id,
+			e.duplicate_of_entry_id,
+			e.title,
+			f.title
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		WHERE
+			e.duplicate_of_entry_id = ANY($1) AND e.status <> $2
+		ORDER BY e.id ASC
+	`
+
+	rows, err := s.db.Query(query, pq.Array(entryIDs), model.EntryStatusRemoved)
+	if err != nil {
+		return nil, fmt.Errorf("store: unable to fetch duplicate entries: %w", err)
+	}
+	defer rows.Close()
+
+	duplicatesMap := make(map[int64]model.Entries)
+	for rows.Next() {
+		entry := model.NewEntry()
+		err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.FeedID,
+			&entry.DuplicateOfEntryID,
+			&entry.Title,
+			&entry.Feed.Title,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("store: unable to scan duplicate entry row: %w", err)
+		}
+
+		entry.Feed.ID = entry.FeedID
+		entry.Feed.UserID = entry.UserID
+		duplicatesMap[entry.DuplicateOfEntryID] = append(duplicatesMap[entry.DuplicateOfEntryID], entry)
+	}
+
+	return duplicatesMap, nil
+}
+
 func (s *Storage) IsNewEntry(feedID int64, entryHash string) bool {
 	var result bool
 	s.db.QueryRow(`SELECT true FROM entries WHERE feed_id=$1 AND hash=$2 LIMIT 1`, feedID, entryHash).Scan(&result)
@@ -261,6 +379,26 @@ func (s *Storage) GetReadTime(feedID int64, entryHash string) int {
 	return result
 }
 
+// GetPodcastEpisode returns the podcast metadata stored for an entry, or nil when there is none.
+func (s *Storage) GetPodcastEpisode(feedID int64, entryHash string) *model.PodcastEpisode {
+	var podcastEpisode *model.PodcastEpisode
+
+	// Note: This query uses entries_feed_id_hash_key index
+	s.db.QueryRow(
+		`SELECT
+			podcast
+		FROM
+			entries
+		WHERE
+			feed_id=$1 AND
+			hash=$2
+		`,
+		feedID,
+		entryHash,
+	).Scan(&podcastEpisode)
+	return podcastEpisode
+}
+
 // cleanupRemovedEntriesNotInFeed deletes from the database entries marked as "removed" and not visible anymore in the feed.
 func (s *Storage) cleanupRemovedEntriesNotInFeed(feedID int64, entryHashes []string) error {
 	query := `
@@ -334,8 +472,35 @@ func (s *Storage) ClearRemovedEntriesContent(limit int) (int64, error) {
 }
 
 // RefreshFeedEntries updates feed entries while refreshing a feed.
-func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, err error) {
-	entryHashes := make([]string, 0, len(entries))
+func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (model.Entries, error) {
+	newEntries, entryHashes, err := s.storeFeedEntries(userID, feedID, entries, updateExistingEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := s.cleanupRemovedEntriesNotInFeed(feedID, entryHashes); err != nil {
+			slog.Error("Unable to cleanup removed entries",
+				slog.Int64("user_id", userID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", err),
+			)
+		}
+	}()
+
+	return newEntries, nil
+}
+
+// StorePartialFeedEntries creates or updates entries that are only a part of the feed,
+// like the ones delivered by a WebSub hub or found in archive pages.
+// Unlike RefreshFeedEntries, removed entries missing from the list are kept.
+func (s *Storage) StorePartialFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (model.Entries, error) {
+	newEntries, _, err := s.storeFeedEntries(userID, feedID, entries, updateExistingEntries)
+	return newEntries, err
+}
+
+func (s *Storage) storeFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, entryHashes []string, err error) {
+	entryHashes = make([]string, 0, len(entries))
 
 	for _, entry := range entries {
 		entry.UserID = userID
@@ -343,15 +508,15 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		tx, err := s.db.Begin()
 		if err != nil {
-			return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+			return nil, nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
 		}
 
 		entryExists, err := s.entryExists(tx, entry)
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				return nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
+				return nil, nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
 
 		if entryExists {
@@ -367,29 +532,19 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				return nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
+				return nil, nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := tx.Commit(); err != nil {
-			return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+			return nil, nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
 
 		entryHashes = append(entryHashes, entry.Hash)
 	}
 
-	go func() {
-		if err := s.cleanupRemovedEntriesNotInFeed(feedID, entryHashes); err != nil {
-			slog.Error("Unable to cleanup removed entries",
-				slog.Int64("user_id", userID),
-				slog.Int64("feed_id", feedID),
-				slog.Any("error", err),
-			)
-		}
-	}()
-
-	return newEntries, nil
+	return newEntries, entryHashes, nil
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the interval (24h minimum).
@@ -172,7 +172,7 @@ func NewEntryPaginationBuilder(store *Storage, userID, entryID int64, order, dir
 	return &EntryPaginationBuilder{
 		store:      store,
 		args:       []any{userID, "removed"},
-		conditions: []string{"e.user_id = $1", "e.status <> $2"},
+		conditions: []string{"e.user_id = $1", "e.status <> $2", notHiddenDuplicateCondition},
 		entryID:    entryID,
 		order:      order,
 		direction:  direction,
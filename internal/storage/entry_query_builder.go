@@ -16,6 +16,11 @@ import (
 	"miniflux.app/v2/internal/timezone"
 )
 
+// notHiddenDuplicateCondition excludes the duplicate entries while the policy of their user hides or groups them,
+// they are visible again when the user switches to another policy.
+const notHiddenDuplicateCondition = `(e.duplicate_of_entry_id IS NULL OR
+	(SELECT du.duplicate_entries_policy FROM users du WHERE du.id=e.user_id) NOT IN ('hide', 'group'))`
+
 // EntryQueryBuilder builds a SQL query to fetch entries.
 type EntryQueryBuilder struct {
 	store           *Storage
@@ -25,6 +30,10 @@ type EntryQueryBuilder struct {
 	limit           int
 	offset          int
 	fetchEnclosures bool
+	fetchDuplicates bool
+
+	// Hidden duplicate entries are excluded from the results unless they are selected by ID or share code.
+	includeDuplicates bool
 }
 
 // WithEnclosures fetches enclosures for each entry.
@@ -33,6 +42,12 @@ func (e *EntryQueryBuilder) WithEnclosures() *EntryQueryBuilder {
 	return e
 }
 
+// WithDuplicateEntries fetches the duplicates grouped under each entry.
+func (e *EntryQueryBuilder) WithDuplicateEntries() *EntryQueryBuilder {
+	e.fetchDuplicates = true
+	return e
+}
+
 // WithSearchQuery adds full-text search query to the condition.
 func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
 	if query != "" {
@@ -109,6 +124,7 @@ func (e *EntryQueryBuilder) AfterEntryID(entryID int64) *EntryQueryBuilder {
 func (e *EntryQueryBuilder) WithEntryIDs(entryIDs []int64) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, fmt.Sprintf("e.id = ANY($%d)", len(e.args)+1))
 	e.args = append(e.args, pq.Int64Array(entryIDs))
+	e.includeDuplicates = true
 	return e
 }
 
@@ -117,6 +133,7 @@ func (e *EntryQueryBuilder) WithEntryID(entryID int64) *EntryQueryBuilder {
 	if entryID != 0 {
 		e.conditions = append(e.conditions, "e.id = $"+strconv.Itoa(len(e.args)+1))
 		e.args = append(e.args, entryID)
+		e.includeDuplicates = true
 	}
 	return e
 }
@@ -181,6 +198,7 @@ func (e *EntryQueryBuilder) WithoutStatus(status string) *EntryQueryBuilder {
 func (e *EntryQueryBuilder) WithShareCode(shareCode string) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, "e.share_code = $"+strconv.Itoa(len(e.args)+1))
 	e.args = append(e.args, shareCode)
+	e.includeDuplicates = true
 	return e
 }
 
@@ -276,6 +294,9 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			e.created_at,
 			e.changed_at,
 			e.tags,
+			coalesce(e.duplicate_of_entry_id, 0),
+			e.language,
+			e.podcast,
 			f.title as feed_title,
 			f.feed_url,
 			f.site_url,
@@ -344,6 +365,9 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.CreatedAt,
 			&entry.ChangedAt,
 			pq.Array(&entry.Tags),
+			&entry.DuplicateOfEntryID,
+			&entry.Language,
+			&entry.Podcast,
 			&entry.Feed.Title,
 			&entry.Feed.FeedURL,
 			&entry.Feed.SiteURL,
@@ -406,6 +430,19 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 		}
 	}
 
+	if e.fetchDuplicates && len(entryIDs) > 0 {
+		duplicates, err := e.store.GetDuplicateEntries(entryIDs)
+		if err != nil {
+			return nil, fmt.Errorf("store: unable to fetch duplicate entries: %w", err)
+		}
+
+		for entryID, entryDuplicates := range duplicates {
+			if entry, exists := entryMap[entryID]; exists {
+				entry.Duplicates = entryDuplicates
+			}
+		}
+	}
+
 	return entries, nil
 }
 
@@ -444,7 +481,11 @@ func (e *EntryQueryBuilder) GetEntryIDs() ([]int64, error) {
 }
 
 func (e *EntryQueryBuilder) buildCondition() string {
-	return strings.Join(e.conditions, " AND ")
+	conditions := e.conditions
+	if !e.includeDuplicates {
+		conditions = append(conditions[:len(conditions):len(conditions)], notHiddenDuplicateCondition)
+	}
+	return strings.Join(conditions, " AND ")
 }
 
 func (e *EntryQueryBuilder) buildSorting() string {
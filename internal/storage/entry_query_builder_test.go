@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntryQueryBuilderExcludesDuplicates(t *testing.T) {
+	builder := NewEntryQueryBuilder(nil, 1)
+	builder.WithFeedID(2)
+
+	if condition := builder.buildCondition(); !strings.HasSuffix(condition, notHiddenDuplicateCondition) {
+		t.Errorf(`The duplicates should be excluded from the entry lists, got %q`, condition)
+	}
+
+	// Building the condition twice must not add the exclusion to the conditions of the builder.
+	if len(builder.conditions) != 2 {
+		t.Errorf(`Unexpected conditions %v`, builder.conditions)
+	}
+
+	builder = NewEntryQueryBuilder(nil, 1)
+	builder.WithEntryID(3)
+
+	if condition := builder.buildCondition(); strings.Contains(condition, "duplicate_of_entry_id") {
+		t.Errorf(`A duplicate selected by ID should be returned, got %q`, condition)
+	}
+
+	builder = NewAnonymousQueryBuilder(nil)
+	builder.WithShareCode("code")
+
+	if condition := builder.buildCondition(); strings.Contains(condition, "duplicate_of_entry_id") {
+		t.Errorf(`A shared duplicate should be returned, got %q`, condition)
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type byStateAndName struct{ f model.Feeds }
@@ -214,6 +216,43 @@ func (s *Storage) WeeklyFeedEntryCount(userID, feedID int64) (int, error) {
 	return weeklyCount, nil
 }
 
+// FeedPublicationHistogram returns when the entries of the feed have been published during the last weeks, by hour of the week.
+func (s *Storage) FeedPublicationHistogram(userID, feedID int64) (*model.PublicationHistogram, error) {
+	query := `
+		SELECT
+			CAST(EXTRACT(dow FROM published_at AT TIME ZONE 'UTC') AS int) AS weekday,
+			CAST(EXTRACT(hour FROM published_at AT TIME ZONE 'UTC') AS int) AS hour,
+			count(*),
+			CAST(avg(EXTRACT(minute FROM published_at AT TIME ZONE 'UTC')) AS int)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND
+			feed_id=$2 AND
+			published_at >= now() - interval '4 weeks' AND
+			published_at <= now()
+		GROUP BY
+			weekday, hour
+	`
+
+	rows, err := s.db.Query(query, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch publication histogram for feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	histogram := &model.PublicationHistogram{}
+	for rows.Next() {
+		var weekday, hour, count, averageMinute int
+		if err := rows.Scan(&weekday, &hour, &count, &averageMinute); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch publication histogram row: %v`, err)
+		}
+		histogram.Add(time.Weekday(weekday), hour, count, averageMinute)
+	}
+
+	return histogram, nil
+}
+
 // FeedByID returns a feed by the ID.
 func (s *Storage) FeedByID(userID, feedID int64) (*model.Feed, error) {
 	builder := NewFeedQueryBuilder(s, userID)
@@ -263,10 +302,23 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			webhook_url,
 			disable_http2,
 			description,
-			proxy_url
+			proxy_url,
+			entry_action_rules,
+			webpage_item_selector,
+			webpage_title_selector,
+			webpage_link_selector,
+			webpage_date_selector,
+			webpage_content_selector,
+			skip_hours,
+			skip_days,
+			ignore_skip_hints,
+			custom_headers,
+			client_certificate,
+			client_private_key,
+			newsletter_token
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42, NULLIF($43, ''))
 		RETURNING
 			id
 	`
@@ -302,6 +354,19 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.DisableHTTP2,
 		feed.Description,
 		feed.ProxyURL,
+		feed.EntryActionRules,
+		feed.WebPageItemSelector,
+		feed.WebPageTitleSelector,
+		feed.WebPageLinkSelector,
+		feed.WebPageDateSelector,
+		feed.WebPageContentSelector,
+		pq.Array(feed.SkipHours),
+		pq.Array(feed.SkipDays),
+		feed.IgnoreSkipHints,
+		feed.CustomHeaders,
+		feed.ClientCertificate,
+		feed.ClientPrivateKey,
+		feed.NewsletterToken,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -384,9 +449,22 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			ntfy_topic=$35,
 			pushover_enabled=$36,
 			pushover_priority=$37,
-			proxy_url=$38
+			proxy_url=$38,
+			entry_action_rules=$39,
+			webpage_item_selector=$40,
+			webpage_title_selector=$41,
+			webpage_link_selector=$42,
+			webpage_date_selector=$43,
+			webpage_content_selector=$44,
+			skip_hours=$45,
+			skip_days=$46,
+			ignore_skip_hints=$47,
+			proposed_feed_url=$48,
+			custom_headers=$49,
+			client_certificate=$50,
+			client_private_key=$51
 		WHERE
-			id=$39 AND user_id=$40
+			id=$52 AND user_id=$53
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -427,6 +505,19 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.PushoverEnabled,
 		feed.PushoverPriority,
 		feed.ProxyURL,
+		feed.EntryActionRules,
+		feed.WebPageItemSelector,
+		feed.WebPageTitleSelector,
+		feed.WebPageLinkSelector,
+		feed.WebPageDateSelector,
+		feed.WebPageContentSelector,
+		pq.Array(feed.SkipHours),
+		pq.Array(feed.SkipDays),
+		feed.IgnoreSkipHints,
+		feed.ProposedFeedURL,
+		feed.CustomHeaders,
+		feed.ClientCertificate,
+		feed.ClientPrivateKey,
 		feed.ID,
 		feed.UserID,
 	)
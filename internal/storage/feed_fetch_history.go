@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// CreateFeedFetch records a refresh attempt in the fetch history of the feed.
+func (s *Storage) CreateFeedFetch(feedFetch *model.FeedFetch) error {
+	query := `
+		INSERT INTO feed_fetch_history
+			(feed_id, fetched_at, status_code, response_time_ms, response_size, not_modified, new_entries, error_msg, previous_feed_url, new_feed_url)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING
+			id
+	`
+	err := s.db.QueryRow(
+		query,
+		feedFetch.FeedID,
+		feedFetch.FetchedAt,
+		feedFetch.StatusCode,
+		feedFetch.ResponseTimeMs,
+		feedFetch.ResponseSize,
+		feedFetch.NotModified,
+		feedFetch.NewEntries,
+		feedFetch.ErrorMsg,
+		feedFetch.PreviousFeedURL,
+		feedFetch.NewFeedURL,
+	).Scan(&feedFetch.ID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create feed fetch for feed #%d: %v`, feedFetch.FeedID, err)
+	}
+
+	return nil
+}
+
+// FeedFetchHistory returns the latest refresh attempts of a feed, the most recent first.
+func (s *Storage) FeedFetchHistory(userID, feedID int64, limit int) (model.FeedFetches, error) {
+	query := `
+		SELECT
+			h.id,
+			h.feed_id,
+			h.fetched_at,
+			h.status_code,
+			h.response_time_ms,
+			h.response_size,
+			h.not_modified,
+			h.new_entries,
+			h.error_msg,
+			h.previous_feed_url,
+			h.new_feed_url
+		FROM
+			feed_fetch_history h
+		JOIN
+			feeds f ON f.id=h.feed_id
+		WHERE
+			h.feed_id=$1 AND f.user_id=$2
+		ORDER BY
+			h.fetched_at DESC, h.id DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, feedID, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch history of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	history := make(model.FeedFetches, 0)
+	for rows.Next() {
+		var feedFetch model.FeedFetch
+		if err := rows.Scan(
+			&feedFetch.ID,
+			&feedFetch.FeedID,
+			&feedFetch.FetchedAt,
+			&feedFetch.StatusCode,
+			&feedFetch.ResponseTimeMs,
+			&feedFetch.ResponseSize,
+			&feedFetch.NotModified,
+			&feedFetch.NewEntries,
+			&feedFetch.ErrorMsg,
+			&feedFetch.PreviousFeedURL,
+			&feedFetch.NewFeedURL,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed fetch row: %v`, err)
+		}
+		history = append(history, &feedFetch)
+	}
+
+	return history, nil
+}
+
+// CleanFeedFetchHistory removes the oldest refresh attempts to keep at most size attempts per feed.
+func (s *Storage) CleanFeedFetchHistory(size int) (int64, error) {
+	query := `
+		DELETE FROM
+			feed_fetch_history
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM (
+					SELECT
+						id,
+						row_number() OVER (PARTITION BY feed_id ORDER BY fetched_at DESC, id DESC) AS position
+					FROM
+						feed_fetch_history
+				) AS ranked_history
+				WHERE
+					position > $1
+			)
+	`
+	result, err := s.db.Exec(query, size)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to clean feed fetch history: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of removed feed fetches: %v`, err)
+	}
+
+	return count, nil
+}
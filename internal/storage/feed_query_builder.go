@@ -11,6 +11,8 @@ import (
 
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/timezone"
+
+	"github.com/lib/pq"
 )
 
 // FeedQueryBuilder builds a SQL query to fetch feeds.
@@ -165,6 +167,12 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.category_id,
 			c.title as category_title,
 			c.hide_globally as category_hidden,
+			c.scraper_rules as category_scraper_rules,
+			c.rewrite_rules as category_rewrite_rules,
+			c.urlrewrite_rules as category_urlrewrite_rules,
+			c.block_filter_entry_rules as category_block_filter_entry_rules,
+			c.keep_filter_entry_rules as category_keep_filter_entry_rules,
+			c.crawler as category_crawler,
 			fi.icon_id,
 			i.external_id,
 			u.timezone,
@@ -176,7 +184,21 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.ntfy_topic,
 			f.pushover_enabled,
 			f.pushover_priority,
-			f.proxy_url
+			f.proxy_url,
+			f.entry_action_rules,
+			f.webpage_item_selector,
+			f.webpage_title_selector,
+			f.webpage_link_selector,
+			f.webpage_date_selector,
+			f.webpage_content_selector,
+			f.skip_hours,
+			f.skip_days,
+			f.ignore_skip_hints,
+			f.proposed_feed_url,
+			f.custom_headers,
+			f.client_certificate,
+			f.client_private_key,
+			coalesce(f.newsletter_token, '')
 		FROM
 			feeds f
 		LEFT JOIN
@@ -246,6 +268,12 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.Category.ID,
 			&feed.Category.Title,
 			&feed.Category.HideGlobally,
+			&feed.Category.ScraperRules,
+			&feed.Category.RewriteRules,
+			&feed.Category.UrlRewriteRules,
+			&feed.Category.BlockFilterEntryRules,
+			&feed.Category.KeepFilterEntryRules,
+			&feed.Category.Crawler,
 			&iconID,
 			&externalIconID,
 			&tz,
@@ -258,6 +286,20 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.PushoverEnabled,
 			&feed.PushoverPriority,
 			&feed.ProxyURL,
+			&feed.EntryActionRules,
+			&feed.WebPageItemSelector,
+			&feed.WebPageTitleSelector,
+			&feed.WebPageLinkSelector,
+			&feed.WebPageDateSelector,
+			&feed.WebPageContentSelector,
+			pq.Array(&feed.SkipHours),
+			pq.Array(&feed.SkipDays),
+			&feed.IgnoreSkipHints,
+			&feed.ProposedFeedURL,
+			&feed.CustomHeaders,
+			&feed.ClientCertificate,
+			&feed.ClientPrivateKey,
+			&feed.NewsletterToken,
 		)
 
 		if err != nil {
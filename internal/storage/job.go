@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// EnqueueJobs adds the refresh jobs to the persistent queue.
+// A feed has at most one refresh job in the queue: enqueuing it again raises the priority of the pending job,
+// and makes it available immediately if it was waiting for a retry.
+func (s *Storage) EnqueueJobs(jobs model.JobList, priority int) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	userIDs := make([]int64, len(jobs))
+	feedIDs := make([]int64, len(jobs))
+	for i, job := range jobs {
+		userIDs[i] = job.UserID
+		feedIDs[i] = job.FeedID
+	}
+
+	query := `
+		INSERT INTO jobs
+			(user_id, feed_id, priority)
+		SELECT
+			user_id, feed_id, $3
+		FROM
+			unnest($1::bigint[], $2::bigint[]) AS t(user_id, feed_id)
+		ON CONFLICT (feed_id, kind) DO UPDATE SET
+			priority = GREATEST(jobs.priority, EXCLUDED.priority),
+			available_at = LEAST(jobs.available_at, EXCLUDED.available_at)
+		WHERE
+			jobs.status = $4
+	`
+
+	if _, err := s.db.Exec(query, pq.Array(userIDs), pq.Array(feedIDs), priority, model.JobStatusPending); err != nil {
+		return fmt.Errorf(`store: unable to enqueue jobs: %v`, err)
+	}
+
+	return nil
+}
+
+// EnqueueBackfillJob adds a job walking the archive pages of the feed, starting with the given page.
+// Nothing is done if the feed already has a backfill job in the queue.
+func (s *Storage) EnqueueBackfillJob(userID, feedID int64, archivePageURL string) error {
+	query := `
+		INSERT INTO jobs
+			(user_id, feed_id, kind, archive_page_url, priority)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (feed_id, kind) DO NOTHING
+	`
+
+	if _, err := s.db.Exec(query, userID, feedID, model.JobKindBackfill, archivePageURL, model.JobPriorityBackground); err != nil {
+		return fmt.Errorf(`store: unable to enqueue backfill job for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// ClaimJobs locks up to limit available pending jobs for the given worker, the highest priority and oldest jobs first.
+// Jobs locked by another transaction are skipped, so several workers can claim jobs concurrently.
+func (s *Storage) ClaimJobs(workerName string, limit int) (model.JobList, error) {
+	query := `
+		UPDATE
+			jobs
+		SET
+			status=$1,
+			attempts=attempts + 1,
+			locked_by=$2,
+			locked_at=now()
+		FROM
+			feeds
+		WHERE
+			jobs.feed_id=feeds.id AND
+			jobs.id IN (
+				SELECT
+					id
+				FROM
+					jobs
+				WHERE
+					status=$3 AND
+					available_at <= now()
+				ORDER BY
+					priority DESC, created_at ASC, id ASC
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			jobs.id, jobs.user_id, jobs.feed_id, feeds.feed_url, jobs.kind, jobs.archive_page_url, jobs.priority, jobs.attempts
+	`
+
+	rows, err := s.db.Query(query, model.JobStatusRunning, workerName, model.JobStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to claim jobs: %v`, err)
+	}
+	defer rows.Close()
+
+	jobs := make(model.JobList, 0, limit)
+	for rows.Next() {
+		var job model.Job
+		if err := rows.Scan(&job.ID, &job.UserID, &job.FeedID, &job.FeedURL, &job.Kind, &job.ArchivePageURL, &job.Priority, &job.Attempts); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch claimed job: %v`, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: error iterating on claimed jobs: %v`, err)
+	}
+
+	return jobs, nil
+}
+
+// CompleteJob removes a processed job from the queue.
+func (s *Storage) CompleteJob(jobID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM jobs WHERE id=$1`, jobID); err != nil {
+		return fmt.Errorf(`store: unable to remove job #%d: %v`, jobID, err)
+	}
+	return nil
+}
+
+// ReleaseJob puts back in the queue a job interrupted before its completion.
+// The interrupted attempt is not counted.
+func (s *Storage) ReleaseJob(jobID int64) error {
+	query := `
+		UPDATE
+			jobs
+		SET
+			status=$1,
+			attempts=greatest(attempts - 1, 0),
+			locked_by='',
+			locked_at=NULL
+		WHERE
+			id=$2 AND status=$3
+	`
+	if _, err := s.db.Exec(query, model.JobStatusPending, jobID, model.JobStatusRunning); err != nil {
+		return fmt.Errorf(`store: unable to release job #%d: %v`, jobID, err)
+	}
+	return nil
+}
+
+// RetryJob puts back in the queue a job whose processing failed, it can be claimed again after the given delay.
+// The job is dropped once it has been attempted maxAttempts times, dropped is true in this case.
+func (s *Storage) RetryJob(jobID int64, delay time.Duration, maxAttempts int) (dropped bool, err error) {
+	result, err := s.db.Exec(`DELETE FROM jobs WHERE id=$1 AND attempts >= $2`, jobID, maxAttempts)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to drop job #%d: %v`, jobID, err)
+	}
+
+	if count, _ := result.RowsAffected(); count > 0 {
+		return true, nil
+	}
+
+	query := `
+		UPDATE
+			jobs
+		SET
+			status=$1,
+			locked_by='',
+			locked_at=NULL,
+			available_at=now() + $2::interval
+		WHERE
+			id=$3 AND status=$4
+	`
+	retryDelayInterval := fmt.Sprintf("%d seconds", int(delay.Seconds()))
+	if _, err := s.db.Exec(query, model.JobStatusPending, retryDelayInterval, jobID, model.JobStatusRunning); err != nil {
+		return false, fmt.Errorf(`store: unable to retry job #%d: %v`, jobID, err)
+	}
+
+	return false, nil
+}
+
+// RequeueStaleJobs puts back in the queue the jobs locked for too long, usually because the process was stopped.
+// The jobs that have already been attempted maxAttempts times are dropped.
+func (s *Storage) RequeueStaleJobs(lockTimeout time.Duration, maxAttempts int) (requeued, dropped int64, err error) {
+	lockTimeoutInterval := fmt.Sprintf("%d seconds", int(lockTimeout.Seconds()))
+
+	query := `
+		DELETE FROM
+			jobs
+		WHERE
+			status=$1 AND
+			locked_at < now() - $2::interval AND
+			attempts >= $3
+	`
+	result, err := s.db.Exec(query, model.JobStatusRunning, lockTimeoutInterval, maxAttempts)
+	if err != nil {
+		return 0, 0, fmt.Errorf(`store: unable to drop stale jobs: %v`, err)
+	}
+	dropped, _ = result.RowsAffected()
+
+	query = `
+		UPDATE
+			jobs
+		SET
+			status=$1,
+			locked_by='',
+			locked_at=NULL
+		WHERE
+			status=$2 AND
+			locked_at < now() - $3::interval
+	`
+	result, err = s.db.Exec(query, model.JobStatusPending, model.JobStatusRunning, lockTimeoutInterval)
+	if err != nil {
+		return 0, dropped, fmt.Errorf(`store: unable to requeue stale jobs: %v`, err)
+	}
+	requeued, _ = result.RowsAffected()
+
+	return requeued, dropped, nil
+}
+
+// JobQueueStats returns the number of pending and running jobs.
+func (s *Storage) JobQueueStats() (*model.JobQueueStats, error) {
+	query := `
+		SELECT
+			count(*) FILTER (WHERE status=$1),
+			count(*) FILTER (WHERE status=$1 AND priority >= $2),
+			count(*) FILTER (WHERE status=$3)
+		FROM
+			jobs
+	`
+
+	stats := &model.JobQueueStats{}
+	err := s.db.QueryRow(query, model.JobStatusPending, model.JobPriorityManual, model.JobStatusRunning).Scan(
+		&stats.Pending,
+		&stats.PendingManual,
+		&stats.Running,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch job queue stats: %v`, err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+)
+
+// UserNewsletterToken returns the token of the personal newsletter address of the user, it is generated on first use.
+func (s *Storage) UserNewsletterToken(userID int64) (string, error) {
+	query := `
+		UPDATE
+			users
+		SET
+			newsletter_token=coalesce(newsletter_token, $2)
+		WHERE
+			id=$1
+		RETURNING
+			newsletter_token
+	`
+	var token string
+	if err := s.db.QueryRow(query, userID, crypto.GenerateRandomStringHex(16)).Scan(&token); err != nil {
+		return "", fmt.Errorf(`store: unable to fetch newsletter token of user #%d: %v`, userID, err)
+	}
+
+	return token, nil
+}
+
+// UserIDByNewsletterToken returns the user owning the personal newsletter address, zero when there is none.
+func (s *Storage) UserIDByNewsletterToken(token string) (int64, error) {
+	var userID int64
+	err := s.db.QueryRow(`SELECT id FROM users WHERE newsletter_token=$1`, token).Scan(&userID)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to find user by newsletter token: %v`, err)
+	}
+
+	return userID, nil
+}
+
+// FeedByNewsletterToken returns the feed receiving the messages sent to the given newsletter address, or nil when there is none.
+func (s *Storage) FeedByNewsletterToken(token string) (*model.Feed, error) {
+	var userID, feedID int64
+	err := s.db.QueryRow(`SELECT user_id, id FROM feeds WHERE newsletter_token=$1`, token).Scan(&userID, &feedID)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to find feed by newsletter token: %v`, err)
+	}
+
+	return s.FeedByID(userID, feedID)
+}
+
+// NewsletterFeedByURL returns the newsletter feed of the user with the given URL, or nil when there is none.
+func (s *Storage) NewsletterFeedByURL(userID int64, feedURL string) (*model.Feed, error) {
+	var feedID int64
+	query := `SELECT id FROM feeds WHERE user_id=$1 AND feed_url=$2 AND newsletter_token IS NOT NULL`
+	err := s.db.QueryRow(query, userID, feedURL).Scan(&feedID)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to find newsletter feed %q: %v`, feedURL, err)
+	}
+
+	return s.FeedByID(userID, feedID)
+}
+
+// CountNewsletterSenderFeeds returns the number of newsletter feeds of the user created for a sender,
+// the feeds having an address of the newsletter domain are not counted.
+func (s *Storage) CountNewsletterSenderFeeds(userID int64, newsletterDomain string) (int, error) {
+	query := `
+		SELECT
+			count(*)
+		FROM
+			feeds
+		WHERE
+			user_id=$1 AND newsletter_token IS NOT NULL AND right(feed_url, length($2)) <> $2
+	`
+	var count int
+	if err := s.db.QueryRow(query, userID, "@"+newsletterDomain).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to count newsletter sender feeds of user #%d: %v`, userID, err)
+	}
+
+	return count, nil
+}
+
+// StoreNewsletterEntry stores a newsletter entry and its attachments in the same transaction.
+// It returns false when the entry already exists, a message delivered twice is stored once.
+func (s *Storage) StoreNewsletterEntry(userID, feedID int64, entry *model.Entry, attachments []*model.NewsletterAttachment) (bool, error) {
+	entry.UserID = userID
+	entry.FeedID = feedID
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+	defer tx.Rollback()
+
+	entryExists, err := s.entryExists(tx, entry)
+	if err != nil {
+		return false, err
+	}
+
+	if entryExists {
+		return false, nil
+	}
+
+	if err := s.createEntry(tx, entry); err != nil {
+		return false, err
+	}
+
+	query := `
+		INSERT INTO newsletter_attachments
+			(entry_id, token, filename, mime_type, content)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id, created_at
+	`
+	for _, attachment := range attachments {
+		attachment.EntryID = entry.ID
+		if err := tx.QueryRow(
+			query,
+			attachment.EntryID,
+			attachment.Token,
+			attachment.Filename,
+			attachment.MimeType,
+			attachment.Content,
+		).Scan(&attachment.ID, &attachment.CreatedAt); err != nil {
+			return false, fmt.Errorf(`store: unable to create newsletter attachment for entry #%d: %v`, entry.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return true, nil
+}
+
+// NewsletterAttachmentByToken returns the attachment identified by the given token, or nil when there is none.
+func (s *Storage) NewsletterAttachmentByToken(token string) (*model.NewsletterAttachment, error) {
+	var attachment model.NewsletterAttachment
+	query := `
+		SELECT
+			id,
+			entry_id,
+			token,
+			filename,
+			mime_type,
+			content,
+			created_at
+		FROM
+			newsletter_attachments
+		WHERE
+			token=$1
+	`
+	err := s.db.QueryRow(query, token).Scan(
+		&attachment.ID,
+		&attachment.EntryID,
+		&attachment.Token,
+		&attachment.Filename,
+		&attachment.MimeType,
+		&attachment.Content,
+		&attachment.CreatedAt,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch newsletter attachment: %v`, err)
+	}
+
+	return &attachment, nil
+}
+
+// DeleteRemovedEntriesNewsletterAttachments deletes the newsletter attachments of the entries marked as "removed".
+func (s *Storage) DeleteRemovedEntriesNewsletterAttachments() (int64, error) {
+	query := `
+		DELETE FROM
+			newsletter_attachments
+		WHERE
+			entry_id IN (SELECT id FROM entries WHERE status=$1)
+	`
+	result, err := s.db.Exec(query, model.EntryStatusRemoved)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to delete newsletter attachments from removed entries: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of rows affected while deleting newsletter attachments from removed entries: %v`, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+)
+
+var ErrPublicFeedNotFound = fmt.Errorf("store: public feed not found")
+
+const publicFeedColumns = `
+	p.id,
+	p.user_id,
+	p.token,
+	p.title,
+	p.source,
+	coalesce(p.category_id, 0),
+	coalesce(c.title, ''),
+	p.tag,
+	p.search_query,
+	p.last_used_at,
+	p.created_at
+`
+
+// PublicFeedExists checks if a public feed with the same title exists.
+func (s *Storage) PublicFeedExists(userID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM public_feeds WHERE user_id=$1 AND lower(title)=lower($2) LIMIT 1`
+	s.db.QueryRow(query, userID, title).Scan(&result)
+	return result
+}
+
+// SetPublicFeedUsedTimestamp updates the last used date of a public feed.
+func (s *Storage) SetPublicFeedUsedTimestamp(publicFeedID int64) error {
+	query := `UPDATE public_feeds SET last_used_at=now() WHERE id=$1`
+	_, err := s.db.Exec(query, publicFeedID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update last used date for public feed #%d: %v`, publicFeedID, err)
+	}
+
+	return nil
+}
+
+// PublicFeeds returns all public feeds that belongs to the given user.
+func (s *Storage) PublicFeeds(userID int64) (model.PublicFeeds, error) {
+	query := `
+		SELECT ` + publicFeedColumns + `
+		FROM
+			public_feeds p
+		LEFT JOIN
+			categories c ON c.id=p.category_id
+		WHERE
+			p.user_id=$1
+		ORDER BY p.title ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch public feeds: %v`, err)
+	}
+	defer rows.Close()
+
+	publicFeeds := make(model.PublicFeeds, 0)
+	for rows.Next() {
+		publicFeed, err := scanPublicFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch public feed row: %v`, err)
+		}
+
+		publicFeeds = append(publicFeeds, publicFeed)
+	}
+
+	return publicFeeds, nil
+}
+
+// PublicFeedByToken returns the public feed identified by the given token, or nil when there is none.
+func (s *Storage) PublicFeedByToken(token string) (*model.PublicFeed, error) {
+	query := `
+		SELECT ` + publicFeedColumns + `
+		FROM
+			public_feeds p
+		LEFT JOIN
+			categories c ON c.id=p.category_id
+		WHERE
+			p.token=$1
+	`
+	publicFeed, err := scanPublicFeed(s.db.QueryRow(query, token))
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch public feed: %v`, err)
+	}
+
+	return publicFeed, nil
+}
+
+// CreatePublicFeed publishes a new feed with a random token.
+func (s *Storage) CreatePublicFeed(userID int64, request *model.PublicFeedCreationRequest) (*model.PublicFeed, error) {
+	query := `
+		INSERT INTO public_feeds
+			(user_id, token, title, source, category_id, tag, search_query)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING
+			id
+	`
+	// Only the criteria of the selected source are kept.
+	var categoryID sql.NullInt64
+	var tag, searchQuery string
+	switch request.Source {
+	case model.PublicFeedSourceCategory:
+		categoryID = sql.NullInt64{Int64: request.CategoryID, Valid: true}
+	case model.PublicFeedSourceTag:
+		tag = request.Tag
+	case model.PublicFeedSourceSearch:
+		searchQuery = request.SearchQuery
+	}
+
+	var publicFeedID int64
+	err := s.db.QueryRow(
+		query,
+		userID,
+		crypto.GenerateRandomStringHex(32),
+		request.Title,
+		request.Source,
+		categoryID,
+		tag,
+		searchQuery,
+	).Scan(&publicFeedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to create public feed: %v`, err)
+	}
+
+	query = `
+		SELECT ` + publicFeedColumns + `
+		FROM
+			public_feeds p
+		LEFT JOIN
+			categories c ON c.id=p.category_id
+		WHERE
+			p.id=$1
+	`
+	publicFeed, err := scanPublicFeed(s.db.QueryRow(query, publicFeedID))
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch public feed #%d: %v`, publicFeedID, err)
+	}
+
+	return publicFeed, nil
+}
+
+// RemovePublicFeed deletes a public feed, its token stops working immediately.
+func (s *Storage) RemovePublicFeed(userID, publicFeedID int64) error {
+	result, err := s.db.Exec(`DELETE FROM public_feeds WHERE id=$1 AND user_id=$2`, publicFeedID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this public feed: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this public feed: %v`, err)
+	}
+
+	if count == 0 {
+		return ErrPublicFeedNotFound
+	}
+
+	return nil
+}
+
+type publicFeedScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPublicFeed(row publicFeedScanner) (*model.PublicFeed, error) {
+	var publicFeed model.PublicFeed
+	if err := row.Scan(
+		&publicFeed.ID,
+		&publicFeed.UserID,
+		&publicFeed.Token,
+		&publicFeed.Title,
+		&publicFeed.Source,
+		&publicFeed.CategoryID,
+		&publicFeed.CategoryTitle,
+		&publicFeed.Tag,
+		&publicFeed.SearchQuery,
+		&publicFeed.LastUsedAt,
+		&publicFeed.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	publicFeed.URLs = publicFeed.FeedURLs()
+	return &publicFeed, nil
+}
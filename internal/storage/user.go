@@ -98,7 +98,9 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			entry_action_rules,
+			duplicate_entries_policy
 	`
 
 	tx, err := s.db.Begin()
@@ -144,6 +146,8 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.KeepFilterEntryRules,
 		&user.AlwaysOpenExternalLinks,
 		&user.OpenExternalLinksInNewTab,
+		&user.EntryActionRules,
+		&user.DuplicateEntriesPolicy,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -210,9 +214,11 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				block_filter_entry_rules=$27,
 				keep_filter_entry_rules=$28,
 				always_open_external_links=$29,
-				open_external_links_in_new_tab=$30
+				open_external_links_in_new_tab=$30,
+				entry_action_rules=$31,
+				duplicate_entries_policy=$32
 			WHERE
-				id=$31
+				id=$33
 		`
 
 		_, err = s.db.Exec(
@@ -247,6 +253,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.KeepFilterEntryRules,
 			user.AlwaysOpenExternalLinks,
 			user.OpenExternalLinksInNewTab,
+			user.EntryActionRules,
+			user.DuplicateEntriesPolicy,
 			user.ID,
 		)
 		if err != nil {
@@ -283,9 +291,11 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				block_filter_entry_rules=$26,
 				keep_filter_entry_rules=$27,
 				always_open_external_links=$28,
-				open_external_links_in_new_tab=$29
+				open_external_links_in_new_tab=$29,
+				entry_action_rules=$30,
+				duplicate_entries_policy=$31
 			WHERE
-				id=$30
+				id=$32
 		`
 
 		_, err := s.db.Exec(
@@ -319,6 +329,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.KeepFilterEntryRules,
 			user.AlwaysOpenExternalLinks,
 			user.OpenExternalLinksInNewTab,
+			user.EntryActionRules,
+			user.DuplicateEntriesPolicy,
 			user.ID,
 		)
 
@@ -374,7 +386,9 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			entry_action_rules,
+			duplicate_entries_policy
 		FROM
 			users
 		WHERE
@@ -417,7 +431,9 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			entry_action_rules,
+			duplicate_entries_policy
 		FROM
 			users
 		WHERE
@@ -460,7 +476,9 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			entry_action_rules,
+			duplicate_entries_policy
 		FROM
 			users
 		WHERE
@@ -510,7 +528,9 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.block_filter_entry_rules,
 			u.keep_filter_entry_rules,
 			u.always_open_external_links,
-			u.open_external_links_in_new_tab
+			u.open_external_links_in_new_tab,
+			u.entry_action_rules,
+			u.duplicate_entries_policy
 		FROM
 			users u
 		LEFT JOIN
@@ -555,6 +575,8 @@ func (s *Storage) fetchUser(query string, args ...any) (*model.User, error) {
 		&user.KeepFilterEntryRules,
 		&user.AlwaysOpenExternalLinks,
 		&user.OpenExternalLinksInNewTab,
+		&user.EntryActionRules,
+		&user.DuplicateEntriesPolicy,
 	)
 
 	if err == sql.ErrNoRows {
@@ -670,7 +692,9 @@ func (s *Storage) Users() (model.Users, error) {
 			block_filter_entry_rules,
 			keep_filter_entry_rules,
 			always_open_external_links,
-			open_external_links_in_new_tab
+			open_external_links_in_new_tab,
+			entry_action_rules,
+			duplicate_entries_policy
 		FROM
 			users
 		ORDER BY username ASC
@@ -716,6 +740,8 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.KeepFilterEntryRules,
 			&user.AlwaysOpenExternalLinks,
 			&user.OpenExternalLinksInNewTab,
+			&user.EntryActionRules,
+			&user.DuplicateEntriesPolicy,
 		)
 
 		if err != nil {
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const webSubSubscriptionQuery = `
+	SELECT
+		s.feed_id,
+		f.user_id,
+		s.hub_url,
+		s.topic_url,
+		s.callback_id,
+		s.secret,
+		s.state,
+		s.lease_expires_at,
+		s.updated_at
+	FROM
+		websub_subscriptions s
+	JOIN
+		feeds f ON f.id=s.feed_id
+`
+
+// WebSubSubscription returns the WebSub subscription of a feed, or nil if the feed is not subscribed to a hub.
+func (s *Storage) WebSubSubscription(feedID int64) (*model.WebSubSubscription, error) {
+	return s.fetchWebSubSubscription(webSubSubscriptionQuery+` WHERE s.feed_id=$1`, feedID)
+}
+
+// WebSubSubscriptionByCallbackID returns the WebSub subscription that owns the given callback.
+func (s *Storage) WebSubSubscriptionByCallbackID(callbackID string) (*model.WebSubSubscription, error) {
+	return s.fetchWebSubSubscription(webSubSubscriptionQuery+` WHERE s.callback_id=$1`, callbackID)
+}
+
+func (s *Storage) fetchWebSubSubscription(query string, arg any) (*model.WebSubSubscription, error) {
+	var subscription model.WebSubSubscription
+	var leaseExpiresAt sql.NullTime
+
+	err := s.db.QueryRow(query, arg).Scan(
+		&subscription.FeedID,
+		&subscription.UserID,
+		&subscription.HubURL,
+		&subscription.TopicURL,
+		&subscription.CallbackID,
+		&subscription.Secret,
+		&subscription.State,
+		&leaseExpiresAt,
+		&subscription.UpdatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch websub subscription: %v`, err)
+	}
+
+	if leaseExpiresAt.Valid {
+		subscription.LeaseExpiresAt = leaseExpiresAt.Time
+	}
+
+	return &subscription, nil
+}
+
+// SaveWebSubSubscription creates or replaces the WebSub subscription of a feed.
+func (s *Storage) SaveWebSubSubscription(subscription *model.WebSubSubscription) error {
+	var leaseExpiresAt sql.NullTime
+	if !subscription.LeaseExpiresAt.IsZero() {
+		leaseExpiresAt = sql.NullTime{Time: subscription.LeaseExpiresAt, Valid: true}
+	}
+
+	query := `
+		INSERT INTO websub_subscriptions
+			(feed_id, hub_url, topic_url, callback_id, secret, state, lease_expires_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, now())
+		ON CONFLICT (feed_id) DO UPDATE SET
+			hub_url=EXCLUDED.hub_url,
+			topic_url=EXCLUDED.topic_url,
+			callback_id=EXCLUDED.callback_id,
+			secret=EXCLUDED.secret,
+			state=EXCLUDED.state,
+			lease_expires_at=EXCLUDED.lease_expires_at,
+			updated_at=EXCLUDED.updated_at
+		RETURNING
+			updated_at
+	`
+
+	err := s.db.QueryRow(
+		query,
+		subscription.FeedID,
+		subscription.HubURL,
+		subscription.TopicURL,
+		subscription.CallbackID,
+		subscription.Secret,
+		subscription.State,
+		leaseExpiresAt,
+	).Scan(&subscription.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to save websub subscription for feed #%d: %v`, subscription.FeedID, err)
+	}
+
+	return nil
+}
+
+// ActivateWebSubSubscription marks the subscription as verified by the hub for the duration of the lease.
+func (s *Storage) ActivateWebSubSubscription(feedID int64, lease time.Duration) error {
+	query := `
+		UPDATE
+			websub_subscriptions
+		SET
+			state=$1,
+			lease_expires_at=now() + $2::interval,
+			updated_at=now()
+		WHERE
+			feed_id=$3
+	`
+
+	if _, err := s.db.Exec(query, model.WebSubStateActive, fmt.Sprintf("%d seconds", int(lease.Seconds())), feedID); err != nil {
+		return fmt.Errorf(`store: unable to activate websub subscription for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// DenyWebSubSubscription marks the subscription as refused by the hub.
+func (s *Storage) DenyWebSubSubscription(feedID int64) error {
+	query := `
+		UPDATE
+			websub_subscriptions
+		SET
+			state=$1,
+			lease_expires_at=NULL,
+			updated_at=now()
+		WHERE
+			feed_id=$2
+	`
+
+	if _, err := s.db.Exec(query, model.WebSubStateDenied, feedID); err != nil {
+		return fmt.Errorf(`store: unable to deny websub subscription for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// RemoveWebSubSubscription deletes the WebSub subscription of a feed.
+func (s *Storage) RemoveWebSubSubscription(feedID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM websub_subscriptions WHERE feed_id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to remove websub subscription for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
@@ -47,6 +47,7 @@ func (e *Engine) ParseTemplates() {
 		"choose_subscription.html": {"feed_menu.html", "layout.html"},
 		"create_api_key.html":      {"layout.html", "settings_menu.html"},
 		"create_category.html":     {"layout.html"},
+		"create_public_feed.html":  {"layout.html", "settings_menu.html"},
 		"create_user.html":         {"layout.html", "settings_menu.html"},
 		"edit_category.html":       {"layout.html", "settings_menu.html"},
 		"edit_feed.html":           {"layout.html"},
@@ -58,7 +59,9 @@ func (e *Engine) ParseTemplates() {
 		"import.html":              {"feed_menu.html", "layout.html"},
 		"integrations.html":        {"layout.html", "settings_menu.html"},
 		"login.html":               {"layout.html"},
+		"newsletters.html":         {"layout.html", "settings_menu.html"},
 		"offline.html":             {},
+		"public_feeds.html":        {"layout.html", "settings_menu.html"},
 		"search.html":              {"item_meta.html", "layout.html", "pagination.html"},
 		"sessions.html":            {"layout.html", "settings_menu.html"},
 		"settings.html":            {"layout.html", "settings_menu.html"},
@@ -67,6 +70,7 @@ func (e *Engine) ParseTemplates() {
 		"unread_entries.html":      {"item_meta.html", "layout.html", "pagination.html"},
 		"users.html":               {"layout.html", "settings_menu.html"},
 		"webauthn_rename.html":     {"layout.html"},
+		"workers.html":             {"layout.html", "settings_menu.html"},
 	}
 
 	for name, dependencies := range templates {
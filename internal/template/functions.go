@@ -48,6 +48,7 @@ func (f *funcMap) Map() template.FuncMap {
 		"hasAuthProxy": func() bool {
 			return config.Opts.AuthProxyHeader() != ""
 		},
+		"hasNewsletterListener": config.Opts.HasNewsletterListener,
 		"route": func(name string, args ...any) string {
 			return route.Path(f.router, name, args...)
 		},
@@ -43,5 +43,13 @@ func (h *handler) showAboutPage(w http.ResponseWriter, r *http.Request) {
 		view.Set("db_usage", dbSize)
 	}
 
+	if user.IsAdmin {
+		if jobQueueStats, jobQueueErr := h.store.JobQueueStats(); jobQueueErr != nil {
+			view.Set("jobQueueError", jobQueueErr)
+		} else {
+			view.Set("jobQueueStats", jobQueueStats)
+		}
+	}
+
 	html.OK(w, r, view.Render("about"))
 }
@@ -32,8 +32,14 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	categoryForm := form.CategoryForm{
-		Title:        category.Title,
-		HideGlobally: category.HideGlobally,
+		Title:                 category.Title,
+		HideGlobally:          category.HideGlobally,
+		ScraperRules:          category.ScraperRules,
+		RewriteRules:          category.RewriteRules,
+		UrlRewriteRules:       category.UrlRewriteRules,
+		BlockFilterEntryRules: category.BlockFilterEntryRules,
+		KeepFilterEntryRules:  category.KeepFilterEntryRules,
+		Crawler:               category.Crawler,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -42,6 +42,10 @@ func (h *handler) showCategoryEntriesAllPage(w http.ResponseWriter, r *http.Requ
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 
+	if user.DuplicateEntriesPolicy == model.DuplicateEntriesPolicyGroup {
+		builder.WithDuplicateEntries()
+	}
+
 	entries, err := builder.GetEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -43,6 +43,10 @@ func (h *handler) showCategoryEntriesStarredPage(w http.ResponseWriter, r *http.
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 
+	if user.DuplicateEntriesPolicy == model.DuplicateEntriesPolicyGroup {
+		builder.WithDuplicateEntries()
+	}
+
 	entries, err := builder.GetEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
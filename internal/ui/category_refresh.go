@@ -13,6 +13,7 @@ import (
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/session"
 )
 
@@ -58,7 +59,10 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) int64
 			slog.Int("nb_jobs", len(jobs)),
 		)
 
-		go h.pool.Push(jobs)
+		if err := h.pool.Push(jobs, model.JobPriorityManual); err != nil {
+			html.ServerError(w, r, err)
+			return 0
+		}
 
 		sess.SetLastForceRefresh()
 		sess.NewFlashMessage(printer.Print("alert.background_feed_refresh"))
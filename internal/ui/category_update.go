@@ -47,13 +47,19 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 
 	categoryRequest := &model.CategoryModificationRequest{
-		Title:        model.SetOptionalField(categoryForm.Title),
-		HideGlobally: model.SetOptionalField(categoryForm.HideGlobally),
+		Title:                 model.SetOptionalField(categoryForm.Title),
+		HideGlobally:          model.SetOptionalField(categoryForm.HideGlobally),
+		ScraperRules:          model.SetOptionalField(categoryForm.ScraperRules),
+		RewriteRules:          model.SetOptionalField(categoryForm.RewriteRules),
+		UrlRewriteRules:       model.SetOptionalField(categoryForm.UrlRewriteRules),
+		BlockFilterEntryRules: model.SetOptionalField(categoryForm.BlockFilterEntryRules),
+		KeepFilterEntryRules:  model.SetOptionalField(categoryForm.KeepFilterEntryRules),
+		Crawler:               model.SetOptionalField(categoryForm.Crawler),
 	}
 
 	if validationErr := validator.ValidateCategoryModification(h.store, user.ID, category.ID, categoryRequest); validationErr != nil {
 		view.Set("errorMessage", validationErr.Translate(user.Language))
-		html.OK(w, r, view.Render("create_category"))
+		html.OK(w, r, view.Render("edit_category"))
 		return
 	}
 
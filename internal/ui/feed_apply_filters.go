@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) applyFeedFilters(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	if !h.store.FeedExists(userID, feedID) {
+		html.NotFound(w, r)
+		return
+	}
+
+	entriesFilterRequest := &model.EntriesFilterRequest{Status: r.FormValue("status")}
+	if err := validator.ValidateEntriesFilterRequest(entriesFilterRequest); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	affected, err := feedHandler.ApplyFilterRules(h.store, userID, feedID, entriesFilterRequest.Status)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(printer.Printf("alert.filters_applied", affected))
+
+	html.Redirect(w, r, route.Path(h.router, "editFeed", "feedID", feedID))
+}
@@ -14,6 +14,9 @@ import (
 	"miniflux.app/v2/internal/ui/view"
 )
 
+// feedFetchHistoryPageSize is the number of refresh attempts displayed on the feed edit page.
+const feedFetchHistoryPageSize = 20
+
 func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	user, err := h.store.UserByID(request.UserID(r))
 	if err != nil {
@@ -39,6 +42,12 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fetchHistory, err := h.store.FeedFetchHistory(user.ID, feedID, feedFetchHistoryPageSize)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.FeedForm{
 		SiteURL:                     feed.SiteURL,
 		FeedURL:                     feed.FeedURL,
@@ -51,13 +60,18 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		KeeplistRules:               feed.KeeplistRules,
 		BlockFilterEntryRules:       feed.BlockFilterEntryRules,
 		KeepFilterEntryRules:        feed.KeepFilterEntryRules,
+		EntryActionRules:            feed.EntryActionRules,
 		Crawler:                     feed.Crawler,
 		UserAgent:                   feed.UserAgent,
 		Cookie:                      feed.Cookie,
+		CustomHeaders:               feed.CustomHeaders.String(),
+		ClientCertificate:           feed.ClientCertificate,
+		ClientPrivateKey:            feed.ClientPrivateKey,
 		CategoryID:                  feed.Category.ID,
 		Username:                    feed.Username,
 		Password:                    feed.Password,
 		IgnoreHTTPCache:             feed.IgnoreHTTPCache,
+		IgnoreSkipHints:             feed.IgnoreSkipHints,
 		AllowSelfSignedCertificates: feed.AllowSelfSignedCertificates,
 		FetchViaProxy:               feed.FetchViaProxy,
 		Disabled:                    feed.Disabled,
@@ -73,6 +87,11 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		PushoverEnabled:             feed.PushoverEnabled,
 		PushoverPriority:            feed.PushoverPriority,
 		ProxyURL:                    feed.ProxyURL,
+		WebPageItemSelector:         feed.WebPageItemSelector,
+		WebPageTitleSelector:        feed.WebPageTitleSelector,
+		WebPageLinkSelector:         feed.WebPageLinkSelector,
+		WebPageDateSelector:         feed.WebPageDateSelector,
+		WebPageContentSelector:      feed.WebPageContentSelector,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -80,6 +99,7 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
 	view.Set("feed", feed)
+	view.Set("fetchHistory", fetchHistory)
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
@@ -42,6 +42,10 @@ func (h *handler) showFeedEntriesAllPage(w http.ResponseWriter, r *http.Request)
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 
+	if user.DuplicateEntriesPolicy == model.DuplicateEntriesPolicyGroup {
+		builder.WithDuplicateEntries()
+	}
+
 	entries, err := builder.GetEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
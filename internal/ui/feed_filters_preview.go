@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+	"miniflux.app/v2/internal/validator"
+)
+
+const filterPreviewLimit = 100
+
+type filterPreviewEntry struct {
+	Entry     *model.Entry
+	Result    filter.Result
+	ReasonKey string
+}
+
+func (h *handler) previewFeedFilters(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedID := request.RouteInt64Param(r, "feedID")
+	feed, err := h.store.FeedByID(loggedUser.ID, feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(loggedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	fetchHistory, err := h.store.FeedFetchHistory(loggedUser.ID, feed.ID, feedFetchHistoryPageSize)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedForm := form.NewFeedForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", feedForm)
+	view.Set("categories", categories)
+	view.Set("feed", feed)
+	view.Set("fetchHistory", fetchHistory)
+	view.Set("menu", "feeds")
+	view.Set("user", loggedUser)
+	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
+	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyURLConfigured())
+
+	feedFilterTestRequest := &model.FeedFilterTestRequest{
+		BlocklistRules:        model.OptionalString(feedForm.BlocklistRules),
+		KeeplistRules:         model.OptionalString(feedForm.KeeplistRules),
+		BlockFilterEntryRules: model.OptionalString(feedForm.BlockFilterEntryRules),
+		KeepFilterEntryRules:  model.OptionalString(feedForm.KeepFilterEntryRules),
+	}
+
+	if validationErr := validator.ValidateFeedFilterTest(feedFilterTestRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(loggedUser.Language))
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	// Empty form values clear the rules instead of falling back to the saved ones.
+	feed.BlocklistRules = feedForm.BlocklistRules
+	feed.KeeplistRules = feedForm.KeeplistRules
+	feed.BlockFilterEntryRules = feedForm.BlockFilterEntryRules
+	feed.KeepFilterEntryRules = feedForm.KeepFilterEntryRules
+
+	builder := h.store.NewEntryQueryBuilder(loggedUser.ID)
+	builder.WithFeedID(feed.ID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("published_at", "desc")
+	builder.WithLimit(filterPreviewLimit)
+	builder.WithEnclosures()
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	blockRules := filter.ParseRules(loggedUser.BlockFilterEntryRules, feed.EffectiveBlockFilterEntryRules()).WithReadingSpeeds(loggedUser.DefaultReadingSpeed, loggedUser.CJKReadingSpeed)
+	keepRules := filter.ParseRules(loggedUser.KeepFilterEntryRules, feed.EffectiveKeepFilterEntryRules()).WithReadingSpeeds(loggedUser.DefaultReadingSpeed, loggedUser.CJKReadingSpeed)
+
+	previewEntries := make([]*filterPreviewEntry, 0, len(entries))
+	countBlocked := 0
+	for _, entry := range entries {
+		result := filter.EvaluateEntry(blockRules, keepRules, feed, entry)
+		if result.Blocked {
+			countBlocked++
+		}
+		previewEntries = append(previewEntries, &filterPreviewEntry{
+			Entry:     entry,
+			Result:    result,
+			ReasonKey: "page.edit_feed.filter_preview.reason." + result.Reason,
+		})
+	}
+
+	view.Set("showFilterPreview", true)
+	view.Set("filterPreview", previewEntries)
+	view.Set("filterPreviewTotal", len(entries))
+	view.Set("filterPreviewBlocked", countBlocked)
+	view.Set("filterPreviewKept", len(entries)-countBlocked)
+
+	html.OK(w, r, view.Render("edit_feed"))
+}
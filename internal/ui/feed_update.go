@@ -42,13 +42,26 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fetchHistory, err := h.store.FeedFetchHistory(loggedUser.ID, feed.ID, feedFetchHistoryPageSize)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.NewFeedForm(r)
 
+	// The URLs of a newsletter feed are email addresses, they are not editable.
+	if feed.IsNewsletter() {
+		feedForm.FeedURL = feed.FeedURL
+		feedForm.SiteURL = feed.SiteURL
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
 	view.Set("feed", feed)
+	view.Set("fetchHistory", fetchHistory)
 	view.Set("menu", "feeds")
 	view.Set("user", loggedUser)
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
@@ -56,15 +69,24 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 
 	feedModificationRequest := &model.FeedModificationRequest{
-		FeedURL:         model.OptionalString(feedForm.FeedURL),
-		SiteURL:         model.OptionalString(feedForm.SiteURL),
-		Title:           model.OptionalString(feedForm.Title),
-		Description:     model.OptionalString(feedForm.Description),
-		CategoryID:      model.OptionalNumber(feedForm.CategoryID),
-		BlocklistRules:  model.OptionalString(feedForm.BlocklistRules),
-		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
-		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
-		ProxyURL:        model.OptionalString(feedForm.ProxyURL),
+		FeedURL:               model.OptionalString(feedForm.FeedURL),
+		SiteURL:               model.OptionalString(feedForm.SiteURL),
+		Title:                 model.OptionalString(feedForm.Title),
+		Description:           model.OptionalString(feedForm.Description),
+		CategoryID:            model.OptionalNumber(feedForm.CategoryID),
+		BlocklistRules:        model.OptionalString(feedForm.BlocklistRules),
+		KeeplistRules:         model.OptionalString(feedForm.KeeplistRules),
+		BlockFilterEntryRules: model.OptionalString(feedForm.BlockFilterEntryRules),
+		KeepFilterEntryRules:  model.OptionalString(feedForm.KeepFilterEntryRules),
+		EntryActionRules:      model.OptionalString(feedForm.EntryActionRules),
+		UrlRewriteRules:       model.OptionalString(feedForm.UrlRewriteRules),
+		ProxyURL:              model.OptionalString(feedForm.ProxyURL),
+		CustomHeaders:         model.SetOptionalField(model.ParseFeedHeaders(feedForm.CustomHeaders)),
+	}
+
+	if feed.IsNewsletter() {
+		feedModificationRequest.FeedURL = nil
+		feedModificationRequest.SiteURL = nil
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feed.ID, feedModificationRequest); validationErr != nil {
@@ -73,6 +95,24 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if validationErr := validator.ValidateFeedClientCertificate(feedForm.ClientCertificate, feedForm.ClientPrivateKey); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(loggedUser.Language))
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	if validationErr := validator.ValidateWebPageSelectors(
+		feedForm.WebPageItemSelector,
+		feedForm.WebPageTitleSelector,
+		feedForm.WebPageLinkSelector,
+		feedForm.WebPageDateSelector,
+		feedForm.WebPageContentSelector,
+	); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(loggedUser.Language))
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
+
 	err = h.store.UpdateFeed(feedForm.Merge(feed))
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -9,14 +9,26 @@ import (
 
 // CategoryForm represents a feed form in the UI
 type CategoryForm struct {
-	Title        string
-	HideGlobally bool
+	Title                 string
+	HideGlobally          bool
+	ScraperRules          string
+	RewriteRules          string
+	UrlRewriteRules       string
+	BlockFilterEntryRules string
+	KeepFilterEntryRules  string
+	Crawler               bool
 }
 
 // NewCategoryForm returns a new CategoryForm.
 func NewCategoryForm(r *http.Request) *CategoryForm {
 	return &CategoryForm{
-		Title:        r.FormValue("title"),
-		HideGlobally: r.FormValue("hide_globally") == "1",
+		Title:                 r.FormValue("title"),
+		HideGlobally:          r.FormValue("hide_globally") == "1",
+		ScraperRules:          r.FormValue("scraper_rules"),
+		RewriteRules:          r.FormValue("rewrite_rules"),
+		UrlRewriteRules:       r.FormValue("urlrewrite_rules"),
+		BlockFilterEntryRules: r.FormValue("block_filter_entry_rules"),
+		KeepFilterEntryRules:  r.FormValue("keep_filter_entry_rules"),
+		Crawler:               r.FormValue("crawler") == "1",
 	}
 }
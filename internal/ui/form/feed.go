@@ -6,6 +6,7 @@ package form // import "miniflux.app/v2/internal/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -23,13 +24,18 @@ type FeedForm struct {
 	KeeplistRules               string
 	BlockFilterEntryRules       string
 	KeepFilterEntryRules        string
+	EntryActionRules            string
 	Crawler                     bool
 	UserAgent                   string
 	Cookie                      string
+	CustomHeaders               string
+	ClientCertificate           string
+	ClientPrivateKey            string
 	CategoryID                  int64
 	Username                    string
 	Password                    string
 	IgnoreHTTPCache             bool
+	IgnoreSkipHints             bool
 	AllowSelfSignedCertificates bool
 	FetchViaProxy               bool
 	Disabled                    bool
@@ -45,6 +51,11 @@ type FeedForm struct {
 	PushoverEnabled             bool
 	PushoverPriority            int
 	ProxyURL                    string
+	WebPageItemSelector         string
+	WebPageTitleSelector        string
+	WebPageLinkSelector         string
+	WebPageDateSelector         string
+	WebPageContentSelector      string
 }
 
 // Merge updates the fields of the given feed.
@@ -52,7 +63,7 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.Category.ID = f.CategoryID
 	feed.Title = f.Title
 	feed.SiteURL = f.SiteURL
-	feed.FeedURL = f.FeedURL
+	feed.WithFeedURL(f.FeedURL)
 	feed.Description = f.Description
 	feed.ScraperRules = f.ScraperRules
 	feed.RewriteRules = f.RewriteRules
@@ -61,14 +72,19 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.KeeplistRules = f.KeeplistRules
 	feed.BlockFilterEntryRules = f.BlockFilterEntryRules
 	feed.KeepFilterEntryRules = f.KeepFilterEntryRules
+	feed.EntryActionRules = f.EntryActionRules
 	feed.Crawler = f.Crawler
 	feed.UserAgent = f.UserAgent
 	feed.Cookie = f.Cookie
+	feed.CustomHeaders = model.ParseFeedHeaders(f.CustomHeaders)
+	feed.ClientCertificate = f.ClientCertificate
+	feed.ClientPrivateKey = f.ClientPrivateKey
 	feed.ParsingErrorCount = 0
 	feed.ParsingErrorMsg = ""
 	feed.Username = f.Username
 	feed.Password = f.Password
 	feed.IgnoreHTTPCache = f.IgnoreHTTPCache
+	feed.IgnoreSkipHints = f.IgnoreSkipHints
 	feed.AllowSelfSignedCertificates = f.AllowSelfSignedCertificates
 	feed.FetchViaProxy = f.FetchViaProxy
 	feed.Disabled = f.Disabled
@@ -83,6 +99,11 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.PushoverEnabled = f.PushoverEnabled
 	feed.PushoverPriority = f.PushoverPriority
 	feed.ProxyURL = f.ProxyURL
+	feed.WebPageItemSelector = f.WebPageItemSelector
+	feed.WebPageTitleSelector = f.WebPageTitleSelector
+	feed.WebPageLinkSelector = f.WebPageLinkSelector
+	feed.WebPageDateSelector = f.WebPageDateSelector
+	feed.WebPageContentSelector = f.WebPageContentSelector
 	return feed
 }
 
@@ -111,17 +132,22 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		ScraperRules:                r.FormValue("scraper_rules"),
 		UserAgent:                   r.FormValue("user_agent"),
 		Cookie:                      r.FormValue("cookie"),
+		CustomHeaders:               r.FormValue("custom_headers"),
+		ClientCertificate:           strings.TrimSpace(r.FormValue("client_certificate")),
+		ClientPrivateKey:            strings.TrimSpace(r.FormValue("client_private_key")),
 		RewriteRules:                r.FormValue("rewrite_rules"),
 		UrlRewriteRules:             r.FormValue("urlrewrite_rules"),
 		BlocklistRules:              r.FormValue("blocklist_rules"),
 		KeeplistRules:               r.FormValue("keeplist_rules"),
 		BlockFilterEntryRules:       r.FormValue("block_filter_entry_rules"),
 		KeepFilterEntryRules:        r.FormValue("keep_filter_entry_rules"),
+		EntryActionRules:            r.FormValue("entry_action_rules"),
 		Crawler:                     r.FormValue("crawler") == "1",
 		CategoryID:                  int64(categoryID),
 		Username:                    r.FormValue("feed_username"),
 		Password:                    r.FormValue("feed_password"),
 		IgnoreHTTPCache:             r.FormValue("ignore_http_cache") == "1",
+		IgnoreSkipHints:             r.FormValue("ignore_skip_hints") == "1",
 		AllowSelfSignedCertificates: r.FormValue("allow_self_signed_certificates") == "1",
 		FetchViaProxy:               r.FormValue("fetch_via_proxy") == "1",
 		Disabled:                    r.FormValue("disabled") == "1",
@@ -136,5 +162,10 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		PushoverEnabled:             r.FormValue("pushover_enabled") == "1",
 		PushoverPriority:            pushoverPriority,
 		ProxyURL:                    r.FormValue("proxy_url"),
+		WebPageItemSelector:         r.FormValue("webpage_item_selector"),
+		WebPageTitleSelector:        r.FormValue("webpage_title_selector"),
+		WebPageLinkSelector:         r.FormValue("webpage_link_selector"),
+		WebPageDateSelector:         r.FormValue("webpage_date_selector"),
+		WebPageContentSelector:      r.FormValue("webpage_content_selector"),
 	}
 }
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NewsletterFeedForm represents the newsletter feed form.
+type NewsletterFeedForm struct {
+	Title      string
+	CategoryID int64
+}
+
+// NewNewsletterFeedForm returns a new NewsletterFeedForm.
+func NewNewsletterFeedForm(r *http.Request) *NewsletterFeedForm {
+	categoryID, _ := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+
+	return &NewsletterFeedForm{
+		Title:      strings.TrimSpace(r.FormValue("title")),
+		CategoryID: categoryID,
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PublicFeedForm represents the public feed form.
+type PublicFeedForm struct {
+	Title       string
+	Source      string
+	CategoryID  int64
+	Tag         string
+	SearchQuery string
+}
+
+// NewPublicFeedForm returns a new PublicFeedForm.
+func NewPublicFeedForm(r *http.Request) *PublicFeedForm {
+	categoryID, _ := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+
+	return &PublicFeedForm{
+		Title:       strings.TrimSpace(r.FormValue("title")),
+		Source:      r.FormValue("source"),
+		CategoryID:  categoryID,
+		Tag:         strings.TrimSpace(r.FormValue("tag")),
+		SearchQuery: strings.TrimSpace(r.FormValue("search_query")),
+	}
+}
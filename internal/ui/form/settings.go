@@ -52,6 +52,8 @@ type SettingsForm struct {
 	MediaPlaybackRate         float64
 	BlockFilterEntryRules     string
 	KeepFilterEntryRules      string
+	EntryActionRules          string
+	DuplicateEntriesPolicy    string
 	AlwaysOpenExternalLinks   bool
 	OpenExternalLinksInNewTab bool
 }
@@ -116,6 +118,8 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.MediaPlaybackRate = s.MediaPlaybackRate
 	user.BlockFilterEntryRules = s.BlockFilterEntryRules
 	user.KeepFilterEntryRules = s.KeepFilterEntryRules
+	user.EntryActionRules = s.EntryActionRules
+	user.DuplicateEntriesPolicy = s.DuplicateEntriesPolicy
 	user.AlwaysOpenExternalLinks = s.AlwaysOpenExternalLinks
 	user.OpenExternalLinksInNewTab = s.OpenExternalLinksInNewTab
 
@@ -209,6 +213,8 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		MediaPlaybackRate:         mediaPlaybackRate,
 		BlockFilterEntryRules:     r.FormValue("block_filter_entry_rules"),
 		KeepFilterEntryRules:      r.FormValue("keep_filter_entry_rules"),
+		EntryActionRules:          r.FormValue("entry_action_rules"),
+		DuplicateEntriesPolicy:    r.FormValue("duplicate_entries_policy"),
 		AlwaysOpenExternalLinks:   r.FormValue("always_open_external_links") == "1",
 		OpenExternalLinksInNewTab: r.FormValue("open_external_links_in_new_tab") == "1",
 	}
@@ -30,7 +30,13 @@ type SubscriptionForm struct {
 	BlockFilterEntryRules       string
 	KeepFilterEntryRules        string
 	DisableHTTP2                bool
+	BackfillHistory             bool
 	ProxyURL                    string
+	WebPageItemSelector         string
+	WebPageTitleSelector        string
+	WebPageLinkSelector         string
+	WebPageDateSelector         string
+	WebPageContentSelector      string
 }
 
 // Validate makes sure the form values locale.are valid.
@@ -51,6 +57,14 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
 	}
 
+	if err := validator.ValidateFilterRules(s.BlockFilterEntryRules, "block"); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateFilterRules(s.KeepFilterEntryRules, "keep"); err != nil {
+		return err
+	}
+
 	if !validator.IsValidRegex(s.UrlRewriteRules) {
 		return locale.NewLocalizedError("error.feed_invalid_urlrewrite_rule")
 	}
@@ -59,6 +73,16 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
 	}
 
+	if err := validator.ValidateWebPageSelectors(
+		s.WebPageItemSelector,
+		s.WebPageTitleSelector,
+		s.WebPageLinkSelector,
+		s.WebPageDateSelector,
+		s.WebPageContentSelector,
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -87,6 +111,12 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		KeepFilterEntryRules:        r.FormValue("keep_filter_entry_rules"),
 		BlockFilterEntryRules:       r.FormValue("block_filter_entry_rules"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
+		BackfillHistory:             r.FormValue("backfill_history") == "1",
 		ProxyURL:                    r.FormValue("proxy_url"),
+		WebPageItemSelector:         r.FormValue("webpage_item_selector"),
+		WebPageTitleSelector:        r.FormValue("webpage_title_selector"),
+		WebPageLinkSelector:         r.FormValue("webpage_link_selector"),
+		WebPageDateSelector:         r.FormValue("webpage_date_selector"),
+		WebPageContentSelector:      r.FormValue("webpage_content_selector"),
 	}
 }
@@ -63,9 +63,9 @@ func (m *middleware) handleUserSession(next http.Handler) http.Handler {
 
 func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if mux.CurrentRoute(r).GetName() == "feedIcon" {
-			// Skip app session handling for the feed icon route to avoid unnecessary session creation
-			// when fetching feed icons.
+		if routeName := mux.CurrentRoute(r).GetName(); routeName == "feedIcon" || routeName == "newsletterAttachment" {
+			// Skip app session handling for the feed icon and newsletter attachment routes to avoid unnecessary session creation
+			// when fetching feed icons or the images of newsletters.
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -161,6 +161,7 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 		"oauth2Callback",
 		"appIcon",
 		"feedIcon",
+		"newsletterAttachment",
 		"favicon",
 		"webManifest",
 		"robots",
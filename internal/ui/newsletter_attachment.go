@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/html"
+)
+
+func (h *handler) showNewsletterAttachment(w http.ResponseWriter, r *http.Request) {
+	attachment, err := h.store.NewsletterAttachmentByToken(request.RouteStringParam(r, "token"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if attachment == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	// The media are displayed in the entries, the other files are downloaded to not run their scripts on this origin.
+	isMedia := strings.HasPrefix(attachment.MimeType, "image/") || strings.HasPrefix(attachment.MimeType, "audio/") || strings.HasPrefix(attachment.MimeType, "video/")
+	isDownload := !isMedia || attachment.MimeType == "image/svg+xml"
+
+	response.New(w, r).WithCaching(crypto.HashFromBytes(attachment.Content), 72*time.Hour, func(b *response.Builder) {
+		b.WithHeader("Content-Security-Policy", response.ContentSecurityPolicyForUntrustedContent)
+		b.WithHeader("X-Content-Type-Options", "nosniff")
+		b.WithHeader("Content-Type", attachment.MimeType)
+		if isDownload {
+			// The filename comes from the sender, it is quoted and encoded to not break the header.
+			disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+			if attachment.Filename == "" || disposition == "" {
+				disposition = "attachment; filename=" + attachment.Token
+			}
+			b.WithHeader("Content-Disposition", disposition)
+		}
+		b.WithBody(attachment.Content)
+		if isMedia {
+			b.WithoutCompression()
+		}
+		b.Write()
+	})
+}
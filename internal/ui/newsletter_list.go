@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showNewslettersPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderNewslettersPage(w, r, user, &form.NewsletterFeedForm{}, "")
+}
+
+func (h *handler) renderNewslettersPage(w http.ResponseWriter, r *http.Request, user *model.User, newsletterFeedForm *form.NewsletterFeedForm, errorMessage string) {
+	token, err := h.store.UserNewsletterToken(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feeds, err := h.store.Feeds(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	var newsletterFeeds model.Feeds
+	for _, feed := range feeds {
+		if feed.IsNewsletter() {
+			newsletterFeeds = append(newsletterFeeds, feed)
+		}
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("newsletterAddress", model.NewsletterAddress(token))
+	view.Set("newsletterFeeds", newsletterFeeds)
+	view.Set("categories", categories)
+	view.Set("form", newsletterFeedForm)
+	view.Set("errorMessage", errorMessage)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("newsletters"))
+}
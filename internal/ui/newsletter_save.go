@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/newsletter"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) saveNewsletterFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	newsletterFeedForm := form.NewNewsletterFeedForm(r)
+	newsletterFeedCreationRequest := &model.NewsletterFeedCreationRequest{
+		Title:      newsletterFeedForm.Title,
+		CategoryID: newsletterFeedForm.CategoryID,
+	}
+
+	if validationErr := validator.ValidateNewsletterFeedCreation(h.store, user.ID, newsletterFeedCreationRequest); validationErr != nil {
+		h.renderNewslettersPage(w, r, user, newsletterFeedForm, validationErr.Translate(user.Language))
+		return
+	}
+
+	if _, err := newsletter.CreateFeed(h.store, user.ID, newsletterFeedCreationRequest); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "newsletters"))
+}
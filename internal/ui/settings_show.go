@@ -47,6 +47,8 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		MediaPlaybackRate:         user.MediaPlaybackRate,
 		BlockFilterEntryRules:     user.BlockFilterEntryRules,
 		KeepFilterEntryRules:      user.KeepFilterEntryRules,
+		EntryActionRules:          user.EntryActionRules,
+		DuplicateEntriesPolicy:    user.DuplicateEntriesPolicy,
 		AlwaysOpenExternalLinks:   user.AlwaysOpenExternalLinks,
 		OpenExternalLinksInNewTab: user.OpenExternalLinksInNewTab,
 	}
@@ -75,6 +77,7 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("default_home_pages", model.HomePages())
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
+	view.Set("duplicate_entries_policies", model.DuplicateEntriesPolicies())
 	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
 	view.Set("webAuthnCerts", creds)
 
@@ -51,6 +51,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("default_home_pages", model.HomePages())
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
+	view.Set("duplicate_entries_policies", model.DuplicateEntriesPolicies())
 	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
 	view.Set("webAuthnCerts", creds)
 
@@ -78,6 +79,8 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		MediaPlaybackRate:      model.OptionalNumber(settingsForm.MediaPlaybackRate),
 		BlockFilterEntryRules:  model.OptionalString(settingsForm.BlockFilterEntryRules),
 		KeepFilterEntryRules:   model.OptionalString(settingsForm.KeepFilterEntryRules),
+		EntryActionRules:       model.OptionalString(settingsForm.EntryActionRules),
+		DuplicateEntriesPolicy: model.OptionalString(settingsForm.DuplicateEntriesPolicy),
 		ExternalFontHosts:      model.OptionalString(settingsForm.ExternalFontHosts),
 	}
 
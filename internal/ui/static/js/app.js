@@ -1036,6 +1036,9 @@ function handleMediaControlButtonClick(mediaPlayerButtonElement) {
         case "seek":
             mediaElement.currentTime = Math.max(mediaElement.currentTime + actionValue, 0);
             break;
+        case "seek-to":
+            mediaElement.currentTime = Math.max(actionValue, 0);
+            break;
         case "speed":
             // 0.25 was chosen because it will allow to get back to 1x in two "faster" clicks.
             // A lower value would result in a playback rate of 0, effectively pausing playback.
@@ -65,6 +65,7 @@ func (h *handler) showChooseSubscriptionPage(w http.ResponseWriter, r *http.Requ
 		BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
 		FetchViaProxy:               subscriptionForm.FetchViaProxy,
 		DisableHTTP2:                subscriptionForm.DisableHTTP2,
+		BackfillHistory:             subscriptionForm.BackfillHistory,
 		ProxyURL:                    subscriptionForm.ProxyURL,
 	})
 	if localizedError != nil {
@@ -52,6 +52,45 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Web pages are not feeds, so there is nothing to discover when selectors are provided.
+	if subscriptionForm.WebPageItemSelector != "" {
+		feed, localizedError := feedHandler.CreateFeed(h.store, user.ID, &model.FeedCreationRequest{
+			CategoryID:                  subscriptionForm.CategoryID,
+			FeedURL:                     subscriptionForm.URL,
+			Crawler:                     subscriptionForm.Crawler,
+			AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
+			UserAgent:                   subscriptionForm.UserAgent,
+			Cookie:                      subscriptionForm.Cookie,
+			Username:                    subscriptionForm.Username,
+			Password:                    subscriptionForm.Password,
+			ScraperRules:                subscriptionForm.ScraperRules,
+			RewriteRules:                subscriptionForm.RewriteRules,
+			UrlRewriteRules:             subscriptionForm.UrlRewriteRules,
+			BlocklistRules:              subscriptionForm.BlocklistRules,
+			KeeplistRules:               subscriptionForm.KeeplistRules,
+			KeepFilterEntryRules:        subscriptionForm.KeepFilterEntryRules,
+			BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
+			FetchViaProxy:               subscriptionForm.FetchViaProxy,
+			DisableHTTP2:                subscriptionForm.DisableHTTP2,
+			BackfillHistory:             subscriptionForm.BackfillHistory,
+			ProxyURL:                    subscriptionForm.ProxyURL,
+			WebPageItemSelector:         subscriptionForm.WebPageItemSelector,
+			WebPageTitleSelector:        subscriptionForm.WebPageTitleSelector,
+			WebPageLinkSelector:         subscriptionForm.WebPageLinkSelector,
+			WebPageDateSelector:         subscriptionForm.WebPageDateSelector,
+			WebPageContentSelector:      subscriptionForm.WebPageContentSelector,
+		})
+		if localizedError != nil {
+			v.Set("form", subscriptionForm)
+			v.Set("errorMessage", localizedError.Translate(user.Language))
+			html.OK(w, r, v.Render("add_subscription"))
+			return
+		}
+
+		html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feed.ID))
+		return
+	}
+
 	var rssBridgeURL string
 	var rssBridgeToken string
 	if intg, err := h.store.Integration(user.ID); err == nil && intg != nil && intg.RSSBridgeEnabled {
@@ -113,6 +152,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 				BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
 				FetchViaProxy:               subscriptionForm.FetchViaProxy,
 				DisableHTTP2:                subscriptionForm.DisableHTTP2,
+				BackfillHistory:             subscriptionForm.BackfillHistory,
 				ProxyURL:                    subscriptionForm.ProxyURL,
 			},
 		})
@@ -143,6 +183,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 			BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
 			FetchViaProxy:               subscriptionForm.FetchViaProxy,
 			DisableHTTP2:                subscriptionForm.DisableHTTP2,
+			BackfillHistory:             subscriptionForm.BackfillHistory,
 			ProxyURL:                    subscriptionForm.ProxyURL,
 		})
 		if localizedError != nil {
@@ -69,6 +69,8 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/feed/{feedID}/edit", handler.showEditFeedPage).Name("editFeed").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/remove", handler.removeFeed).Name("removeFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/update", handler.updateFeed).Name("updateFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/filters/preview", handler.previewFeedFilters).Name("previewFeedFilters").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/filters/apply", handler.applyFeedFilters).Name("applyFeedFilters").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/entries", handler.showFeedEntriesPage).Name("feedEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entries/all", handler.showFeedEntriesAllPage).Name("feedEntriesAll").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entry/{entryID}", handler.showFeedEntryPage).Name("feedEntry").Methods(http.MethodGet)
@@ -126,6 +128,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/integrations", handler.showIntegrationPage).Name("integrations").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/integration", handler.updateIntegration).Name("updateIntegration").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/about", handler.showAboutPage).Name("about").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/workers", handler.showWorkersPage).Name("workers").Methods(http.MethodGet)
 
 	// Session pages.
 	uiRouter.HandleFunc("/sessions", handler.showSessionsPage).Name("sessions").Methods(http.MethodGet)
@@ -137,6 +140,17 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/keys/create", handler.showCreateAPIKeyPage).Name("createAPIKey").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/save", handler.saveAPIKey).Name("saveAPIKey").Methods(http.MethodPost)
 
+	// Public feeds pages.
+	uiRouter.HandleFunc("/public-feeds", handler.showPublicFeedsPage).Name("publicFeeds").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/public-feeds/{publicFeedID}/remove", handler.removePublicFeed).Name("removePublicFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/public-feeds/create", handler.showCreatePublicFeedPage).Name("createPublicFeed").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/public-feeds/save", handler.savePublicFeed).Name("savePublicFeed").Methods(http.MethodPost)
+
+	// Newsletters pages.
+	uiRouter.HandleFunc("/newsletters", handler.showNewslettersPage).Name("newsletters").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/newsletters/save", handler.saveNewsletterFeed).Name("saveNewsletterFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/newsletter/attachment/{token}", handler.showNewsletterAttachment).Name("newsletterAttachment").Methods(http.MethodGet)
+
 	// OPML pages.
 	uiRouter.HandleFunc("/export", handler.exportFeeds).Name("export").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
@@ -19,6 +19,14 @@ func ValidateCategoryCreation(store *storage.Storage, userID int64, request *mod
 		return locale.NewLocalizedError("error.category_already_exists")
 	}
 
+	if err := ValidateFilterRules(request.BlockFilterEntryRules, "block"); err != nil {
+		return err
+	}
+
+	if err := ValidateFilterRules(request.KeepFilterEntryRules, "keep"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -34,5 +42,17 @@ func ValidateCategoryModification(store *storage.Storage, userID, categoryID int
 		}
 	}
 
+	if request.BlockFilterEntryRules != nil {
+		if err := ValidateFilterRules(*request.BlockFilterEntryRules, "block"); err != nil {
+			return err
+		}
+	}
+
+	if request.KeepFilterEntryRules != nil {
+		if err := ValidateFilterRules(*request.KeepFilterEntryRules, "keep"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -18,6 +18,16 @@ func ValidateEntriesStatusUpdateRequest(request *model.EntriesStatusUpdateReques
 	return ValidateEntryStatus(request.Status)
 }
 
+// ValidateEntriesFilterRequest makes sure blocked entries are either marked as read or removed.
+func ValidateEntriesFilterRequest(request *model.EntriesFilterRequest) error {
+	switch request.Status {
+	case model.EntryStatusRead, model.EntryStatusRemoved:
+		return nil
+	}
+
+	return fmt.Errorf(`invalid entry status, valid status values are: "%s" and "%s"`, model.EntryStatusRead, model.EntryStatusRemoved)
+}
+
 // ValidateEntryStatus makes sure the entry status is valid.
 func ValidateEntryStatus(status string) error {
 	switch status {
@@ -34,6 +34,20 @@ func TestValidateEntriesStatusUpdateRequest(t *testing.T) {
 	}
 }
 
+func TestValidateEntriesFilterRequest(t *testing.T) {
+	for _, status := range []string{model.EntryStatusRead, model.EntryStatusRemoved} {
+		if err := ValidateEntriesFilterRequest(&model.EntriesFilterRequest{Status: status}); err != nil {
+			t.Errorf(`The status %q should be accepted`, status)
+		}
+	}
+
+	for _, status := range []string{model.EntryStatusUnread, "", "invalid"} {
+		if err := ValidateEntriesFilterRequest(&model.EntriesFilterRequest{Status: status}); err == nil {
+			t.Errorf(`The status %q should be rejected`, status)
+		}
+	}
+}
+
 func TestValidateEntryStatus(t *testing.T) {
 	for _, status := range []string{model.EntryStatusRead, model.EntryStatusUnread, model.EntryStatusRemoved} {
 		if err := ValidateEntryStatus(status); err != nil {
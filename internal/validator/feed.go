@@ -4,8 +4,15 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"crypto/tls"
+	"maps"
+	"slices"
+
+	"golang.org/x/net/http/httpguts"
+
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/webpage"
 	"miniflux.app/v2/internal/storage"
 )
 
@@ -35,10 +42,40 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
 	}
 
+	if err := ValidateFilterRules(request.BlockFilterEntryRules, "block"); err != nil {
+		return err
+	}
+
+	if err := ValidateFilterRules(request.KeepFilterEntryRules, "keep"); err != nil {
+		return err
+	}
+
+	if err := ValidateEntryActionRules(request.EntryActionRules); err != nil {
+		return err
+	}
+
 	if request.ProxyURL != "" && !IsValidURL(request.ProxyURL) {
 		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
 	}
 
+	if err := ValidateFeedCustomHeaders(request.CustomHeaders); err != nil {
+		return err
+	}
+
+	if err := ValidateFeedClientCertificate(request.ClientCertificate, request.ClientPrivateKey); err != nil {
+		return err
+	}
+
+	if err := ValidateWebPageSelectors(
+		request.WebPageItemSelector,
+		request.WebPageTitleSelector,
+		request.WebPageLinkSelector,
+		request.WebPageDateSelector,
+		request.WebPageContentSelector,
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -92,6 +129,24 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.BlockFilterEntryRules != nil {
+		if err := ValidateFilterRules(*request.BlockFilterEntryRules, "block"); err != nil {
+			return err
+		}
+	}
+
+	if request.KeepFilterEntryRules != nil {
+		if err := ValidateFilterRules(*request.KeepFilterEntryRules, "keep"); err != nil {
+			return err
+		}
+	}
+
+	if request.EntryActionRules != nil {
+		if err := ValidateEntryActionRules(*request.EntryActionRules); err != nil {
+			return err
+		}
+	}
+
 	if request.ProxyURL != nil {
 		if *request.ProxyURL == "" {
 			return locale.NewLocalizedError("error.proxy_url_not_empty")
@@ -102,5 +157,112 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.CustomHeaders != nil {
+		if err := ValidateFeedCustomHeaders(*request.CustomHeaders); err != nil {
+			return err
+		}
+	}
+
+	for _, selector := range []*string{
+		request.WebPageItemSelector,
+		request.WebPageTitleSelector,
+		request.WebPageLinkSelector,
+		request.WebPageDateSelector,
+		request.WebPageContentSelector,
+	} {
+		if selector != nil {
+			if err := validateWebPageSelector(*selector); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateFeedCustomHeaders validates the custom HTTP headers sent when fetching a feed.
+func ValidateFeedCustomHeaders(headers model.FeedHeaders) *locale.LocalizedError {
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		value := headers[name]
+		if !httpguts.ValidHeaderFieldName(name) || value == "" || !httpguts.ValidHeaderFieldValue(value) {
+			return locale.NewLocalizedError("error.feed_invalid_custom_header", name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateFeedClientCertificate validates the PEM encoded certificate and private key used for mutual TLS authentication.
+// Both are optional, but one can't be used without the other.
+func ValidateFeedClientCertificate(certificate, privateKey string) *locale.LocalizedError {
+	if certificate == "" && privateKey == "" {
+		return nil
+	}
+
+	if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+		return locale.NewLocalizedError("error.feed_invalid_client_certificate")
+	}
+
+	return nil
+}
+
+// ValidateWebPageSelectors validates the CSS selectors used to build a feed from a web page.
+// The other selectors are relative to the items, so they can't be used without an item selector.
+func ValidateWebPageSelectors(itemSelector string, otherSelectors ...string) *locale.LocalizedError {
+	if err := validateWebPageSelector(itemSelector); err != nil {
+		return err
+	}
+
+	for _, selector := range otherSelectors {
+		if selector == "" {
+			continue
+		}
+
+		if itemSelector == "" {
+			return locale.NewLocalizedError("error.feed_webpage_item_selector_required")
+		}
+
+		if err := validateWebPageSelector(selector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateWebPageSelector(selector string) *locale.LocalizedError {
+	if selector == "" {
+		return nil
+	}
+
+	if err := webpage.ValidateSelector(selector); err != nil {
+		return locale.NewLocalizedError("error.feed_invalid_webpage_selector", selector)
+	}
+
+	return nil
+}
+
+// ValidateFeedFilterTest validates the rules given to test the filters of a feed.
+func ValidateFeedFilterTest(request *model.FeedFilterTestRequest) *locale.LocalizedError {
+	if request.BlocklistRules != nil && !IsValidRegex(*request.BlocklistRules) {
+		return locale.NewLocalizedError("error.feed_invalid_blocklist_rule")
+	}
+
+	if request.KeeplistRules != nil && !IsValidRegex(*request.KeeplistRules) {
+		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
+	}
+
+	if request.BlockFilterEntryRules != nil {
+		if err := ValidateFilterRules(*request.BlockFilterEntryRules, "block"); err != nil {
+			return err
+		}
+	}
+
+	if request.KeepFilterEntryRules != nil {
+		if err := ValidateFilterRules(*request.KeepFilterEntryRules, "keep"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
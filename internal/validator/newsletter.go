@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateNewsletterFeedCreation validates the title and the category of a new newsletter feed.
+func ValidateNewsletterFeedCreation(store *storage.Storage, userID int64, request *model.NewsletterFeedCreationRequest) *locale.LocalizedError {
+	if request.Title == "" {
+		return locale.NewLocalizedError("error.title_required")
+	}
+
+	if request.CategoryID == 0 || !store.CategoryIDExists(userID, request.CategoryID) {
+		return locale.NewLocalizedError("error.category_not_found")
+	}
+
+	return nil
+}
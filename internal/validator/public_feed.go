@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidatePublicFeedCreation validates the title and the source of a new public feed.
+func ValidatePublicFeedCreation(store *storage.Storage, userID int64, request *model.PublicFeedCreationRequest) *locale.LocalizedError {
+	if request.Title == "" {
+		return locale.NewLocalizedError("error.title_required")
+	}
+
+	switch request.Source {
+	case model.PublicFeedSourceCategory:
+		if request.CategoryID == 0 || !store.CategoryIDExists(userID, request.CategoryID) {
+			return locale.NewLocalizedError("error.category_not_found")
+		}
+	case model.PublicFeedSourceTag:
+		if request.Tag == "" {
+			return locale.NewLocalizedError("error.public_feed_tag_required")
+		}
+	case model.PublicFeedSourceSearch:
+		if request.SearchQuery == "" {
+			return locale.NewLocalizedError("error.public_feed_search_query_required")
+		}
+	case model.PublicFeedSourceStarred:
+	default:
+		return locale.NewLocalizedError("error.public_feed_invalid_source")
+	}
+
+	if store.PublicFeedExists(userID, request.Title) {
+		return locale.NewLocalizedError("error.public_feed_already_exists")
+	}
+
+	return nil
+}
@@ -4,12 +4,15 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"errors"
 	"slices"
 	"strings"
 	"unicode"
 
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/timezone"
 )
@@ -130,13 +133,25 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 	}
 
 	if changes.BlockFilterEntryRules != nil {
-		if err := isValidFilterRules(*changes.BlockFilterEntryRules, "block"); err != nil {
+		if err := ValidateFilterRules(*changes.BlockFilterEntryRules, "block"); err != nil {
 			return err
 		}
 	}
 
 	if changes.KeepFilterEntryRules != nil {
-		if err := isValidFilterRules(*changes.KeepFilterEntryRules, "keep"); err != nil {
+		if err := ValidateFilterRules(*changes.KeepFilterEntryRules, "keep"); err != nil {
+			return err
+		}
+	}
+
+	if changes.EntryActionRules != nil {
+		if err := ValidateEntryActionRules(*changes.EntryActionRules); err != nil {
+			return err
+		}
+	}
+
+	if changes.DuplicateEntriesPolicy != nil {
+		if err := validateDuplicateEntriesPolicy(*changes.DuplicateEntriesPolicy); err != nil {
 			return err
 		}
 	}
@@ -229,6 +244,13 @@ func validateCategoriesSortingOrder(order string) *locale.LocalizedError {
 	return nil
 }
 
+func validateDuplicateEntriesPolicy(policy string) *locale.LocalizedError {
+	if _, found := model.DuplicateEntriesPolicies()[policy]; !found {
+		return locale.NewLocalizedError("error.invalid_duplicate_entries_policy")
+	}
+	return nil
+}
+
 func validateDisplayMode(displayMode string) *locale.LocalizedError {
 	if displayMode != "fullscreen" && displayMode != "standalone" && displayMode != "minimal-ui" && displayMode != "browser" {
 		return locale.NewLocalizedError("error.invalid_display_mode")
@@ -258,12 +280,30 @@ func validateMediaPlaybackRate(mediaPlaybackRate float64) *locale.LocalizedError
 	return nil
 }
 
-func isValidFilterRules(filterEntryRules string, filterType string) *locale.LocalizedError {
+// ValidateFilterRules validates block or keep filter rules, one rule per line.
+// Lines are either "FieldName=RegEx" pairs or boolean expressions.
+func ValidateFilterRules(filterEntryRules string, filterType string) *locale.LocalizedError {
 	// Valid Format: FieldName=RegEx\nFieldName=RegEx...
-	fieldNames := []string{"EntryTitle", "EntryURL", "EntryCommentsURL", "EntryContent", "EntryAuthor", "EntryTag", "EntryDate"}
+	fieldNames := filter.LegacyFieldNames
 
 	rules := strings.Split(filterEntryRules, "\n")
 	for i, rule := range rules {
+		rule = strings.TrimSuffix(rule, "\r")
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		if filter.IsExpressionRule(rule) {
+			if err := filter.ValidateExpression(rule); err != nil {
+				var expressionErr *filter.ExpressionError
+				if errors.As(err, &expressionErr) {
+					return locale.NewLocalizedError("error.settings_"+filterType+"_rule_invalid_expression", i+1, expressionErr.Column, expressionErr.Message)
+				}
+				return locale.NewLocalizedError("error.settings_"+filterType+"_rule_invalid_expression", i+1, 1, err.Error())
+			}
+			continue
+		}
+
 		// Check if rule starts with a valid fieldName
 		idx := slices.IndexFunc(fieldNames, func(fieldName string) bool { return strings.HasPrefix(rule, fieldName) })
 		if idx == -1 {
@@ -286,6 +326,34 @@ func isValidFilterRules(filterEntryRules string, filterType string) *locale.Loca
 		if !IsValidRegex(fieldRegEx) {
 			return locale.NewLocalizedError("error.settings_"+filterType+"_rule_invalid_regex", i+1)
 		}
+
+		// Check that the rule is not an expression written without spaces
+		if filter.IsAmbiguousLegacyRule(rule) {
+			return locale.NewLocalizedError("error.settings_"+filterType+"_rule_ambiguous", i+1)
+		}
+	}
+	return nil
+}
+
+// ValidateEntryActionRules validates action rules, one "Action: Rule" per line.
+func ValidateEntryActionRules(entryActionRules string) *locale.LocalizedError {
+	for i, rule := range strings.Split(entryActionRules, "\n") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		action, err := filter.ParseActionRule(rule)
+		if err != nil {
+			var expressionErr *filter.ExpressionError
+			if errors.As(err, &expressionErr) {
+				return locale.NewLocalizedError("error.entry_action_rule_invalid_expression", i+1, expressionErr.Column, expressionErr.Message)
+			}
+			return locale.NewLocalizedError("error.entry_action_rule_invalid", i+1, err.Error())
+		}
+
+		if action.Type == filter.ActionSendTo && !integration.IsSaveIntegration(action.Argument) {
+			return locale.NewLocalizedError("error.entry_action_rule_unknown_integration", i+1, action.Argument)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package websub implements the callback used by WebSub hubs to verify the subscriptions and to deliver the content.
+//
+// Specs: https://www.w3.org/TR/websub/
+package websub // import "miniflux.app/v2/internal/websub"
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	mff "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// Serve declares the WebSub callback routes.
+func Serve(router *mux.Router, store *storage.Storage) {
+	handler := &handler{store}
+
+	sr := router.PathPrefix("/websub").Subrouter()
+	sr.HandleFunc("/{callbackID}", handler.verifyIntent).Methods(http.MethodGet).Name("webSubVerifyIntent")
+	sr.HandleFunc("/{callbackID}", handler.receiveContent).Methods(http.MethodPost).Name("webSubReceiveContent")
+}
+
+type handler struct {
+	store *storage.Storage
+}
+
+func (h *handler) verifyIntent(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.store.WebSubSubscriptionByCallbackID(request.RouteStringParam(r, "callbackID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if subscription == nil || request.QueryStringParam(r, "hub.topic", "") != subscription.TopicURL {
+		html.NotFound(w, r)
+		return
+	}
+
+	switch request.QueryStringParam(r, "hub.mode", "") {
+	case "subscribe":
+		challenge := request.QueryStringParam(r, "hub.challenge", "")
+		if challenge == "" {
+			html.BadRequest(w, r, errors.New("websub: the hub.challenge parameter is missing"))
+			return
+		}
+
+		lease := time.Duration(request.QueryIntParam(r, "hub.lease_seconds", 0)) * time.Second
+		if lease <= 0 {
+			lease = config.Opts.WebSubLeaseDuration()
+		}
+
+		if err := h.store.ActivateWebSubSubscription(subscription.FeedID, lease); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		slog.Info("WebSub subscription verified by the hub",
+			slog.Int64("user_id", subscription.UserID),
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.Duration("lease", lease),
+		)
+
+		builder := response.New(w, r)
+		builder.WithHeader("Content-Type", "text/plain; charset=utf-8")
+		builder.WithHeader("Cache-Control", "no-cache, max-age=0, must-revalidate, no-store")
+		builder.WithBody(challenge)
+		builder.Write()
+	case "denied":
+		if err := h.store.DenyWebSubSubscription(subscription.FeedID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		slog.Warn("WebSub subscription denied by the hub",
+			slog.Int64("user_id", subscription.UserID),
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.String("reason", request.QueryStringParam(r, "hub.reason", "")),
+		)
+
+		html.OK(w, r, "")
+	default:
+		// Miniflux never unsubscribes, subscriptions expire at the end of the lease.
+		html.NotFound(w, r)
+	}
+}
+
+func (h *handler) receiveContent(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.store.WebSubSubscriptionByCallbackID(request.RouteStringParam(r, "callbackID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	// Hubs may terminate the subscription when the callback returns a 410 status code.
+	if subscription == nil || subscription.State == model.WebSubStateDenied {
+		builder := response.New(w, r)
+		builder.WithStatus(http.StatusGone)
+		builder.Write()
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, config.Opts.HTTPClientMaxBodySize()))
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	// The content must be ignored when the signature is invalid, but the hub still expects a successful response.
+	if !validSignature(r.Header.Get("X-Hub-Signature"), body, subscription.Secret) {
+		slog.Warn("Ignoring WebSub content with an invalid signature",
+			slog.Int64("user_id", subscription.UserID),
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("client_ip", request.ClientIP(r)),
+		)
+		accepted(w, r)
+		return
+	}
+
+	// The hub is acknowledged right away, the entries may take a while to be scraped and processed.
+	go func() {
+		if localizedError := mff.ProcessWebSubContent(h.store, subscription.UserID, subscription.FeedID, body); localizedError != nil {
+			slog.Warn("Unable to process WebSub content",
+				slog.Int64("user_id", subscription.UserID),
+				slog.Int64("feed_id", subscription.FeedID),
+				slog.Any("error", localizedError.Error()),
+			)
+		}
+	}()
+
+	accepted(w, r)
+}
+
+func accepted(w http.ResponseWriter, r *http.Request) {
+	builder := response.New(w, r)
+	builder.WithStatus(http.StatusAccepted)
+	builder.Write()
+}
@@ -4,32 +4,198 @@
 package worker // import "miniflux.app/v2/internal/worker"
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/storage"
 )
 
-// Pool handles a pool of workers.
+// Pool handles a pool of workers processing the jobs of the persistent queue.
 type Pool struct {
-	queue chan model.Job
+	store        *storage.Storage
+	wakeUp       chan struct{}
+	instanceName string
+	workers      []*worker
+	running      sync.WaitGroup
+
+	// The refreshes in progress are canceled with jobsCtx when the shutdown timeout expires.
+	jobsCtx    context.Context
+	cancelJobs context.CancelFunc
+
+	mu        sync.Mutex
+	scheduler model.SchedulerStatus
 }
 
-// Push send a list of jobs to the queue.
-func (p *Pool) Push(jobs model.JobList) {
-	for _, job := range jobs {
-		p.queue <- job
+// Push adds a list of jobs to the queue and wakes up the idle workers.
+func (p *Pool) Push(jobs model.JobList, priority int) error {
+	if err := p.store.EnqueueJobs(jobs, priority); err != nil {
+		return err
 	}
+
+	for range min(len(jobs), cap(p.wakeUp)) {
+		select {
+		case p.wakeUp <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
 }
 
 // NewPool creates a pool of background workers.
-func NewPool(store *storage.Storage, nbWorkers int) *Pool {
+// The workers stop claiming jobs when the context is canceled.
+func NewPool(ctx context.Context, store *storage.Storage, nbWorkers int) *Pool {
 	workerPool := &Pool{
-		queue: make(chan model.Job),
+		store:        store,
+		wakeUp:       make(chan struct{}, nbWorkers),
+		instanceName: instanceName(),
+	}
+	workerPool.jobsCtx, workerPool.cancelJobs = context.WithCancel(context.Background())
+
+	if config.Opts.HasMetricsCollector() {
+		metric.BackgroundWorkersGauge.WithLabelValues(stateIdle).Set(float64(nbWorkers))
+		metric.BackgroundWorkersGauge.WithLabelValues(stateBusy).Set(0)
 	}
 
 	for i := range nbWorkers {
-		worker := &worker{id: i, store: store}
-		go worker.Run(workerPool.queue)
+		worker := &worker{id: i, name: fmt.Sprintf("%s/worker-%d", workerPool.instanceName, i), store: store}
+		workerPool.workers = append(workerPool.workers, worker)
+		workerPool.running.Add(1)
+		go func() {
+			defer workerPool.running.Done()
+			worker.Run(ctx, workerPool.jobsCtx, workerPool.wakeUp)
+		}()
 	}
 
+	go workerPool.requeueStaleJobs(ctx, config.Opts.JobQueueLockTimeout(), config.Opts.JobQueueMaxAttempts())
+
 	return workerPool
 }
+
+// Wait waits for the workers to complete their current job once the context given to NewPool is canceled.
+// The jobs still running when ctx is done are canceled, and put back in the queue by their workers before returning.
+// It returns the workers whose job was interrupted.
+func (p *Pool) Wait(ctx context.Context) []*model.WorkerStatus {
+	stopped := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		p.cancelJobs()
+		<-stopped
+	}
+
+	var interruptedWorkers []*model.WorkerStatus
+	for _, worker := range p.workers {
+		if workerStatus := worker.interruptedStatus(); workerStatus != nil {
+			interruptedWorkers = append(interruptedWorkers, workerStatus)
+		}
+	}
+
+	return interruptedWorkers
+}
+
+// SetSchedulerFrequency records the frequency of the feed scheduler and the date of its first run.
+func (p *Pool) SetSchedulerFrequency(frequency time.Duration, nextRunAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.scheduler.Frequency = int64(frequency.Seconds())
+	p.scheduler.NextRunAt = &nextRunAt
+}
+
+// RecordSchedulerRun records a run of the feed scheduler and the number of jobs it pushed to the queue.
+func (p *Pool) RecordSchedulerRun(runAt time.Time, batchSize int, nextRunAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.scheduler.LastRunAt = &runAt
+	p.scheduler.LastBatchSize = batchSize
+	p.scheduler.NextRunAt = &nextRunAt
+}
+
+// Status returns what the workers and the scheduler of this instance are doing, the state of the hosts receiving
+// their requests, and the statistics of the shared queue.
+func (p *Pool) Status() (*model.WorkerPoolStatus, error) {
+	jobQueueStats, err := p.store.JobQueueStats()
+	if err != nil {
+		return nil, err
+	}
+
+	poolStatus := &model.WorkerPoolStatus{
+		InstanceName: p.instanceName,
+		Workers:      make([]*model.WorkerStatus, 0, len(p.workers)),
+		Queue:        jobQueueStats,
+		Scheduler:    p.schedulerStatus(),
+		Hosts:        fetcher.HostLimiterInstance.Status(),
+	}
+
+	for _, worker := range p.workers {
+		poolStatus.Workers = append(poolStatus.Workers, worker.status())
+	}
+
+	return poolStatus, nil
+}
+
+// schedulerStatus returns a copy of the scheduler status that can be modified by the caller.
+func (p *Pool) schedulerStatus() model.SchedulerStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	schedulerStatus := p.scheduler
+	if schedulerStatus.LastRunAt != nil {
+		lastRunAt := *schedulerStatus.LastRunAt
+		schedulerStatus.LastRunAt = &lastRunAt
+	}
+	if schedulerStatus.NextRunAt != nil {
+		nextRunAt := *schedulerStatus.NextRunAt
+		schedulerStatus.NextRunAt = &nextRunAt
+	}
+	return schedulerStatus
+}
+
+// requeueStaleJobs periodically puts back in the queue the jobs of the workers that were stopped before completing them.
+func (p *Pool) requeueStaleJobs(ctx context.Context, lockTimeout time.Duration, maxAttempts int) {
+	for ctx.Err() == nil {
+		requeued, dropped, err := p.store.RequeueStaleJobs(lockTimeout, maxAttempts)
+		if err != nil {
+			slog.Error("Unable to requeue stale jobs", slog.Any("error", err))
+		} else if requeued > 0 || dropped > 0 {
+			slog.Warn("Stale jobs found in the queue",
+				slog.Int64("requeued_jobs", requeued),
+				slog.Int64("dropped_jobs", dropped),
+				slog.Int("max_attempts", maxAttempts),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(max(lockTimeout/2, time.Minute)):
+		}
+	}
+}
+
+// instanceName identifies the process holding the jobs in the queue.
+func instanceName() string {
+	name := config.Opts.InstanceName()
+	if name == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			name = hostname
+		} else {
+			name = "unknown"
+		}
+	}
+	return fmt.Sprintf("%s/%d", name, os.Getpid())
+}
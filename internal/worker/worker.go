@@ -4,46 +4,256 @@
 package worker // import "miniflux.app/v2/internal/worker"
 
 import (
+	"context"
 	"log/slog"
+	"sync"
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/storage"
 )
 
+// pollInterval is the delay before looking for new jobs when the queue is empty.
+// Workers are woken up earlier when jobs are pushed from this process.
+const pollInterval = 5 * time.Second
+
+// A job failed because of the database is retried after retryBaseDelay, this delay doubles after each failed attempt up to retryMaxDelay.
+const (
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = time.Hour
+)
+
+// Worker states reported in the metrics.
+const (
+	stateBusy = "busy"
+	stateIdle = "idle"
+)
+
 // worker refreshes a feed in the background.
 type worker struct {
 	id    int
+	name  string
 	store *storage.Storage
+
+	mu            sync.Mutex
+	currentJob    *model.Job
+	startedAt     time.Time
+	processedJobs int64
+
+	// interruption is the status of the worker when its job was interrupted by the shutdown.
+	interruption *model.WorkerStatus
 }
 
-// Run wait for a job and refresh the given feed.
-func (w *worker) Run(c <-chan model.Job) {
+// status returns a snapshot of what the worker is doing.
+func (w *worker) status() *model.WorkerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	workerStatus := &model.WorkerStatus{
+		ID:            w.id,
+		Name:          w.name,
+		ProcessedJobs: w.processedJobs,
+	}
+
+	if w.currentJob != nil {
+		startedAt := w.startedAt
+		workerStatus.Busy = true
+		workerStatus.JobID = w.currentJob.ID
+		workerStatus.UserID = w.currentJob.UserID
+		workerStatus.FeedID = w.currentJob.FeedID
+		workerStatus.FeedURL = w.currentJob.FeedURL
+		workerStatus.StartedAt = &startedAt
+	}
+
+	return workerStatus
+}
+
+func (w *worker) setCurrentJob(job *model.Job) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if job == nil {
+		w.processedJobs++
+	} else {
+		w.startedAt = time.Now()
+	}
+	w.currentJob = job
+}
+
+// Run claims the jobs of the queue one by one and refreshes the given feed.
+// The worker stops claiming jobs when ctx is canceled, the job being processed is completed first
+// unless jobsCtx is canceled too.
+func (w *worker) Run(ctx, jobsCtx context.Context, wakeUp <-chan struct{}) {
 	slog.Debug("Worker started",
 		slog.Int("worker_id", w.id),
+		slog.String("worker_name", w.name),
+	)
+
+	for ctx.Err() == nil {
+		jobs, err := w.store.ClaimJobs(w.name, 1)
+		if err != nil {
+			slog.Error("Unable to claim jobs from the queue",
+				slog.Int("worker_id", w.id),
+				slog.Any("error", err),
+			)
+			select {
+			case <-ctx.Done():
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		if len(jobs) == 0 {
+			select {
+			case <-ctx.Done():
+			case <-wakeUp:
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		w.process(jobsCtx, jobs[0])
+	}
+
+	slog.Debug("Worker stopped",
+		slog.Int("worker_id", w.id),
+		slog.String("worker_name", w.name),
 	)
+}
+
+func (w *worker) process(ctx context.Context, job model.Job) {
+	slog.Debug("Job received by worker",
+		slog.Int("worker_id", w.id),
+		slog.Int64("job_id", job.ID),
+		slog.String("job_kind", job.Kind),
+		slog.Int("job_priority", job.Priority),
+		slog.Int("job_attempts", job.Attempts),
+		slog.Int64("user_id", job.UserID),
+		slog.Int64("feed_id", job.FeedID),
+		slog.String("feed_url", job.FeedURL),
+	)
+
+	w.setCurrentJob(&job)
+	defer w.setCurrentJob(nil)
+
+	if config.Opts.HasMetricsCollector() {
+		metric.BackgroundWorkersGauge.WithLabelValues(stateIdle).Dec()
+		metric.BackgroundWorkersGauge.WithLabelValues(stateBusy).Inc()
+		defer func() {
+			metric.BackgroundWorkersGauge.WithLabelValues(stateBusy).Dec()
+			metric.BackgroundWorkersGauge.WithLabelValues(stateIdle).Inc()
+		}()
+	}
+
+	if job.Kind == model.JobKindBackfill {
+		w.complete(ctx, job, feedHandler.BackfillFeed(ctx, w.store, job.UserID, job.FeedID, job.ArchivePageURL))
+		return
+	}
 
-	for {
-		job := <-c
-		slog.Debug("Job received by worker",
+	startTime := time.Now()
+	localizedError := feedHandler.RefreshFeed(ctx, w.store, job.UserID, job.FeedID, false)
+
+	if config.Opts.HasMetricsCollector() {
+		status := "success"
+		if localizedError != nil {
+			status = "error"
+		}
+		metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
+	}
+
+	w.complete(ctx, job, localizedError)
+}
+
+// complete removes the job from the queue, or puts it back when it was interrupted or should be retried.
+func (w *worker) complete(ctx context.Context, job model.Job, localizedError *locale.LocalizedErrorWrapper) {
+	if localizedError != nil && ctx.Err() != nil {
+		w.release(job)
+		return
+	}
+
+	if shouldRetry(localizedError) {
+		w.retry(job)
+		return
+	}
+
+	if err := w.store.CompleteJob(job.ID); err != nil {
+		slog.Error("Unable to remove the job from the queue",
 			slog.Int("worker_id", w.id),
+			slog.Int64("job_id", job.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// release puts back the job interrupted by the shutdown in the queue, another worker processes it from the start.
+func (w *worker) release(job model.Job) {
+	interruption := w.status()
+
+	if err := w.store.ReleaseJob(job.ID); err != nil {
+		slog.Error("Unable to put back the interrupted job in the queue",
+			slog.Int("worker_id", w.id),
+			slog.Int64("job_id", job.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.interruption = interruption
+}
+
+// interruptedStatus returns the status of the worker when its job was interrupted, or nil.
+func (w *worker) interruptedStatus() *model.WorkerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.interruption
+}
+
+// retry puts back the failed job in the queue, or drops it after too many attempts.
+func (w *worker) retry(job model.Job) {
+	maxAttempts := config.Opts.JobQueueMaxAttempts()
+	delay := retryDelay(job.Attempts)
+
+	dropped, err := w.store.RetryJob(job.ID, delay, maxAttempts)
+	switch {
+	case err != nil:
+		slog.Error("Unable to put back the failed job in the queue",
+			slog.Int("worker_id", w.id),
+			slog.Int64("job_id", job.ID),
+			slog.Any("error", err),
+		)
+	case dropped:
+		slog.Warn("Job dropped from the queue after too many failed attempts",
+			slog.Int("worker_id", w.id),
+			slog.Int64("job_id", job.ID),
+			slog.Int64("user_id", job.UserID),
+			slog.Int64("feed_id", job.FeedID),
+			slog.Int("max_attempts", maxAttempts),
+		)
+	default:
+		slog.Debug("Failed job put back in the queue",
+			slog.Int("worker_id", w.id),
+			slog.Int64("job_id", job.ID),
 			slog.Int64("user_id", job.UserID),
 			slog.Int64("feed_id", job.FeedID),
-			slog.String("feed_url", job.FeedURL),
+			slog.Int("job_attempts", job.Attempts),
+			slog.Duration("retry_delay", delay),
 		)
+	}
+}
 
-		startTime := time.Now()
-		localizedError := feedHandler.RefreshFeed(w.store, job.UserID, job.FeedID, false)
+// shouldRetry returns true when the job failed because of the database.
+// The errors of the feed itself are not retried, they are counted once per check
+// and the next check is scheduled by the refresh according to the error.
+func shouldRetry(localizedError *locale.LocalizedErrorWrapper) bool {
+	return localizedError != nil && localizedError.TranslationKey() == "error.database_error"
+}
 
-		if config.Opts.HasMetricsCollector() {
-			status := "success"
-			if localizedError != nil {
-				status = "error"
-			}
-			metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
-		}
-	}
+// retryDelay returns the delay before the next attempt of a job that failed the given number of times.
+func retryDelay(attempts int) time.Duration {
+	return min(retryBaseDelay<<min(max(attempts-1, 0), 16), retryMaxDelay)
 }
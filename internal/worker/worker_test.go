@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker // import "miniflux.app/v2/internal/worker"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+)
+
+func TestWorkerStatus(t *testing.T) {
+	w := &worker{id: 1, name: "test/worker-1"}
+
+	workerStatus := w.status()
+	if workerStatus.Busy || workerStatus.StartedAt != nil || workerStatus.ProcessedJobs != 0 {
+		t.Fatalf(`A new worker should be idle, got %+v`, workerStatus)
+	}
+
+	w.setCurrentJob(&model.Job{ID: 10, UserID: 2, FeedID: 3, FeedURL: "https://example.org/feed.xml"})
+
+	workerStatus = w.status()
+	if !workerStatus.Busy || workerStatus.StartedAt == nil {
+		t.Fatalf(`The worker should be busy, got %+v`, workerStatus)
+	}
+
+	if workerStatus.JobID != 10 || workerStatus.UserID != 2 || workerStatus.FeedID != 3 || workerStatus.FeedURL != "https://example.org/feed.xml" {
+		t.Errorf(`The current job is not reported, got %+v`, workerStatus)
+	}
+
+	w.setCurrentJob(nil)
+
+	workerStatus = w.status()
+	if workerStatus.Busy || workerStatus.FeedID != 0 || workerStatus.StartedAt != nil {
+		t.Errorf(`The worker should be idle after its job, got %+v`, workerStatus)
+	}
+
+	if workerStatus.ProcessedJobs != 1 {
+		t.Errorf(`Unexpected number of processed jobs, got %d instead of 1`, workerStatus.ProcessedJobs)
+	}
+}
+
+func TestPoolSchedulerStatus(t *testing.T) {
+	pool := &Pool{}
+
+	if schedulerStatus := pool.schedulerStatus(); schedulerStatus.NextRunAt != nil || schedulerStatus.LastRunAt != nil {
+		t.Fatalf(`The scheduler should not be reported before it starts, got %+v`, schedulerStatus)
+	}
+
+	startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pool.SetSchedulerFrequency(time.Minute, startedAt.Add(time.Minute))
+	pool.RecordSchedulerRun(startedAt.Add(time.Minute), 5, startedAt.Add(2*time.Minute))
+
+	schedulerStatus := pool.schedulerStatus()
+	if schedulerStatus.Frequency != 60 {
+		t.Errorf(`Unexpected frequency, got %d instead of 60`, schedulerStatus.Frequency)
+	}
+
+	if !schedulerStatus.LastRunAt.Equal(startedAt.Add(time.Minute)) {
+		t.Errorf(`Unexpected last run, got %v`, schedulerStatus.LastRunAt)
+	}
+
+	if !schedulerStatus.NextRunAt.Equal(startedAt.Add(2 * time.Minute)) {
+		t.Errorf(`Unexpected next run, got %v`, schedulerStatus.NextRunAt)
+	}
+
+	if schedulerStatus.LastBatchSize != 5 {
+		t.Errorf(`Unexpected batch size, got %d instead of 5`, schedulerStatus.LastBatchSize)
+	}
+
+	// The status returned to the caller must not share its dates with the pool.
+	*schedulerStatus.NextRunAt = time.Time{}
+	if pool.schedulerStatus().NextRunAt.IsZero() {
+		t.Error(`The scheduler status of the pool was modified by the caller`)
+	}
+}
+
+func TestPoolWaitForStoppedWorkers(t *testing.T) {
+	pool := &Pool{workers: []*worker{{id: 0}, {id: 1}}}
+
+	if interruptedWorkers := pool.Wait(context.Background()); len(interruptedWorkers) != 0 {
+		t.Errorf(`No worker should be interrupted, got %d`, len(interruptedWorkers))
+	}
+}
+
+func TestPoolWaitCancelsJobsAtDeadline(t *testing.T) {
+	busyWorker := &worker{id: 0, name: "test/worker-0"}
+	pool := &Pool{workers: []*worker{busyWorker, {id: 1}}}
+	pool.jobsCtx, pool.cancelJobs = context.WithCancel(context.Background())
+
+	// The busy worker returns once its job is canceled, like a worker refreshing a feed.
+	busyWorker.setCurrentJob(&model.Job{ID: 10, UserID: 2, FeedID: 3})
+	pool.running.Add(1)
+	go func() {
+		defer pool.running.Done()
+		<-pool.jobsCtx.Done()
+
+		busyWorker.mu.Lock()
+		busyWorker.interruption = &model.WorkerStatus{ID: 0, Busy: true, JobID: 10, FeedID: 3}
+		busyWorker.mu.Unlock()
+		busyWorker.setCurrentJob(nil)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	interruptedWorkers := pool.Wait(ctx)
+	if len(interruptedWorkers) != 1 || interruptedWorkers[0].JobID != 10 {
+		t.Fatalf(`The job of the busy worker should be interrupted, got %+v`, interruptedWorkers)
+	}
+
+	if pool.jobsCtx.Err() == nil {
+		t.Error(`The jobs should be canceled at the deadline`)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	scenarios := map[int]time.Duration{
+		0:  time.Minute,
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		3:  4 * time.Minute,
+		7:  time.Hour,
+		50: time.Hour,
+	}
+
+	for attempts, expected := range scenarios {
+		if delay := retryDelay(attempts); delay != expected {
+			t.Errorf(`Unexpected retry delay after %d attempts, got %v instead of %v`, attempts, delay, expected)
+		}
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	// The errors of the feed increment its error counter, retrying them would count the same check several times.
+	feedErrors := []*locale.LocalizedErrorWrapper{
+		nil,
+		locale.NewLocalizedErrorWrapper(errors.New("timeout"), "error.network_timeout", "https://example.org/feed.xml"),
+		locale.NewLocalizedErrorWrapper(errors.New("status code 503"), "error.http_unexpected_status_code", 503),
+		locale.NewLocalizedErrorWrapper(errors.New("invalid feed"), "error.unable_to_parse_feed", "invalid feed"),
+		locale.NewLocalizedErrorWrapper(feedHandler.ErrFeedGone, "error.feed_gone"),
+		locale.NewLocalizedErrorWrapper(feedHandler.ErrFeedNotFound, "error.feed_not_found"),
+	}
+
+	for _, localizedError := range feedErrors {
+		if shouldRetry(localizedError) {
+			t.Errorf(`The job should not be retried after the error %v`, localizedError.TranslationKey())
+		}
+	}
+
+	databaseError := locale.NewLocalizedErrorWrapper(errors.New("connection refused"), "error.database_error", "connection refused")
+	if !shouldRetry(databaseError) {
+		t.Error(`The job should be retried after a database error`)
+	}
+}